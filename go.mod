@@ -5,6 +5,7 @@ go 1.25.3
 require (
 	cloud.google.com/go/iam v1.5.3
 	cloud.google.com/go/longrunning v0.7.0
+	github.com/bufbuild/protocompile v0.14.1
 	github.com/cbroglie/mustache v1.4.0
 	github.com/go-git/go-git/v5 v5.16.3
 	github.com/google/go-cmp v0.7.0
@@ -0,0 +1,127 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"slices"
+	"strings"
+)
+
+// DocumentationOverride replaces text in the documentation of an API element,
+// usually to fix broken comments in googleapis. The overrides apply to all
+// languages, unless Languages is set.
+type DocumentationOverride struct {
+	// ID is the fully qualified element ID (e.g., .google.cloud.dialogflow.v2.Message.field).
+	ID string `yaml:"id"`
+
+	// Match is the text to match in the documentation.
+	Match string `yaml:"match"`
+
+	// Replace is the replacement text.
+	Replace string `yaml:"replace"`
+
+	// Languages limits the override to these languages. Empty means all
+	// languages.
+	Languages []string `yaml:"languages,omitempty"`
+}
+
+// PaginationOverride selects the items field of a paginated method, for
+// responses with more than one repeated field. The overrides apply to all
+// languages, unless Languages is set.
+//
+// Only the languages generated by sidekick (Rust and Dart) support pagination
+// overrides. The Go and Python generators detect pagination on their own.
+type PaginationOverride struct {
+	// ID is the fully qualified method ID (e.g., .google.cloud.sql.v1.Service.Method).
+	ID string `yaml:"id"`
+
+	// ItemField is the name of the field used for items.
+	ItemField string `yaml:"item_field"`
+
+	// Languages limits the override to these languages. Empty means all
+	// languages.
+	Languages []string `yaml:"languages,omitempty"`
+}
+
+// LibraryDocumentationOverrides returns the documentation overrides for the
+// library in the given language, in the order they apply:
+//
+//   - the embedded global overrides for the APIs of the library,
+//   - the overrides in the library,
+//   - the Rust overrides in the library, for Rust only.
+func LibraryDocumentationOverrides(library *Library, language string) ([]DocumentationOverride, error) {
+	global, err := ReadDocumentationOverrides()
+	if err != nil {
+		return nil, err
+	}
+	var overrides []DocumentationOverride
+	for _, apiPath := range GetLibraryAPIs(library) {
+		overrides = append(overrides, DocumentationOverridesForAPI(global, apiPath)...)
+	}
+	for _, o := range library.DocumentationOverrides {
+		if appliesTo(o.Languages, language) {
+			overrides = append(overrides, o)
+		}
+	}
+	if language == "rust" && library.Rust != nil {
+		for _, o := range library.Rust.DocumentationOverrides {
+			overrides = append(overrides, DocumentationOverride{ID: o.ID, Match: o.Match, Replace: o.Replace})
+		}
+	}
+	return overrides, nil
+}
+
+// LibraryPaginationOverrides returns the pagination overrides for the library
+// in the given language: the overrides in the library, followed by the Rust
+// overrides in the library, for Rust only.
+func LibraryPaginationOverrides(library *Library, language string) []PaginationOverride {
+	var overrides []PaginationOverride
+	for _, o := range library.PaginationOverrides {
+		if appliesTo(o.Languages, language) {
+			overrides = append(overrides, o)
+		}
+	}
+	if language == "rust" && library.Rust != nil {
+		for _, o := range library.Rust.PaginationOverrides {
+			overrides = append(overrides, PaginationOverride{ID: o.ID, ItemField: o.ItemField})
+		}
+	}
+	return overrides
+}
+
+// DocumentationOverridesForAPI returns the overrides for elements in the API
+// at apiPath, e.g. the overrides for `.google.cloud.secretmanager.v1.Secret`
+// are in `google/cloud/secretmanager/v1`.
+func DocumentationOverridesForAPI(overrides []DocumentationOverride, apiPath string) []DocumentationOverride {
+	prefix := APIElementPrefix(apiPath)
+	var result []DocumentationOverride
+	for _, o := range overrides {
+		if strings.HasPrefix(o.ID, prefix) {
+			result = append(result, o)
+		}
+	}
+	return result
+}
+
+// APIElementPrefix returns the prefix for the IDs of the elements in the API
+// at apiPath, e.g. `.google.cloud.secretmanager.v1.` for
+// `google/cloud/secretmanager/v1`.
+func APIElementPrefix(apiPath string) string {
+	return "." + strings.ReplaceAll(apiPath, "/", ".") + "."
+}
+
+func appliesTo(languages []string, language string) bool {
+	return len(languages) == 0 || slices.Contains(languages, language)
+}
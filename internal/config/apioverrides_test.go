@@ -0,0 +1,95 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLibraryDocumentationOverrides(t *testing.T) {
+	library := &Library{
+		Name:    "google-cloud-developerconnect-v1",
+		Channel: "google/cloud/developerconnect/v1",
+		DocumentationOverrides: []DocumentationOverride{
+			{ID: ".google.cloud.developerconnect.v1.Connection", Match: "a", Replace: "b"},
+			{ID: ".google.cloud.developerconnect.v1.Connection.name", Match: "c", Replace: "d", Languages: []string{"go", "python"}},
+		},
+		Rust: &RustCrate{
+			DocumentationOverrides: []RustDocumentationOverride{
+				{ID: ".google.cloud.developerconnect.v1.Connection", Match: "e", Replace: "f"},
+			},
+		},
+	}
+	global := DocumentationOverride{ID: ".google.cloud.developerconnect.v1.ArtifactConfig.google_artifact_registry", Match: "regsitry", Replace: "registry"}
+	for _, test := range []struct {
+		language string
+		want     []DocumentationOverride
+	}{
+		{"rust", []DocumentationOverride{global, library.DocumentationOverrides[0], {ID: ".google.cloud.developerconnect.v1.Connection", Match: "e", Replace: "f"}}},
+		{"dart", []DocumentationOverride{global, library.DocumentationOverrides[0]}},
+		{"go", []DocumentationOverride{global, library.DocumentationOverrides[0], library.DocumentationOverrides[1]}},
+	} {
+		t.Run(test.language, func(t *testing.T) {
+			got, err := LibraryDocumentationOverrides(library, test.language)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLibraryPaginationOverrides(t *testing.T) {
+	library := &Library{
+		PaginationOverrides: []PaginationOverride{
+			{ID: ".test.Service.List", ItemField: "items"},
+			{ID: ".test.Service.Search", ItemField: "results", Languages: []string{"dart"}},
+		},
+		Rust: &RustCrate{
+			PaginationOverrides: []RustPaginationOverride{{ID: ".test.Service.Find", ItemField: "found"}},
+		},
+	}
+	for _, test := range []struct {
+		language string
+		want     []PaginationOverride
+	}{
+		{"rust", []PaginationOverride{library.PaginationOverrides[0], {ID: ".test.Service.Find", ItemField: "found"}}},
+		{"dart", library.PaginationOverrides},
+	} {
+		t.Run(test.language, func(t *testing.T) {
+			got := LibraryPaginationOverrides(library, test.language)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDocumentationOverridesForAPI(t *testing.T) {
+	overrides := []DocumentationOverride{
+		{ID: ".google.cloud.secretmanager.v1.Secret"},
+		{ID: ".google.cloud.secretmanager.v1beta2.Secret"},
+		{ID: ".google.cloud.secretmanager.v1.SecretManagerService.GetSecret"},
+	}
+	got := DocumentationOverridesForAPI(overrides, "google/cloud/secretmanager/v1")
+	want := []DocumentationOverride{overrides[0], overrides[2]}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+}
@@ -39,6 +39,10 @@ type Config struct {
 	// Sources contains references to external source repositories.
 	Sources *Sources `yaml:"sources,omitempty"`
 
+	// Tools pins the versions of the external tools used to generate and
+	// release libraries.
+	Tools *Tools `yaml:"tools,omitempty"`
+
 	// Default contains default generation settings.
 	Default *Default `yaml:"default"`
 
@@ -63,6 +67,28 @@ type Sources struct {
 	Python *PythonSources `yaml:"python,omitempty"`
 }
 
+// Tools pins the versions of the external tools used to generate and
+// release libraries, so every machine produces the same output. Language
+// backends refuse to run with a different version of a pinned tool, and
+// `librarian doctor --install` installs the pinned versions into the
+// librarian cache.
+type Tools struct {
+	// Protoc is the protoc version (e.g., "25.1").
+	Protoc string `yaml:"protoc,omitempty"`
+
+	// Plugins maps protoc plugins and formatters to their versions
+	// (e.g., protoc-gen-go_gapic: v0.53.1, black: 24.1.0).
+	Plugins map[string]string `yaml:"plugins,omitempty"`
+
+	// Synthtool is the commit of github.com/googleapis/synthtool used to
+	// post-process Python libraries.
+	Synthtool string `yaml:"synthtool,omitempty"`
+
+	// Cargo maps crates installed with `cargo install` to their versions
+	// (e.g., taplo-cli: 0.9.3, cargo-semver-checks: 0.40.0).
+	Cargo map[string]string `yaml:"cargo,omitempty"`
+}
+
 // Source represents a single source repository configuration.
 type Source struct {
 	// Commit is the git commit hash or tag to use.
@@ -82,6 +108,9 @@ type Default struct {
 
 	// Rust contains Rust-specific default configuration.
 	Rust *RustDefault `yaml:"rust,omitempty"`
+
+	// Dart contains Dart-specific default configuration.
+	Dart *DartDefault `yaml:"dart,omitempty"`
 }
 
 // DefaultGenerate contains default generation configuration.
@@ -180,6 +209,14 @@ type Library struct {
 	// CopyrightYear is the copyright year for the library.
 	CopyrightYear string `yaml:"copyright_year,omitempty"`
 
+	// DocumentationOverrides fixes the documentation of API elements in all
+	// languages.
+	DocumentationOverrides []DocumentationOverride `yaml:"documentation_overrides,omitempty"`
+
+	// PaginationOverrides selects the items field of paginated methods in all
+	// languages.
+	PaginationOverrides []PaginationOverride `yaml:"pagination_overrides,omitempty"`
+
 	// Rust contains Rust-specific library configuration.
 	Rust *RustCrate `yaml:"rust,omitempty"`
 
@@ -192,6 +229,12 @@ type Library struct {
 	// Dart contains Dart-specific library configuration.
 	Dart *DartPackage `yaml:"dart,omitempty"`
 
+	// Java contains Java-specific library configuration.
+	Java *JavaPackage `yaml:"java,omitempty"`
+
+	// Nodejs contains Node.js-specific library configuration.
+	Nodejs *NodejsPackage `yaml:"nodejs,omitempty"`
+
 	// APIServiceConfigs maps API paths to their service config file paths (runtime only, not serialized).
 	// For single-API libraries: map[API]serviceConfigPath
 	// For multi-API libraries: map[APIs[0]]path1, map[APIs[1]]path2, etc.
@@ -263,8 +306,8 @@ func (c *Config) Write(path string) error {
 }
 
 // ReadDocumentationOverrides reads the embedded documentation overrides.
-func ReadDocumentationOverrides() ([]RustDocumentationOverride, error) {
-	var overrides []RustDocumentationOverride
+func ReadDocumentationOverrides() ([]DocumentationOverride, error) {
+	var overrides []DocumentationOverride
 	if err := yaml.Unmarshal(documentationOverridesYAML, &overrides); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal documentation overrides: %w", err)
 	}
@@ -75,6 +75,11 @@ func initRust() *Default {
 					Package: "google-cloud-api",
 					Source:  "google.api",
 				},
+				{
+					Name:    "auth",
+					Package: "google-cloud-auth",
+					UsedIf:  "media",
+				},
 				{
 					Name:    "async-trait",
 					Package: "async-trait",
@@ -111,6 +116,11 @@ func initRust() *Default {
 					Package: "google-cloud-type",
 					Source:  "google.type",
 				},
+				{
+					Name:    "http",
+					Package: "http",
+					UsedIf:  "media",
+				},
 				{
 					Name:    "iam_v1",
 					Package: "google-cloud-iam-v1",
@@ -91,10 +91,12 @@ type RustCrate struct {
 	// DetailedTracingAttributes indicates whether to include detailed tracing attributes.
 	DetailedTracingAttributes bool `yaml:"detailed_tracing_attributes,omitempty"`
 
-	// DocumentationOverrides contains overrides for element documentation.
+	// DocumentationOverrides contains overrides for element documentation,
+	// applied after the language-neutral overrides in the library.
 	DocumentationOverrides []RustDocumentationOverride `yaml:"documentation_overrides,omitempty"`
 
-	// PaginationOverrides contains overrides for pagination configuration.
+	// PaginationOverrides contains overrides for pagination configuration,
+	// applied after the language-neutral overrides in the library.
 	PaginationOverrides []RustPaginationOverride `yaml:"pagination_overrides,omitempty"`
 
 	// NameOverrides contains codec-level overrides for type and service names.
@@ -216,13 +218,75 @@ type GoAPI struct {
 	ProtoPackage string `yaml:"proto_package,omitempty"`
 }
 
+// DartDefault contains Dart-specific default configuration.
+type DartDefault struct {
+	// APIKeysEnvironmentVariables is a comma-separated list of environment
+	// variable names for API keys, used by packages that do not set their own.
+	APIKeysEnvironmentVariables string `yaml:"api_keys_environment_variables,omitempty"`
+
+	// IssueTrackerURL is the issue tracker linked from every package.
+	IssueTrackerURL string `yaml:"issue_tracker_url,omitempty"`
+
+	// RepositoryURL is the repository linked from every package.
+	RepositoryURL string `yaml:"repository_url,omitempty"`
+
+	// Packages maps Dart package names to their version constraints
+	// (e.g., "http": "^1.3.0").
+	Packages map[string]string `yaml:"packages,omitempty"`
+
+	// Protos maps proto packages to the Dart import that provides them
+	// (e.g., "google.protobuf": "package:google_cloud_protobuf/protobuf.dart").
+	Protos map[string]string `yaml:"protos,omitempty"`
+
+	// Prefixes maps proto packages to the prefix used when importing them
+	// (e.g., "google.protobuf": "protobuf").
+	Prefixes map[string]string `yaml:"prefixes,omitempty"`
+}
+
 // DartPackage contains Dart-specific library configuration.
 type DartPackage struct {
 	// APIKeysEnvironmentVariables is a comma-separated list of environment variable names for API keys.
 	APIKeysEnvironmentVariables string `yaml:"api_keys_environment_variables,omitempty"`
 
+	// Dependencies is a list of additional dependencies, for handwritten code.
+	Dependencies []string `yaml:"dependencies,omitempty"`
+
 	// DevDependencies is a list of development dependencies.
 	DevDependencies []string `yaml:"dev_dependencies,omitempty"`
+
+	// ExtraExports is a list of Dart export statements appended after the imports.
+	ExtraExports []string `yaml:"extra_exports,omitempty"`
+
+	// PartFile is a handwritten part file included in the main library file.
+	PartFile string `yaml:"part_file,omitempty"`
+
+	// ReadmeAfterTitleText is Markdown inserted in README.md after the title.
+	ReadmeAfterTitleText string `yaml:"readme_after_title_text,omitempty"`
+
+	// ReadmeQuickstartText is Markdown used as the quickstart section of README.md.
+	ReadmeQuickstartText string `yaml:"readme_quickstart_text,omitempty"`
+}
+
+// JavaPackage contains Java-specific library configuration.
+type JavaPackage struct {
+	// ArtifactID is the Maven artifact ID of the client library.
+	// Defaults to "google-cloud-{name}".
+	ArtifactID string `yaml:"artifact_id,omitempty"`
+
+	// GroupID is the Maven group ID of the client library.
+	// Defaults to "com.google.cloud".
+	GroupID string `yaml:"group_id,omitempty"`
+}
+
+// NodejsPackage contains Node.js-specific library configuration.
+type NodejsPackage struct {
+	// PackageName is the npm package name.
+	// Defaults to "@google-cloud/{name}" without a "google-cloud-" prefix.
+	PackageName string `yaml:"package_name,omitempty"`
+
+	// MainService is the service exported as the package default, for
+	// APIs with more than one service.
+	MainService string `yaml:"main_service,omitempty"`
 }
 
 // PythonSources contains Python-specific source repository configurations.
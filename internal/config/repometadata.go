@@ -114,6 +114,8 @@ func buildClientDocURL(language, serviceName string) string {
 	switch language {
 	case "python":
 		return fmt.Sprintf("https://cloud.google.com/python/docs/reference/%s/latest", serviceName)
+	case "nodejs":
+		return fmt.Sprintf("https://cloud.google.com/nodejs/docs/reference/%s/latest", serviceName)
 	case "rust":
 		// Rust uses docs.rs
 		return fmt.Sprintf("https://docs.rs/google-cloud-%s/latest", serviceName)
@@ -0,0 +1,277 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package language
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/dart"
+	golang "github.com/julieqiu/librarianx/internal/language/internal/go"
+	"github.com/julieqiu/librarianx/internal/language/internal/java"
+	"github.com/julieqiu/librarianx/internal/language/internal/nodejs"
+	"github.com/julieqiu/librarianx/internal/language/internal/python"
+	"github.com/julieqiu/librarianx/internal/language/internal/rust"
+	"github.com/julieqiu/librarianx/internal/language/internal/toolchain"
+)
+
+func init() {
+	Register("dart", dartLanguage{})
+	Register("go", goLanguage{})
+	Register("java", javaLanguage{})
+	Register("nodejs", nodejsLanguage{})
+	Register("python", pythonLanguage{})
+	Register("rust", rustLanguage{})
+}
+
+// unsupported returns an ErrNotSupported error for operation in language.
+func unsupported(operation, language string) error {
+	return fmt.Errorf("%s %w for %s", operation, ErrNotSupported, language)
+}
+
+// generateOnly returns the tools of backends that only invoke external
+// tools during generation; their releases only edit files.
+func generateOnly(operation string, tools func() []toolchain.Tool) []Tool {
+	if operation != OperationGenerate {
+		return nil
+	}
+	return tools()
+}
+
+type goLanguage struct{}
+
+func (goLanguage) Init(ctx context.Context, cacheDir string, cfg *config.Config) (*config.Default, error) {
+	return golang.Init(), nil
+}
+
+func (goLanguage) Create(ctx context.Context, req *Request) error {
+	return golang.Create(ctx, req.Library, req.Settings, req.RepoDir, req.GoogleapisDir, req.ServiceConfigPath)
+}
+
+func (goLanguage) Generate(ctx context.Context, req *Request) error {
+	return golang.Generate(ctx, req.Library, req.Settings, req.RepoDir, req.GoogleapisDir, req.ServiceConfigPath)
+}
+
+func (goLanguage) PostProcess(ctx context.Context, req *Request) error {
+	return unsupported("post-processing", "go")
+}
+
+func (goLanguage) Release(ctx context.Context, cfg *config.Config, configPath string) error {
+	return unsupported("release", "go")
+}
+
+func (goLanguage) Publish(ctx context.Context, cfg *config.Config, dryRun bool) error {
+	return unsupported("publish", "go")
+}
+
+func (goLanguage) Clean(ctx context.Context, req *Request) error {
+	return golang.Clean(req.Library, req.Settings, req.RepoDir)
+}
+
+func (goLanguage) Tools(cfg *config.Config, operation string) []Tool {
+	return generateOnly(operation, golang.GenerateTools)
+}
+
+type pythonLanguage struct{}
+
+func (pythonLanguage) Init(ctx context.Context, cacheDir string, cfg *config.Config) (*config.Default, error) {
+	defaults, pythonSources, err := python.Init(ctx, cacheDir)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Sources == nil {
+		cfg.Sources = &config.Sources{}
+	}
+	cfg.Sources.Python = pythonSources
+	return defaults, nil
+}
+
+func (pythonLanguage) Create(ctx context.Context, req *Request) error {
+	return python.Create(ctx, req.Library, req.Settings, req.RepoDir, req.GoogleapisDir, req.ServiceConfigPath)
+}
+
+func (pythonLanguage) Generate(ctx context.Context, req *Request) error {
+	return python.Generate(ctx, "python", req.Repo, req.RepoDir, req.Library, req.Settings, req.GoogleapisDir, req.ServiceConfigPath, req.DefaultChannel)
+}
+
+func (pythonLanguage) PostProcess(ctx context.Context, req *Request) error {
+	return python.PostProcess(ctx, req.Repo, req.RepoDir, req.Library, req.Settings)
+}
+
+func (pythonLanguage) Release(ctx context.Context, cfg *config.Config, configPath string) error {
+	return unsupported("release", "python")
+}
+
+func (pythonLanguage) Publish(ctx context.Context, cfg *config.Config, dryRun bool) error {
+	return unsupported("publish", "python")
+}
+
+func (pythonLanguage) Clean(ctx context.Context, req *Request) error {
+	return python.Clean(req.Library, req.Settings, req.RepoDir)
+}
+
+func (pythonLanguage) Tools(cfg *config.Config, operation string) []Tool {
+	tools := generateOnly(operation, python.GenerateTools)
+	if len(tools) > 0 && cfg.Tools != nil && cfg.Tools.Synthtool != "" {
+		tools = append(tools, python.SynthtoolTool())
+	}
+	return tools
+}
+
+type rustLanguage struct{}
+
+func (rustLanguage) Init(ctx context.Context, cacheDir string, cfg *config.Config) (*config.Default, error) {
+	if err := rust.SetupWorkspace("."); err != nil {
+		return nil, err
+	}
+	return rust.ConfigDefault(), nil
+}
+
+func (rustLanguage) Create(ctx context.Context, req *Request) error {
+	return rust.Create(ctx, req.Library, req.Settings, req.RepoDir, req.GoogleapisDir, req.ServiceConfigPath)
+}
+
+func (rustLanguage) Generate(ctx context.Context, req *Request) error {
+	return rust.Generate(ctx, req.Library, req.Settings, req.RepoDir, req.GoogleapisDir, req.ServiceConfigPath)
+}
+
+func (rustLanguage) PostProcess(ctx context.Context, req *Request) error {
+	return unsupported("post-processing", "rust")
+}
+
+func (rustLanguage) Release(ctx context.Context, cfg *config.Config, configPath string) error {
+	return rust.BumpVersions(ctx, cfg, configPath)
+}
+
+func (rustLanguage) Publish(ctx context.Context, cfg *config.Config, dryRun bool) error {
+	return rust.Publish(ctx, cfg, dryRun)
+}
+
+func (rustLanguage) Clean(ctx context.Context, req *Request) error {
+	return rust.Clean(req.Library, req.Settings, req.RepoDir)
+}
+
+func (rustLanguage) Tools(cfg *config.Config, operation string) []Tool {
+	if operation == OperationRelease {
+		return rust.ReleaseTools()
+	}
+	return rust.GenerateTools()
+}
+
+type dartLanguage struct{}
+
+func (dartLanguage) Init(ctx context.Context, cacheDir string, cfg *config.Config) (*config.Default, error) {
+	return dart.ConfigDefault(), nil
+}
+
+func (dartLanguage) Create(ctx context.Context, req *Request) error {
+	return dart.Create(ctx, req.Library, req.Settings, req.RepoDir, req.dartDefaults(), req.GoogleapisDir, req.ServiceConfigPath)
+}
+
+func (dartLanguage) Generate(ctx context.Context, req *Request) error {
+	return dart.Generate(ctx, req.Library, req.Settings, req.RepoDir, req.dartDefaults(), req.GoogleapisDir, req.ServiceConfigPath)
+}
+
+func (dartLanguage) PostProcess(ctx context.Context, req *Request) error {
+	return unsupported("post-processing", "dart")
+}
+
+func (dartLanguage) Release(ctx context.Context, cfg *config.Config, configPath string) error {
+	return dart.BumpVersions(ctx, cfg, configPath)
+}
+
+func (dartLanguage) Publish(ctx context.Context, cfg *config.Config, dryRun bool) error {
+	return unsupported("publish", "dart")
+}
+
+func (dartLanguage) Clean(ctx context.Context, req *Request) error {
+	return dart.Clean(req.Library, req.Settings, req.RepoDir)
+}
+
+func (dartLanguage) Tools(cfg *config.Config, operation string) []Tool {
+	return generateOnly(operation, dart.GenerateTools)
+}
+
+type javaLanguage struct{}
+
+func (javaLanguage) Init(ctx context.Context, cacheDir string, cfg *config.Config) (*config.Default, error) {
+	return java.ConfigDefault(), nil
+}
+
+func (javaLanguage) Create(ctx context.Context, req *Request) error {
+	return java.Create(ctx, req.Library, req.Settings, req.RepoDir, req.GoogleapisDir, req.ServiceConfigPath)
+}
+
+func (javaLanguage) Generate(ctx context.Context, req *Request) error {
+	return java.Generate(ctx, req.Library, req.Settings, req.RepoDir, req.GoogleapisDir, req.ServiceConfigPath)
+}
+
+func (javaLanguage) PostProcess(ctx context.Context, req *Request) error {
+	return unsupported("post-processing", "java")
+}
+
+func (javaLanguage) Release(ctx context.Context, cfg *config.Config, configPath string) error {
+	return java.BumpVersions(ctx, cfg, configPath)
+}
+
+func (javaLanguage) Publish(ctx context.Context, cfg *config.Config, dryRun bool) error {
+	return unsupported("publish", "java")
+}
+
+func (javaLanguage) Clean(ctx context.Context, req *Request) error {
+	return java.Clean(req.Library, req.Settings, req.RepoDir)
+}
+
+func (javaLanguage) Tools(cfg *config.Config, operation string) []Tool {
+	return generateOnly(operation, java.GenerateTools)
+}
+
+type nodejsLanguage struct{}
+
+func (nodejsLanguage) Init(ctx context.Context, cacheDir string, cfg *config.Config) (*config.Default, error) {
+	return nodejs.ConfigDefault(), nil
+}
+
+func (nodejsLanguage) Create(ctx context.Context, req *Request) error {
+	return nodejs.Create(ctx, req.Repo, req.RepoDir, req.Library, req.Settings, req.GoogleapisDir, req.ServiceConfigPath, req.DefaultChannel)
+}
+
+func (nodejsLanguage) Generate(ctx context.Context, req *Request) error {
+	return nodejs.Generate(ctx, req.Repo, req.RepoDir, req.Library, req.Settings, req.GoogleapisDir, req.ServiceConfigPath, req.DefaultChannel)
+}
+
+func (nodejsLanguage) PostProcess(ctx context.Context, req *Request) error {
+	return unsupported("post-processing", "nodejs")
+}
+
+func (nodejsLanguage) Release(ctx context.Context, cfg *config.Config, configPath string) error {
+	return nodejs.BumpVersions(ctx, cfg, configPath)
+}
+
+func (nodejsLanguage) Publish(ctx context.Context, cfg *config.Config, dryRun bool) error {
+	return unsupported("publish", "nodejs")
+}
+
+func (nodejsLanguage) Clean(ctx context.Context, req *Request) error {
+	return nodejs.Clean(req.Library, req.Settings, req.RepoDir)
+}
+
+func (nodejsLanguage) Tools(cfg *config.Config, operation string) []Tool {
+	if operation == OperationRelease {
+		return nodejs.ReleaseTools()
+	}
+	return nodejs.GenerateTools()
+}
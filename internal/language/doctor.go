@@ -0,0 +1,86 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package language
+
+import (
+	"context"
+
+	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/toolchain"
+)
+
+// ToolResult is the outcome of checking a single tool.
+type ToolResult = toolchain.Result
+
+// Doctor checks every external tool the backend of language invokes, for
+// both generation and release. Versions pinned in the tools section of cfg
+// must match exactly; pinned tools installed in cacheDir are used first.
+func Doctor(ctx context.Context, language string, cfg *config.Config, cacheDir string) ([]ToolResult, error) {
+	tools, err := allTools(language, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := toolchain.Activate(cacheDir, tools); err != nil {
+		return nil, err
+	}
+	return toolchain.Check(ctx, tools), nil
+}
+
+// InstallTools installs every tool version pinned in the tools section of
+// cfg into cacheDir.
+func InstallTools(ctx context.Context, cfg *config.Config, cacheDir string) error {
+	tools, err := allTools(cfg.Language, cfg)
+	if err != nil {
+		return err
+	}
+	return toolchain.Install(ctx, cacheDir, tools)
+}
+
+// Preflight verifies the tools the backend of the repository's language
+// invokes for operation, so missing tools are reported before any work
+// starts. Pinned tools installed in cacheDir are put first on the PATH, so
+// the backend invokes them.
+func Preflight(ctx context.Context, cfg *config.Config, cacheDir, operation string) error {
+	lang, err := Lookup(cfg.Language)
+	if err != nil {
+		return err
+	}
+	tools := toolchain.Pin(lang.Tools(cfg, operation), cfg.Tools)
+	if err := toolchain.Activate(cacheDir, tools); err != nil {
+		return err
+	}
+	return toolchain.Verify(ctx, tools)
+}
+
+// allTools returns the tools the backend of language invokes for generation
+// and release, with the versions pinned in cfg.
+func allTools(language string, cfg *config.Config) ([]Tool, error) {
+	lang, err := Lookup(language)
+	if err != nil {
+		return nil, err
+	}
+	var tools []Tool
+	seen := map[string]bool{}
+	for _, operation := range []string{OperationGenerate, OperationRelease} {
+		for _, tool := range lang.Tools(cfg, operation) {
+			if seen[tool.Name] {
+				continue
+			}
+			seen[tool.Name] = true
+			tools = append(tools, tool)
+		}
+	}
+	return toolchain.Pin(tools, cfg.Tools), nil
+}
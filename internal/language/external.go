@@ -0,0 +1,262 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package language
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/julieqiu/librarianx/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// externalPrefix is the name prefix of external backend executables.
+	externalPrefix = "librarian-"
+
+	// protocolVersion is the version of the external backend protocol.
+	protocolVersion = 1
+)
+
+// External is a Language implemented by an external executable.
+//
+// Each operation runs the executable with the operation name as its only
+// argument (e.g., "librarian-java generate"), writes an externalRequest as
+// JSON to its stdin and reads an externalResponse as JSON from its stdout.
+// Configuration objects are encoded with the same field names as
+// librarian.yaml. The protocol is documented in doc/language-protocol.md.
+type External struct {
+	// Name is the language name.
+	Name string
+
+	// Path is the path of the executable.
+	Path string
+}
+
+// NewExternal returns a Language backed by the executable at path.
+func NewExternal(name, path string) *External {
+	return &External{Name: name, Path: path}
+}
+
+// externalRequest is the JSON request sent to external backends.
+type externalRequest struct {
+	Protocol          int               `json:"protocol"`
+	Command           string            `json:"command"`
+	Language          string            `json:"language"`
+	Repo              string            `json:"repo,omitempty"`
+	RepoDir           string            `json:"repo_dir,omitempty"`
+	CacheDir          string            `json:"cache_dir,omitempty"`
+	ConfigPath        string            `json:"config_path,omitempty"`
+	DryRun            bool              `json:"dry_run,omitempty"`
+	Config            any               `json:"config,omitempty"`
+	Library           any               `json:"library,omitempty"`
+	Defaults          any               `json:"defaults,omitempty"`
+	Settings          *Settings         `json:"settings,omitempty"`
+	GoogleapisDir     string            `json:"googleapis_dir,omitempty"`
+	ServiceConfigPath string            `json:"service_config,omitempty"`
+	DefaultChannel    string            `json:"default_channel,omitempty"`
+	APIServiceConfigs map[string]string `json:"api_service_configs,omitempty"`
+}
+
+// externalResponse is the JSON response read from external backends. All
+// fields are optional.
+type externalResponse struct {
+	// Error describes why the operation failed.
+	Error string `json:"error,omitempty"`
+
+	// Defaults is the default configuration returned by init.
+	Defaults any `json:"defaults,omitempty"`
+
+	// Versions are the library versions after release.
+	Versions map[string]string `json:"versions,omitempty"`
+}
+
+// Init implements Language.
+func (e *External) Init(ctx context.Context, cacheDir string, cfg *config.Config) (*config.Default, error) {
+	req, err := e.configRequest("init", cfg)
+	if err != nil {
+		return nil, err
+	}
+	req.CacheDir = cacheDir
+	resp, err := e.run(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defaults := &config.Default{}
+	if err := fromConfigValue(resp.Defaults, defaults); err != nil {
+		return nil, fmt.Errorf("invalid defaults from %s: %w", e.Path, err)
+	}
+	return defaults, nil
+}
+
+// Create implements Language.
+func (e *External) Create(ctx context.Context, req *Request) error {
+	return e.runLibrary(ctx, "create", req)
+}
+
+// Generate implements Language.
+func (e *External) Generate(ctx context.Context, req *Request) error {
+	return e.runLibrary(ctx, "generate", req)
+}
+
+// PostProcess implements Language.
+func (e *External) PostProcess(ctx context.Context, req *Request) error {
+	return e.runLibrary(ctx, "post-process", req)
+}
+
+// Clean implements Language.
+func (e *External) Clean(ctx context.Context, req *Request) error {
+	return e.runLibrary(ctx, "clean", req)
+}
+
+// Release implements Language. The versions returned by the backend are
+// recorded in librarian.yaml.
+func (e *External) Release(ctx context.Context, cfg *config.Config, configPath string) error {
+	req, err := e.configRequest("release", cfg)
+	if err != nil {
+		return err
+	}
+	req.ConfigPath = configPath
+	resp, err := e.run(ctx, req)
+	if err != nil {
+		return err
+	}
+	if cfg.Versions == nil {
+		cfg.Versions = make(map[string]string)
+	}
+	for name, version := range resp.Versions {
+		cfg.Versions[name] = version
+	}
+	return cfg.Write(configPath)
+}
+
+// Publish implements Language.
+func (e *External) Publish(ctx context.Context, cfg *config.Config, dryRun bool) error {
+	req, err := e.configRequest("publish", cfg)
+	if err != nil {
+		return err
+	}
+	req.DryRun = dryRun
+	_, err = e.run(ctx, req)
+	return err
+}
+
+// Tools implements Language. External backends check their own tools, so
+// only the backend executable itself is required.
+func (e *External) Tools(cfg *config.Config, operation string) []Tool {
+	return []Tool{{
+		Name:    e.Path,
+		Install: "see doc/language-protocol.md",
+	}}
+}
+
+func (e *External) configRequest(command string, cfg *config.Config) (*externalRequest, error) {
+	value, err := toConfigValue(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &externalRequest{
+		Protocol: protocolVersion,
+		Command:  command,
+		Language: e.Name,
+		Repo:     cfg.Repo,
+		Config:   value,
+	}, nil
+}
+
+func (e *External) runLibrary(ctx context.Context, command string, req *Request) error {
+	library, err := toConfigValue(req.Library)
+	if err != nil {
+		return err
+	}
+	defaults, err := toConfigValue(req.Defaults)
+	if err != nil {
+		return err
+	}
+	_, err = e.run(ctx, &externalRequest{
+		Protocol:          protocolVersion,
+		Command:           command,
+		Language:          e.Name,
+		Repo:              req.Repo,
+		RepoDir:           req.RepoDir,
+		Library:           library,
+		Defaults:          defaults,
+		Settings:          req.Settings,
+		GoogleapisDir:     req.GoogleapisDir,
+		ServiceConfigPath: req.ServiceConfigPath,
+		DefaultChannel:    req.DefaultChannel,
+		APIServiceConfigs: req.Library.APIServiceConfigs,
+	})
+	return err
+}
+
+// run executes the backend for req and decodes its response.
+func (e *External) run(ctx context.Context, req *externalRequest) (*externalResponse, error) {
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(os.Stderr, "\nRunning: %s %s\n", e.Path, req.Command)
+	cmd := exec.CommandContext(ctx, e.Path, req.Command)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stderr = os.Stderr
+	output, runErr := cmd.Output()
+
+	resp := &externalResponse{}
+	if len(bytes.TrimSpace(output)) > 0 {
+		if err := json.Unmarshal(output, resp); err != nil && runErr == nil {
+			return nil, fmt.Errorf("invalid response from %s %s: %w", e.Path, req.Command, err)
+		}
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("%s %s failed: %s", e.Path, req.Command, resp.Error)
+	}
+	if runErr != nil {
+		return nil, fmt.Errorf("%s %s failed: %w", e.Path, req.Command, runErr)
+	}
+	return resp, nil
+}
+
+// toConfigValue converts a configuration struct to a generic value with
+// the field names used in librarian.yaml.
+func toConfigValue(v any) (any, error) {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// fromConfigValue converts a generic value with librarian.yaml field names
+// into the configuration struct out.
+func fromConfigValue(value any, out any) error {
+	if value == nil {
+		return errors.New("missing value")
+	}
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, out)
+}
@@ -22,71 +22,71 @@ import (
 	"strings"
 
 	"github.com/julieqiu/librarianx/internal/config"
-	golang "github.com/julieqiu/librarianx/internal/language/internal/go"
-	"github.com/julieqiu/librarianx/internal/language/internal/python"
-	"github.com/julieqiu/librarianx/internal/language/internal/rust"
 )
 
 // Create creates a new client library for the specified language.
-func Create(ctx context.Context, language, repo string, library *config.Library, defaults *config.Default, googleapisDir, serviceConfigPath, defaultOutput string) error {
-	switch language {
-	case "go":
-		return golang.Create(ctx, library, defaults, googleapisDir, serviceConfigPath, defaultOutput)
-	case "rust":
-		return rust.Create(ctx, library, defaults, googleapisDir, serviceConfigPath, defaultOutput)
-	case "python":
-		return python.Create(ctx, library, defaults, googleapisDir, serviceConfigPath, defaultOutput)
-	default:
-		return fmt.Errorf("unsupported language: %s", language)
+func Create(ctx context.Context, language, repo, repoDir string, library *config.Library, defaults *config.Default, googleapisDir, serviceConfigPath string) error {
+	lang, err := Lookup(language)
+	if err != nil {
+		return err
 	}
+	return lang.Create(ctx, NewRequest(repo, repoDir, library, defaults, googleapisDir, serviceConfigPath))
 }
 
 // PostProcess runs only the post-processing step (e.g., synthtool) for the specified language.
-func PostProcess(ctx context.Context, language, repo string, library *config.Library, defaults *config.Default) error {
-	switch language {
-	case "python":
-		return python.PostProcess(ctx, repo, library, defaults)
-	case "rust":
-		return fmt.Errorf("post-processing not supported for rust")
-	default:
-		return fmt.Errorf("unsupported language: %s", language)
+func PostProcess(ctx context.Context, language, repo, repoDir string, library *config.Library, defaults *config.Default) error {
+	lang, err := Lookup(language)
+	if err != nil {
+		return err
+	}
+	return lang.PostProcess(ctx, NewRequest(repo, repoDir, library, defaults, "", ""))
+}
+
+// Clean deletes the generated files of a library, preserving kept files.
+// In "channel" mode, the library of each API version is cleaned, the same
+// way generateForChannel generates them.
+func Clean(ctx context.Context, oneLibraryPer, language, repo, repoDir string, library *config.Library, defaults *config.Default) error {
+	lang, err := Lookup(language)
+	if err != nil {
+		return err
+	}
+	if oneLibraryPer != "channel" {
+		return lang.Clean(ctx, NewRequest(repo, repoDir, library, defaults, "", ""))
+	}
+	apis := config.GetLibraryAPIs(library)
+	if len(apis) == 0 {
+		return fmt.Errorf("no APIs found for library %s", library.Name)
+	}
+	for _, apiPath := range apis {
+		channelLibrary := singleChannelLibrary(library, apiPath, library.APIServiceConfigs[apiPath])
+		if err := lang.Clean(ctx, NewRequest(repo, repoDir, channelLibrary, defaults, "", "")); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
 // Generate generates a library based on the one_library_per mode.
 // For "api" mode: generates once for all APIs in the library.
 // For "channel" mode: generates separately for each API version.
-func Generate(ctx context.Context, oneLibraryPer, language, repo string, library *config.Library, defaults *config.Default, googleapisDir string) error {
+func Generate(ctx context.Context, oneLibraryPer, language, repo, repoDir string, library *config.Library, defaults *config.Default, googleapisDir string) error {
+	lang, err := Lookup(language)
+	if err != nil {
+		return err
+	}
 	switch oneLibraryPer {
 	case "api":
-		return generateForAPI(ctx, language, repo, library, defaults, googleapisDir)
+		return generateForAPI(ctx, lang, repo, repoDir, library, defaults, googleapisDir)
 	case "channel":
-		return generateForChannel(ctx, language, repo, library, defaults, googleapisDir)
+		return generateForChannel(ctx, lang, repo, repoDir, library, defaults, googleapisDir)
 	default:
 		return fmt.Errorf("invalid one_library_per value %q: must be \"api\" or \"channel\"", oneLibraryPer)
 	}
 }
 
-// generateChannel generates code for a single channel using language-specific
-// generators.  This is used internally by generateForAPI and
-// generateForChannel.
-func generateChannel(ctx context.Context, language, repo string, library *config.Library, defaults *config.Default, googleapisDir, serviceConfigPath, defaultOutput string) error {
-	switch language {
-	case "go":
-		return golang.Generate(ctx, library, defaults, googleapisDir, serviceConfigPath, defaultOutput)
-	case "rust":
-		return rust.Generate(ctx, library, defaults, googleapisDir, serviceConfigPath, defaultOutput)
-	case "python":
-		defaultAPI := getDefaultChannel(library)
-		return python.Generate(ctx, language, repo, library, defaults, googleapisDir, serviceConfigPath, defaultOutput, defaultAPI)
-	default:
-		return fmt.Errorf("unsupported language: %s", language)
-	}
-}
-
 // generateForAPI generates a single library containing all API versions.
 // Used for languages with "one_library_per: api" (Python, Go).
-func generateForAPI(ctx context.Context, language, repo string, library *config.Library, defaults *config.Default, googleapisDir string) error {
+func generateForAPI(ctx context.Context, lang Language, repo, repoDir string, library *config.Library, defaults *config.Default, googleapisDir string) error {
 	// Use the first service config path (all point to the same service YAML)
 	var primaryServiceConfigPath string
 	for _, serviceConfigPath := range library.APIServiceConfigs {
@@ -94,25 +94,30 @@ func generateForAPI(ctx context.Context, language, repo string, library *config.
 		break
 	}
 
-	return generateChannel(ctx, language, repo, library, defaults, googleapisDir, primaryServiceConfigPath, defaults.Output)
+	return lang.Generate(ctx, NewRequest(repo, repoDir, library, defaults, googleapisDir, primaryServiceConfigPath))
 }
 
 // generateForChannel generates a separate library for each API version.
 // Used for languages with "one_library_per: channel" (Rust, Dart).
-func generateForChannel(ctx context.Context, language, repo string, library *config.Library, defaults *config.Default, googleapisDir string) error {
+func generateForChannel(ctx context.Context, lang Language, repo, repoDir string, library *config.Library, defaults *config.Default, googleapisDir string) error {
 	for apiPath, serviceConfigPath := range library.APIServiceConfigs {
-		// Create a single-API library for this version
-		singleChannelLibrary := *library
-		singleChannelLibrary.Channel = apiPath
-		singleChannelLibrary.APIServiceConfigs = map[string]string{apiPath: serviceConfigPath}
-
-		if err := generateChannel(ctx, language, repo, &singleChannelLibrary, defaults, googleapisDir, serviceConfigPath, defaults.Output); err != nil {
+		channelLibrary := singleChannelLibrary(library, apiPath, serviceConfigPath)
+		if err := lang.Generate(ctx, NewRequest(repo, repoDir, channelLibrary, defaults, googleapisDir, serviceConfigPath)); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// singleChannelLibrary returns a copy of library restricted to the API
+// version apiPath.
+func singleChannelLibrary(library *config.Library, apiPath, serviceConfigPath string) *config.Library {
+	channelLibrary := *library
+	channelLibrary.Channel = apiPath
+	channelLibrary.APIServiceConfigs = map[string]string{apiPath: serviceConfigPath}
+	return &channelLibrary
+}
+
 // getDefaultChannel returns the default API path for a library.
 // The default is the latest stable version, or if no stable versions exist, the latest pre-release.
 // Version ordering: v2, v1, v2beta1, v1beta1, v2alpha1, v1alpha1.
@@ -16,35 +16,16 @@ package language
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/julieqiu/librarianx/internal/config"
-	golang "github.com/julieqiu/librarianx/internal/language/internal/go"
-	"github.com/julieqiu/librarianx/internal/language/internal/python"
-	"github.com/julieqiu/librarianx/internal/language/internal/rust"
 )
 
 // Init initializes a default config for the given language.
 // It returns the default config and updates cfg.Sources with language-specific sources.
 func Init(ctx context.Context, language, cacheDir string, cfg *config.Config) (*config.Default, error) {
-	switch language {
-	case "go":
-		return golang.Init(), nil
-	case "rust":
-		if err := rust.SetupWorkspace("."); err != nil {
-			return nil, err
-		}
-		return rust.ConfigDefault(), nil
-	case "python":
-		defaults, pythonSources, err := python.Init(ctx, cacheDir)
-		if err != nil {
-			return nil, err
-		}
-		if cfg.Sources == nil {
-			cfg.Sources = &config.Sources{}
-		}
-		cfg.Sources.Python = pythonSources
-		return defaults, nil
+	lang, err := Lookup(language)
+	if err != nil {
+		return nil, err
 	}
-	return nil, fmt.Errorf("not supported: %q", language)
+	return lang.Init(ctx, cacheDir, cfg)
 }
@@ -0,0 +1,217 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dart
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/settings"
+	"github.com/julieqiu/librarianx/internal/language/internal/staging"
+	"github.com/julieqiu/librarianx/internal/language/internal/toolchain"
+	sidekickconfig "github.com/julieqiu/librarianx/internal/sidekick/config"
+	sidekickdart "github.com/julieqiu/librarianx/internal/sidekick/dart"
+	"github.com/julieqiu/librarianx/internal/sidekick/parser"
+)
+
+// initialVersion is the version of newly created packages.
+const initialVersion = "0.1.0"
+
+// Create creates a new Dart package.
+func Create(ctx context.Context, library *config.Library, resolved *settings.Settings, repoDir string, defaults *config.DartDefault, googleapisDir, serviceConfigPath string) error {
+	if err := toolchain.Verify(ctx, GenerateTools()); err != nil {
+		return err
+	}
+	if err := Generate(ctx, library, resolved, repoDir, defaults, googleapisDir, serviceConfigPath); err != nil {
+		return err
+	}
+
+	outdir, err := outputDir(library, resolved, repoDir)
+	if err != nil {
+		return err
+	}
+
+	// Create CHANGELOG.md if it doesn't exist
+	changelogPath := filepath.Join(outdir, "CHANGELOG.md")
+	if _, err := os.Stat(changelogPath); errors.Is(err, fs.ErrNotExist) {
+		changelog := fmt.Sprintf("## %s\n\n- Initial release.\n", initialVersion)
+		if err := os.WriteFile(changelogPath, []byte(changelog), 0644); err != nil {
+			return fmt.Errorf("failed to write CHANGELOG.md: %w", err)
+		}
+	}
+	return nil
+}
+
+// Generate generates a Dart package.
+// The package is generated into a staging directory next to the output directory,
+// which only replaces the output directory if generation and formatting succeed.
+// defaults are the repository-wide Dart settings, and may be nil.
+func Generate(ctx context.Context, library *config.Library, resolved *settings.Settings, repoDir string, defaults *config.DartDefault, googleapisDir, serviceConfigPath string) error {
+	outdir, err := outputDir(library, resolved, repoDir)
+	if err != nil {
+		return err
+	}
+
+	// Keep the released version unless librarian.yaml pins one
+	version := library.Version
+	if version == "" {
+		v, err := readPubspecVersion(filepath.Join(outdir, "pubspec.yaml"))
+		if err != nil {
+			return err
+		}
+		version = v
+	}
+	if version == "" {
+		version = initialVersion
+	}
+
+	sidekickConfig, err := toSidekickConfig(library, defaults, googleapisDir, serviceConfigPath, version)
+	if err != nil {
+		return err
+	}
+	model, err := parser.CreateModel(sidekickConfig)
+	if err != nil {
+		return err
+	}
+
+	stage, err := staging.NewKept(outdir, library.Keep, cleanOutputDirectory)
+	if err != nil {
+		return err
+	}
+	if err := sidekickdart.Generate(model, stage.Dir, sidekickConfig); err != nil {
+		return stage.Fail(err)
+	}
+	return stage.Commit()
+}
+
+// outputDir returns the directory of the package for library. Packages are
+// named after their channel, e.g. google/cloud/secretmanager/v1 is generated
+// into {output}/google_cloud_secretmanager_v1, unless the library sets its
+// path. The directory is relative to repoDir. It is an error if library has
+// neither a path nor a channel.
+func outputDir(library *config.Library, resolved *settings.Settings, repoDir string) (string, error) {
+	if library.Path != "" {
+		return filepath.Join(repoDir, resolved.Output), nil
+	}
+	if library.Channel == "" {
+		return "", fmt.Errorf("no channel for library %s", library.Name)
+	}
+	name := strings.TrimPrefix(library.Channel, "google/cloud/")
+	name = strings.TrimPrefix(name, "google/")
+	return filepath.Join(repoDir, resolved.Output, "google_cloud_"+strings.ReplaceAll(name, "/", "_")), nil
+}
+
+func toSidekickConfig(library *config.Library, defaults *config.DartDefault, googleapisDir, serviceConfig, version string) (*sidekickconfig.Config, error) {
+	sidekickConfig := &sidekickconfig.Config{
+		General: sidekickconfig.GeneralConfig{
+			Language:            "dart",
+			SpecificationFormat: "protobuf",
+			ServiceConfig:       serviceConfig,
+			SpecificationSource: library.Channel,
+		},
+		Source: map[string]string{
+			"googleapis-root": googleapisDir,
+		},
+		Codec: buildCodec(library, defaults, version),
+	}
+
+	docOverrides, err := config.LibraryDocumentationOverrides(library, "dart")
+	if err != nil {
+		return nil, err
+	}
+	for _, override := range docOverrides {
+		sidekickConfig.CommentOverrides = append(sidekickConfig.CommentOverrides, sidekickconfig.DocumentationOverride{
+			ID:      override.ID,
+			Match:   override.Match,
+			Replace: override.Replace,
+		})
+	}
+	for _, override := range config.LibraryPaginationOverrides(library, "dart") {
+		sidekickConfig.PaginationOverrides = append(sidekickConfig.PaginationOverrides, sidekickconfig.PaginationOverride{
+			ID:        override.ID,
+			ItemField: override.ItemField,
+		})
+	}
+	return sidekickConfig, nil
+}
+
+func buildCodec(library *config.Library, defaults *config.DartDefault, version string) map[string]string {
+	codec := map[string]string{
+		"version": version,
+	}
+	if library.CopyrightYear != "" {
+		codec["copyright-year"] = library.CopyrightYear
+	}
+	if library.Publish != nil && library.Publish.Disabled {
+		codec["not-for-publication"] = "true"
+	}
+
+	// Repository-wide settings
+	if defaults != nil {
+		dart := defaults
+		if dart.APIKeysEnvironmentVariables != "" {
+			codec["api-keys-environment-variables"] = dart.APIKeysEnvironmentVariables
+		}
+		if dart.IssueTrackerURL != "" {
+			codec["issue-tracker-url"] = dart.IssueTrackerURL
+		}
+		if dart.RepositoryURL != "" {
+			codec["repository-url"] = dart.RepositoryURL
+		}
+		for name, constraint := range dart.Packages {
+			codec["package:"+name] = constraint
+		}
+		for protoPackage, imp := range dart.Protos {
+			codec["proto:"+protoPackage] = imp
+		}
+		for protoPackage, prefix := range dart.Prefixes {
+			codec["prefix:"+protoPackage] = prefix
+		}
+	}
+
+	// Package settings
+	if library.Dart == nil {
+		return codec
+	}
+	dart := library.Dart
+	if dart.APIKeysEnvironmentVariables != "" {
+		codec["api-keys-environment-variables"] = dart.APIKeysEnvironmentVariables
+	}
+	if len(dart.Dependencies) > 0 {
+		codec["dependencies"] = strings.Join(dart.Dependencies, ",")
+	}
+	if len(dart.DevDependencies) > 0 {
+		codec["dev-dependencies"] = strings.Join(dart.DevDependencies, ",")
+	}
+	if len(dart.ExtraExports) > 0 {
+		codec["extra-exports"] = strings.Join(dart.ExtraExports, ";")
+	}
+	if dart.PartFile != "" {
+		codec["part-file"] = dart.PartFile
+	}
+	if dart.ReadmeAfterTitleText != "" {
+		codec["readme-after-title-text"] = dart.ReadmeAfterTitleText
+	}
+	if dart.ReadmeQuickstartText != "" {
+		codec["readme-quickstart-text"] = dart.ReadmeQuickstartText
+	}
+	return codec
+}
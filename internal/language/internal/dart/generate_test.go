@@ -0,0 +1,156 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dart
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/settings"
+)
+
+func TestBuildCodec(t *testing.T) {
+	defaults := &config.DartDefault{
+		APIKeysEnvironmentVariables: "GOOGLE_API_KEY",
+		IssueTrackerURL:             "https://github.com/googleapis/google-cloud-dart/issues",
+		Packages:                    map[string]string{"http": "^1.3.0"},
+		Protos:                      map[string]string{"google.protobuf": "package:google_cloud_protobuf/protobuf.dart"},
+		Prefixes:                    map[string]string{"google.protobuf": "protobuf"},
+	}
+	for _, test := range []struct {
+		name    string
+		library *config.Library
+		want    map[string]string
+	}{
+		{
+			name:    "defaults",
+			library: &config.Library{Name: "google-cloud-secretmanager-v1", CopyrightYear: "2025"},
+			want: map[string]string{
+				"version":                        "0.1.0",
+				"copyright-year":                 "2025",
+				"api-keys-environment-variables": "GOOGLE_API_KEY",
+				"issue-tracker-url":              "https://github.com/googleapis/google-cloud-dart/issues",
+				"package:http":                   "^1.3.0",
+				"proto:google.protobuf":          "package:google_cloud_protobuf/protobuf.dart",
+				"prefix:google.protobuf":         "protobuf",
+			},
+		},
+		{
+			name: "package settings",
+			library: &config.Library{
+				Name:    "google-ai-generativelanguage-v1beta",
+				Publish: &config.LibraryPublish{Disabled: true},
+				Dart: &config.DartPackage{
+					APIKeysEnvironmentVariables: "GOOGLE_API_KEY,GEMINI_API_KEY",
+					Dependencies:                []string{"googleapis_auth"},
+					DevDependencies:             []string{"test", "mockito"},
+					ExtraExports:                []string{"export 'package:google_cloud_gax/gax.dart' show Any"},
+					PartFile:                    "src/extensions.dart",
+				},
+			},
+			want: map[string]string{
+				"version":                        "0.1.0",
+				"not-for-publication":            "true",
+				"api-keys-environment-variables": "GOOGLE_API_KEY,GEMINI_API_KEY",
+				"issue-tracker-url":              "https://github.com/googleapis/google-cloud-dart/issues",
+				"package:http":                   "^1.3.0",
+				"proto:google.protobuf":          "package:google_cloud_protobuf/protobuf.dart",
+				"prefix:google.protobuf":         "protobuf",
+				"dependencies":                   "googleapis_auth",
+				"dev-dependencies":               "test,mockito",
+				"extra-exports":                  "export 'package:google_cloud_gax/gax.dart' show Any",
+				"part-file":                      "src/extensions.dart",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := buildCodec(test.library, defaults, "0.1.0")
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOutputDir(t *testing.T) {
+	for _, test := range []struct {
+		library *config.Library
+		want    string
+	}{
+		{&config.Library{Channel: "google/cloud/secretmanager/v1"}, "/repo/generated/google_cloud_secretmanager_v1"},
+		{&config.Library{Channel: "google/ai/generativelanguage/v1beta"}, "/repo/generated/google_cloud_ai_generativelanguage_v1beta"},
+		{&config.Library{Channel: "google/type", Path: "packages/google_cloud_type"}, "/repo/packages/google_cloud_type"},
+	} {
+		t.Run(test.library.Channel, func(t *testing.T) {
+			resolved := settings.Resolve(test.library, &config.Default{Output: "generated"})
+			got, err := outputDir(test.library, resolved, "/repo")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("outputDir() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestOutputDirWithoutChannel(t *testing.T) {
+	library := &config.Library{Name: "google_cloud_secretmanager"}
+	if _, err := outputDir(library, &settings.Settings{Output: "generated"}, "/repo"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestCleanOutputDirectory(t *testing.T) {
+	outdir := t.TempDir()
+	for _, path := range []string{
+		"CHANGELOG.md",
+		"pubspec.yaml",
+		"lib/secretmanager.dart",
+		"lib/src/handwritten.dart",
+		"test/secretmanager_test.dart",
+	} {
+		full := filepath.Join(outdir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cleanOutputDirectory(outdir, []string{"lib/src", "test/"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	err := filepath.WalkDir(outdir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(outdir, path)
+		got = append(got, filepath.ToSlash(rel))
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"CHANGELOG.md", "lib/src/handwritten.dart", "test/secretmanager_test.dart"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dart
+
+import (
+	"github.com/julieqiu/librarianx/internal/config"
+)
+
+// ConfigDefault initializes a default Dart config.
+func ConfigDefault() *config.Default {
+	return &config.Default{
+		Output: "generated/",
+		Generate: &config.DefaultGenerate{
+			Auto:          true,
+			OneLibraryPer: "channel",
+			ReleaseLevel:  "preview",
+		},
+
+		Release: &config.DefaultRelease{
+			TagFormat: "{name}-v{version}",
+			Remote:    "upstream",
+			Branch:    "main",
+		},
+
+		Dart: &config.DartDefault{
+			APIKeysEnvironmentVariables: "GOOGLE_API_KEY",
+			IssueTrackerURL:             "https://github.com/googleapis/google-cloud-dart/issues",
+			RepositoryURL:               "https://github.com/googleapis/google-cloud-dart",
+
+			Packages: map[string]string{
+				"google_cloud_api":          "^0.1.0",
+				"google_cloud_iam_v1":       "^0.1.0",
+				"google_cloud_location":     "^0.1.0",
+				"google_cloud_logging_type": "^0.1.0",
+				"google_cloud_longrunning":  "^0.1.0",
+				"google_cloud_protobuf":     "^0.1.0",
+				"google_cloud_rpc":          "^0.1.0",
+				"google_cloud_type":         "^0.1.0",
+				"http":                      "^1.3.0",
+			},
+
+			Protos: map[string]string{
+				"google.api":            "package:google_cloud_api/api.dart",
+				"google.cloud.location": "package:google_cloud_location/location.dart",
+				"google.iam.v1":         "package:google_cloud_iam_v1/iam.dart",
+				"google.logging.type":   "package:google_cloud_logging_type/logging_type.dart",
+				"google.longrunning":    "package:google_cloud_longrunning/longrunning.dart",
+				"google.protobuf":       "package:google_cloud_protobuf/protobuf.dart",
+				"google.rpc":            "package:google_cloud_rpc/rpc.dart",
+				"google.type":           "package:google_cloud_type/type.dart",
+			},
+		},
+	}
+}
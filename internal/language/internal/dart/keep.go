@@ -0,0 +1,43 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dart
+
+import (
+	"slices"
+
+	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/settings"
+	"github.com/julieqiu/librarianx/internal/language/internal/staging"
+)
+
+// Clean deletes the generated files of library, preserving CHANGELOG.md and
+// the files listed in library.Keep.
+func Clean(library *config.Library, resolved *settings.Settings, repoDir string) error {
+	outdir, err := outputDir(library, resolved, repoDir)
+	if err != nil {
+		return err
+	}
+	return cleanOutputDirectory(outdir, library.Keep)
+}
+
+// alwaysKept lists the handwritten files every Dart package keeps across
+// regeneration.
+var alwaysKept = []string{"CHANGELOG.md"}
+
+// cleanOutputDirectory deletes everything in the output directory except files listed in keepPaths.
+// CHANGELOG.md is always preserved.
+func cleanOutputDirectory(outdir string, keepPaths []string) error {
+	return staging.Clean(outdir, slices.Concat(keepPaths, alwaysKept))
+}
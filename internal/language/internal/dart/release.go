@@ -0,0 +1,121 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dart
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/semver"
+	"gopkg.in/yaml.v3"
+)
+
+type pubspec struct {
+	Name      string `yaml:"name"`
+	Version   string `yaml:"version"`
+	PublishTo string `yaml:"publish_to"`
+}
+
+// BumpVersions bumps versions for all published pubspec.yaml files and
+// updates librarian.yaml.
+func BumpVersions(ctx context.Context, cfg *config.Config, configPath string) error {
+	if cfg.Versions == nil {
+		cfg.Versions = make(map[string]string)
+	}
+
+	err := filepath.WalkDir(".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != "." && (strings.HasPrefix(d.Name(), ".") || d.Name() == "build") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() != "pubspec.yaml" {
+			return nil
+		}
+
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var spec pubspec
+		if err := yaml.Unmarshal(contents, &spec); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+
+		// Workspace roots and unpublished packages have no version to bump
+		if spec.Version == "" || spec.PublishTo == "none" {
+			return nil
+		}
+
+		newVersion, err := semver.BumpMinor(spec.Version)
+		if err != nil {
+			return fmt.Errorf("failed to bump version of %s: %w", path, err)
+		}
+
+		// Update pubspec.yaml
+		lines := strings.Split(string(contents), "\n")
+		idx := -1
+		for i, line := range lines {
+			if strings.HasPrefix(line, "version:") {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			return fmt.Errorf("no version line found in %s", path)
+		}
+		lines[idx] = "version: " + newVersion
+		if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+			return err
+		}
+
+		// Update librarian.yaml
+		cfg.Versions[spec.Name] = newVersion
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Write updated config
+	return cfg.Write(configPath)
+}
+
+// readPubspecVersion returns the version in the pubspec.yaml at path, or an
+// empty string if the file does not exist.
+func readPubspecVersion(path string) (string, error) {
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	var spec pubspec
+	if err := yaml.Unmarshal(contents, &spec); err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return spec.Version, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dart
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/config"
+)
+
+func TestBumpVersions(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+
+	for path, content := range map[string]string{
+		"pubspec.yaml": "name: workspace\nworkspace:\n  - generated/google_cloud_secretmanager_v1\n",
+		"generated/google_cloud_secretmanager_v1/pubspec.yaml": "name: google_cloud_secretmanager_v1\nversion: 0.1.0\n",
+		"generated/google_cloud_type/pubspec.yaml":             "name: google_cloud_type\nversion: 1.2.3-wip\n",
+		"generated/google_cloud_internal/pubspec.yaml":         "name: google_cloud_internal\nversion: 0.1.0\npublish_to: none\n",
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := &config.Config{
+		Version:  "v1",
+		Language: "dart",
+	}
+	configPath := "librarian.yaml"
+	if err := BumpVersions(t.Context(), cfg, configPath); err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string]string{
+		"generated/google_cloud_secretmanager_v1/pubspec.yaml": "0.2.0",
+		"generated/google_cloud_type/pubspec.yaml":             "1.3.0",
+		"generated/google_cloud_internal/pubspec.yaml":         "0.1.0",
+	} {
+		got, err := readPubspecVersion(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("%s: version = %q, want %q", path, got, want)
+		}
+	}
+
+	updatedCfg, err := config.Read(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantVersions := map[string]string{
+		"google_cloud_secretmanager_v1": "0.2.0",
+		"google_cloud_type":             "1.3.0",
+	}
+	if diff := cmp.Diff(wantVersions, updatedCfg.Versions); diff != "" {
+		t.Errorf("versions mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -19,26 +19,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/settings"
 )
 
 // Create creates a new Go client library.
 // It creates initial scaffolding files and calls Generate to create the library code.
-func Create(ctx context.Context, library *config.Library, defaults *config.Default, googleapisDir, serviceConfigPath, defaultOutput string) error {
-	// Determine output directory
-	outdir := library.Path
-	if outdir == "" {
-		// Use default output pattern if no explicit path
-		if defaults != nil {
-			outdir = strings.ReplaceAll(defaults.Output, "{name}", library.Name)
-		}
-	}
-
+func Create(ctx context.Context, library *config.Library, resolved *settings.Settings, repoDir, googleapisDir, serviceConfigPath string) error {
 	// Convert to absolute path
-	var err error
-	outdir, err = filepath.Abs(outdir)
+	outdir, err := filepath.Abs(filepath.Join(repoDir, resolved.Output))
 	if err != nil {
 		return fmt.Errorf("failed to get absolute path for output directory: %w", err)
 	}
@@ -54,7 +44,7 @@ func Create(ctx context.Context, library *config.Library, defaults *config.Defau
 	}
 
 	// Call Generate to create the library code
-	if err := Generate(ctx, library, defaults, googleapisDir, serviceConfigPath, defaultOutput); err != nil {
+	if err := Generate(ctx, library, resolved, repoDir, googleapisDir, serviceConfigPath); err != nil {
 		return fmt.Errorf("failed to generate library: %w", err)
 	}
 
@@ -102,10 +92,7 @@ const Version = "0.0.0"
 
 	// Create go.mod
 	goModPath := filepath.Join(outdir, "go.mod")
-	goMod := fmt.Sprintf(`module cloud.google.com/go/%s
-
-go 1.23
-`, library.Name)
+	goMod := fmt.Sprintf("module %s\n\ngo %s\n", modulePath(library), goVersion)
 	if err := os.WriteFile(goModPath, []byte(goMod), 0644); err != nil {
 		return fmt.Errorf("failed to write go.mod: %w", err)
 	}
@@ -16,74 +16,112 @@ package golang
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/googleapis"
+	"github.com/julieqiu/librarianx/internal/language/internal/protopatch"
+	"github.com/julieqiu/librarianx/internal/language/internal/settings"
+	"github.com/julieqiu/librarianx/internal/language/internal/staging"
 )
 
 // Generate generates a Go client library.
 // Files and directories specified in library.Keep will be preserved during regeneration.
-func Generate(ctx context.Context, library *config.Library, defaults *config.Default, googleapisDir, serviceConfigPath, defaultOutput string) error {
-	// Determine output directory
-	outdir := library.Path
-	if outdir == "" {
-		// Use default output pattern if no explicit path
-		if defaults != nil {
-			outdir = strings.ReplaceAll(defaults.Output, "{name}", library.Name)
-		}
+// The library and its snippets are generated into staging directories next to
+// their output directories, which only replace the output directories if
+// generation succeeds.
+func Generate(ctx context.Context, library *config.Library, resolved *settings.Settings, repoDir, googleapisDir, serviceConfigPath string) error {
+	if repoDir == "" {
+		return fmt.Errorf("no repository directory for library %s", library.Name)
 	}
-
 	// Convert to absolute path since protoc runs from a different directory
-	var err error
-	outdir, err = filepath.Abs(outdir)
+	outdir, err := filepath.Abs(filepath.Join(repoDir, resolved.Output))
 	if err != nil {
 		return fmt.Errorf("failed to get absolute path for output directory: %w", err)
 	}
 	fmt.Println(outdir)
 
-	// Clean output directory before generation
-	if err := cleanOutputDirectory(outdir, library.Keep); err != nil {
-		return fmt.Errorf("failed to clean output directory: %w", err)
-	}
-
-	// Create output directory
-	if err := os.MkdirAll(outdir, 0755); err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
-	}
-
 	// Get APIs to generate
 	apis := config.GetLibraryAPIs(library)
 	if len(apis) == 0 {
 		return fmt.Errorf("no APIs found for library %s", library.Name)
 	}
 
-	// Determine transport from library or defaults
-	transport := library.Transport
-	if transport == "" && defaults != nil && defaults.Generate != nil {
-		transport = defaults.Generate.Transport
+	stage, err := staging.NewKept(outdir, library.Keep, cleanOutputDirectory)
+	if err != nil {
+		return err
 	}
 
-	// Determine rest_numeric_enums setting
-	restNumericEnums := false
-	if library.RestNumericEnums != nil {
-		restNumericEnums = *library.RestNumericEnums
-	} else if defaults != nil && defaults.Generate != nil {
-		restNumericEnums = defaults.Generate.RestNumericEnums
+	// Snippets live in a separate module at the repository root and are
+	// staged the same way.
+	snippetsOut := filepath.Join(repoDir, filepath.FromSlash(snippetsDir), filepath.FromSlash(strings.TrimPrefix(modulePath(library), rootModulePath+"/")))
+	snippetsStage, err := staging.New(snippetsOut, os.RemoveAll)
+	if err != nil {
+		return stage.Fail(err)
+	}
+
+	// Both stages are swapped in before either is discarded, so the library
+	// and its snippets are replaced together or not at all.
+	if err := generateLibrary(ctx, library, resolved, googleapisDir, repoDir, stage.Dir, snippetsStage.Dir, apis); err != nil {
+		return snippetsStage.Fail(stage.Fail(err))
+	}
+	if err := stage.Swap(); err != nil {
+		return snippetsStage.Fail(stage.Fail(err))
+	}
+	if err := snippetsStage.Swap(); err != nil {
+		return stage.Fail(snippetsStage.Fail(err))
+	}
+
+	// Wire the library into the snippets module and the workspace
+	if err := updateSnippetsModule(ctx, repoDir, outdir, library); err != nil {
+		return snippetsStage.Fail(stage.Fail(fmt.Errorf("failed to update snippets module: %w", err)))
+	}
+	if err := updateGoWork(ctx, repoDir); err != nil {
+		return snippetsStage.Fail(stage.Fail(fmt.Errorf("failed to update go.work: %w", err)))
+	}
+	return errors.Join(stage.Done(), snippetsStage.Done())
+}
+
+// generateLibrary runs protoc and the post-processing steps for all APIs of
+// library, writing the library to outdir and its snippets to snippetsOut.
+func generateLibrary(ctx context.Context, library *config.Library, resolved *settings.Settings, googleapisDir, repoRoot, outdir, snippetsOut string, apis []string) error {
+	overrides, err := config.LibraryDocumentationOverrides(library, "go")
+	if err != nil {
+		return err
 	}
 
 	// Generate each API
 	for _, apiPath := range apis {
 		// Get service config for this API
 		apiServiceConfig := library.APIServiceConfigs[apiPath]
-		if err := generateAPI(ctx, apiPath, library, googleapisDir, apiServiceConfig, outdir, transport, restNumericEnums); err != nil {
+		apiOverrides := config.DocumentationOverridesForAPI(overrides, apiPath)
+		if err := generateAPI(ctx, apiPath, library, googleapisDir, apiServiceConfig, outdir, resolved.Transport, resolved.RestNumericEnums, apiOverrides); err != nil {
 			return fmt.Errorf("failed to generate API %s: %w", apiPath, err)
 		}
 	}
 
+	// Move the generated files from their import path into place
+	if err := relocateGeneratedFiles(outdir, snippetsOut, library); err != nil {
+		return fmt.Errorf("failed to relocate generated files: %w", err)
+	}
+
+	// Delete generated files the library does not want
+	if err := deleteOutputPaths(outdir, library); err != nil {
+		return err
+	}
+
+	// Create or update go.mod
+	if err := writeGoMod(ctx, outdir, repoRoot, googleapisDir, apis, library); err != nil {
+		return fmt.Errorf("failed to write go.mod: %w", err)
+	}
+
 	// Run post-processing
 	if err := postProcess(ctx, outdir); err != nil {
 		return fmt.Errorf("failed to post-process: %w", err)
@@ -98,23 +136,65 @@ func Generate(ctx context.Context, library *config.Library, defaults *config.Def
 }
 
 // generateAPI generates code for a single API using protoc with Go plugins.
-func generateAPI(ctx context.Context, apiPath string, library *config.Library, googleapisDir, serviceConfigPath, outdir, transport string, restNumericEnums bool) error {
-	// Build protoc command arguments
-	protoPattern := filepath.Join(apiPath, "*.proto")
+// The documentation overrides are applied to a copy of the protos.
+func generateAPI(ctx context.Context, apiPath string, library *config.Library, googleapisDir, serviceConfigPath, outdir, transport string, restNumericEnums bool, overrides []config.DocumentationOverride) error {
+	args, err := protocArgs(apiPath, library, googleapisDir, serviceConfigPath, outdir, transport, restNumericEnums)
+	if err != nil {
+		return err
+	}
+	overlay, err := protopatch.New(googleapisDir, apiPath, overrides)
+	if err != nil {
+		return err
+	}
+	defer overlay.Close()
+	args = append(overlay.Args, args...)
+
+	cmdStr := "protoc " + strings.Join(args, " ")
+
+	// Debug: print the protoc command
+	fmt.Fprintf(os.Stderr, "\nRunning: %s\n", cmdStr)
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", cmdStr)
+	cmd.Dir = overlay.Dir
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("protoc command failed: %w", err)
+	}
+
+	return nil
+}
+
+// protocArgs builds the protoc arguments to generate a single API.
+func protocArgs(apiPath string, library *config.Library, googleapisDir, serviceConfigPath, outdir, transport string, restNumericEnums bool) ([]string, error) {
+	api := goAPIConfig(library, apiPath)
+
+	// Select the protos to compile
+	protos, err := apiProtos(googleapisDir, apiPath, api)
+	if err != nil {
+		return nil, err
+	}
 
 	// Base arguments for all Go generation
-	args := []string{
-		protoPattern,
+	args := append(protos,
 		fmt.Sprintf("--go_out=%s", outdir),
 		fmt.Sprintf("--go-grpc_out=%s", outdir),
-		fmt.Sprintf("--go_gapic_out=%s", outdir),
+	)
+
+	// Proto-only APIs do not get a GAPIC client
+	if api != nil && api.DisableGapic {
+		return args, nil
 	}
+	args = append(args, fmt.Sprintf("--go_gapic_out=%s", outdir))
 
 	// Build GAPIC options
 	var gapicOpts []string
 
-	// Import path from library config
-	if library.Go != nil && library.Go.ImportPath != "" {
+	// Import path from the client directory, or from library config
+	if api != nil && api.ClientDirectory != "" {
+		gapicOpts = append(gapicOpts, fmt.Sprintf("go-gapic-package=%s", clientImportPath(library, api.ClientDirectory)))
+	} else if library.Go != nil && library.Go.ImportPath != "" {
 		gapicOpts = append(gapicOpts, fmt.Sprintf("go-gapic-package=%s", library.Go.ImportPath))
 	}
 
@@ -129,19 +209,7 @@ func generateAPI(ctx context.Context, apiPath string, library *config.Library, g
 	}
 
 	// gRPC service config (retry/timeout settings)
-	grpcConfigPath := ""
-	if library.GRPCServiceConfig != "" {
-		// GRPCServiceConfig is relative to the API directory
-		grpcConfigPath = filepath.Join(googleapisDir, apiPath, library.GRPCServiceConfig)
-	} else {
-		// Auto-discover: look for *_grpc_service_config.json in the API directory
-		apiDir := filepath.Join(googleapisDir, apiPath)
-		matches, err := filepath.Glob(filepath.Join(apiDir, "*_grpc_service_config.json"))
-		if err == nil && len(matches) > 0 {
-			grpcConfigPath = matches[0]
-		}
-	}
-	if grpcConfigPath != "" {
+	if grpcConfigPath := googleapis.GRPCServiceConfig(library, googleapisDir, apiPath); grpcConfigPath != "" {
 		gapicOpts = append(gapicOpts, fmt.Sprintf("grpc-service-config=%s", grpcConfigPath))
 	}
 
@@ -150,32 +218,142 @@ func generateAPI(ctx context.Context, apiPath string, library *config.Library, g
 		gapicOpts = append(gapicOpts, fmt.Sprintf("api-service-config=%s", serviceConfigPath))
 	}
 
-	// Metadata generation
-	if library.Go != nil && library.Go.Metadata {
-		gapicOpts = append(gapicOpts, "metadata")
+	if library.Go != nil {
+		// Metadata generation
+		if library.Go.Metadata {
+			gapicOpts = append(gapicOpts, "metadata")
+		}
+
+		// Discovery-based (DIREGAPIC) clients
+		if library.Go.Diregapic {
+			gapicOpts = append(gapicOpts, "diregapic")
+		}
+
+		// Renamed services, sorted for a stable command line
+		names := make([]string, 0, len(library.Go.RenamedServices))
+		for name := range library.Go.RenamedServices {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			gapicOpts = append(gapicOpts, fmt.Sprintf("rename-service=%s=%s", name, library.Go.RenamedServices[name]))
+		}
 	}
 
 	// Add GAPIC options to command
-	if len(gapicOpts) > 0 {
-		for _, opt := range gapicOpts {
-			args = append(args, fmt.Sprintf("--go_gapic_opt=%s", opt))
+	for _, opt := range gapicOpts {
+		args = append(args, fmt.Sprintf("--go_gapic_opt=%s", opt))
+	}
+
+	return args, nil
+}
+
+// goAPIConfig returns the Go-specific configuration for apiPath, or nil if
+// there is none.
+func goAPIConfig(library *config.Library, apiPath string) *config.GoAPI {
+	if library.Go == nil {
+		return nil
+	}
+	for i := range library.Go.APIs {
+		if library.Go.APIs[i].Path == apiPath {
+			return &library.Go.APIs[i]
 		}
 	}
+	return nil
+}
 
-	cmdStr := "protoc " + strings.Join(args, " ")
+// apiProtos returns the proto files to compile for apiPath, relative to
+// googleapisDir.
+//
+// By default this is every proto in the API directory. If api.ProtoPackage is
+// set, only protos declaring that package are included. Protos listed in
+// api.NestedProtos are added on top.
+func apiProtos(googleapisDir, apiPath string, api *config.GoAPI) ([]string, error) {
+	protos := []string{filepath.Join(apiPath, "*.proto")}
+	if api == nil {
+		return protos, nil
+	}
+	if api.ProtoPackage != "" {
+		matches, err := filepath.Glob(filepath.Join(googleapisDir, apiPath, "*.proto"))
+		if err != nil {
+			return nil, err
+		}
+		protos = nil
+		for _, match := range matches {
+			pkg, err := protoPackage(match)
+			if err != nil {
+				return nil, err
+			}
+			if pkg == api.ProtoPackage {
+				protos = append(protos, filepath.Join(apiPath, filepath.Base(match)))
+			}
+		}
+		if len(protos) == 0 {
+			return nil, fmt.Errorf("no protos in %s declare package %s", apiPath, api.ProtoPackage)
+		}
+	}
+	for _, nested := range api.NestedProtos {
+		protos = append(protos, filepath.Join(apiPath, nested))
+	}
+	return protos, nil
+}
 
-	// Debug: print the protoc command
-	fmt.Fprintf(os.Stderr, "\nRunning: %s\n", cmdStr)
+// protoPackage returns the package declared in the proto file at path.
+func protoPackage(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if rest, ok := strings.CutPrefix(line, "package "); ok {
+			return strings.TrimSpace(strings.TrimSuffix(rest, ";")), nil
+		}
+	}
+	return "", nil
+}
 
-	cmd := exec.CommandContext(ctx, "sh", "-c", cmdStr)
-	cmd.Dir = googleapisDir
-	cmd.Stdout = os.Stderr
-	cmd.Stderr = os.Stderr
+// modulePath returns the Go module path of library.
+func modulePath(library *config.Library) string {
+	path := "cloud.google.com/go/" + library.Name
+	if library.Go != nil && library.Go.ModulePathVersion != "" {
+		path += "/" + library.Go.ModulePathVersion
+	}
+	return path
+}
 
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("protoc command failed: %w", err)
+// versionSegment matches the elements of a client directory that are
+// versions, such as "apiv1", "apiv2beta" or "v2".
+var versionSegment = regexp.MustCompile(`^(api)?v\d+`)
+
+// clientImportPath returns the go-gapic-package value for a client written to
+// clientDirectory, relative to the module root. The package name is the last
+// path element that is not a version (e.g., "generativelanguage" for
+// "generativelanguage/apiv1beta"), or the library name if every element is a
+// version (e.g., "apiv1/v2").
+func clientImportPath(library *config.Library, clientDirectory string) string {
+	pkg := library.Name
+	parts := strings.Split(clientDirectory, "/")
+	for i := len(parts) - 1; i >= 0; i-- {
+		if !versionSegment.MatchString(parts[i]) {
+			pkg = parts[i]
+			break
+		}
 	}
+	return fmt.Sprintf("%s/%s;%s", modulePath(library), clientDirectory, pkg)
+}
 
+// deleteOutputPaths deletes the paths, relative to outdir, listed in the
+// library's delete_generation_output_paths.
+func deleteOutputPaths(outdir string, library *config.Library) error {
+	if library.Go == nil {
+		return nil
+	}
+	for _, path := range library.Go.DeleteGenerationOutputPaths {
+		if err := os.RemoveAll(filepath.Join(outdir, path)); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", path, err)
+		}
+	}
 	return nil
 }
 
@@ -192,20 +370,8 @@ func postProcess(ctx context.Context, outdir string) error {
 		fmt.Fprintf(os.Stderr, "Warning: goimports failed (skipping): %v\n", err)
 	}
 
-	// Run go mod tidy if go.mod exists
-	goModPath := filepath.Join(outdir, "go.mod")
-	if _, err := os.Stat(goModPath); err == nil {
-		cmd = exec.CommandContext(ctx, "go", "mod", "tidy")
-		cmd.Dir = outdir
-		cmd.Stdout = os.Stderr
-		cmd.Stderr = os.Stderr
-
-		if err := cmd.Run(); err != nil {
-			return fmt.Errorf("go mod tidy failed: %w", err)
-		}
-	}
-
-	return nil
+	// Resolve the remaining requirements from the local module cache
+	return goModTidy(ctx, outdir)
 }
 
 // createScaffoldingFiles creates initial files for a new library if they don't exist.
@@ -0,0 +1,326 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golang
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/settings"
+)
+
+func TestProtocArgs(t *testing.T) {
+	googleapisDir := t.TempDir()
+	apiPath := "google/pubsub/v1"
+	for name, content := range map[string]string{
+		"pubsub.proto": "syntax = \"proto3\";\n\npackage google.pubsub.v1;\n",
+		"schema.proto": "syntax = \"proto3\";\n\npackage google.pubsub.v1;\n",
+		"legacy.proto": "syntax = \"proto3\";\n\npackage google.pubsub.legacy;\n",
+	} {
+		path := filepath.Join(googleapisDir, apiPath, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, test := range []struct {
+		name    string
+		library *config.Library
+		want    []string
+	}{
+		{
+			name: "defaults",
+			library: &config.Library{
+				Name: "pubsub",
+				Go:   &config.GoModule{ImportPath: "cloud.google.com/go/pubsub/apiv1;pubsub"},
+			},
+			want: []string{
+				"google/pubsub/v1/*.proto",
+				"--go_out=/out",
+				"--go-grpc_out=/out",
+				"--go_gapic_out=/out",
+				"--go_gapic_opt=go-gapic-package=cloud.google.com/go/pubsub/apiv1;pubsub",
+				"--go_gapic_opt=transport=grpc",
+			},
+		},
+		{
+			name: "renamed services and diregapic",
+			library: &config.Library{
+				Name: "pubsub",
+				Go: &config.GoModule{
+					Diregapic: true,
+					RenamedServices: map[string]string{
+						"Subscriber": "SubscriptionAdmin",
+						"Publisher":  "TopicAdmin",
+					},
+				},
+			},
+			want: []string{
+				"google/pubsub/v1/*.proto",
+				"--go_out=/out",
+				"--go-grpc_out=/out",
+				"--go_gapic_out=/out",
+				"--go_gapic_opt=transport=grpc",
+				"--go_gapic_opt=diregapic",
+				"--go_gapic_opt=rename-service=Publisher=TopicAdmin",
+				"--go_gapic_opt=rename-service=Subscriber=SubscriptionAdmin",
+			},
+		},
+		{
+			name: "disable gapic",
+			library: &config.Library{
+				Name: "pubsub",
+				Go: &config.GoModule{
+					ImportPath: "cloud.google.com/go/pubsub/apiv1;pubsub",
+					APIs:       []config.GoAPI{{Path: apiPath, DisableGapic: true}},
+				},
+			},
+			want: []string{
+				"google/pubsub/v1/*.proto",
+				"--go_out=/out",
+				"--go-grpc_out=/out",
+			},
+		},
+		{
+			name: "client directory, proto package and nested protos",
+			library: &config.Library{
+				Name: "pubsub",
+				Go: &config.GoModule{
+					ModulePathVersion: "v2",
+					APIs: []config.GoAPI{{
+						Path:            apiPath,
+						ClientDirectory: "admin/apiv1",
+						ProtoPackage:    "google.pubsub.v1",
+						NestedProtos:    []string{"internal/types.proto"},
+					}},
+				},
+			},
+			want: []string{
+				"google/pubsub/v1/pubsub.proto",
+				"google/pubsub/v1/schema.proto",
+				"google/pubsub/v1/internal/types.proto",
+				"--go_out=/out",
+				"--go-grpc_out=/out",
+				"--go_gapic_out=/out",
+				"--go_gapic_opt=go-gapic-package=cloud.google.com/go/pubsub/v2/admin/apiv1;admin",
+				"--go_gapic_opt=transport=grpc",
+			},
+		},
+		{
+			name: "versioned client directory",
+			library: &config.Library{
+				Name: "cloudbuild",
+				Go: &config.GoModule{
+					APIs: []config.GoAPI{{Path: apiPath, ClientDirectory: "apiv1/v2"}},
+				},
+			},
+			want: []string{
+				"google/pubsub/v1/*.proto",
+				"--go_out=/out",
+				"--go-grpc_out=/out",
+				"--go_gapic_out=/out",
+				"--go_gapic_opt=go-gapic-package=cloud.google.com/go/cloudbuild/apiv1/v2;cloudbuild",
+				"--go_gapic_opt=transport=grpc",
+			},
+		},
+		{
+			name: "client directory under a version",
+			library: &config.Library{
+				Name: "bigquery",
+				Go: &config.GoModule{
+					APIs: []config.GoAPI{{Path: apiPath, ClientDirectory: "v2/apiv2"}},
+				},
+			},
+			want: []string{
+				"google/pubsub/v1/*.proto",
+				"--go_out=/out",
+				"--go-grpc_out=/out",
+				"--go_gapic_out=/out",
+				"--go_gapic_opt=go-gapic-package=cloud.google.com/go/bigquery/v2/apiv2;bigquery",
+				"--go_gapic_opt=transport=grpc",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := protocArgs(apiPath, test.library, googleapisDir, "", "/out", "grpc", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProtocArgsUnknownProtoPackage(t *testing.T) {
+	library := &config.Library{
+		Name: "pubsub",
+		Go: &config.GoModule{
+			APIs: []config.GoAPI{{Path: "google/pubsub/v1", ProtoPackage: "google.pubsub.v2"}},
+		},
+	}
+	if _, err := protocArgs("google/pubsub/v1", library, t.TempDir(), "", "/out", "grpc", false); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestDeleteOutputPaths(t *testing.T) {
+	outdir := t.TempDir()
+	for _, path := range []string{"apiv1/doc.go", "internal/generated/snippets/foo.go", "keep.go"} {
+		full := filepath.Join(outdir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	library := &config.Library{
+		Name: "pubsub",
+		Go:   &config.GoModule{DeleteGenerationOutputPaths: []string{"apiv1", "internal/generated"}},
+	}
+	if err := deleteOutputPaths(outdir, library); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"apiv1", "internal/generated"} {
+		if _, err := os.Stat(filepath.Join(outdir, path)); !os.IsNotExist(err) {
+			t.Errorf("%s should have been deleted", path)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outdir, "keep.go")); err != nil {
+		t.Errorf("keep.go should not have been deleted: %v", err)
+	}
+}
+
+func TestGenerateFailureKeepsOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses shell scripts")
+	}
+	// The fake protoc writes a snippet, and a file that belongs to another
+	// module, which fails the generation.
+	bin := t.TempDir()
+	protoc := `#!/bin/sh
+for arg; do
+  case "$arg" in
+    --go_out=*) out="${arg#--go_out=}" ;;
+  esac
+done
+mkdir -p "$out/cloud.google.com/go/internal/generated/snippets/secretmanager/apiv1" "$out/cloud.google.com/go/iam"
+echo new > "$out/cloud.google.com/go/internal/generated/snippets/secretmanager/apiv1/main.go"
+echo other > "$out/cloud.google.com/go/iam/iam.go"
+`
+	if err := os.WriteFile(filepath.Join(bin, "protoc"), []byte(protoc), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	repoDir := t.TempDir()
+	googleapisDir := t.TempDir()
+	writeFiles(t, googleapisDir, map[string]string{"google/cloud/secretmanager/v1/service.proto": `syntax = "proto3";`})
+	writeFiles(t, repoDir, map[string]string{
+		"secretmanager/apiv1/old.go":                                  "old",
+		"internal/generated/snippets/secretmanager/apiv1/old/main.go": "old",
+	})
+	library := &config.Library{
+		Name:    "secretmanager",
+		Channel: "google/cloud/secretmanager/v1",
+	}
+	resolved := settings.Resolve(library, &config.Default{Output: "{name}/"})
+	err := Generate(t.Context(), library, resolved, repoDir, googleapisDir, "")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if want := "cloud.google.com/go/iam/iam.go"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error %q does not mention %s", err, want)
+	}
+	for _, path := range []string{
+		"secretmanager/apiv1/old.go",
+		"internal/generated/snippets/secretmanager/apiv1/old/main.go",
+	} {
+		got, err := os.ReadFile(filepath.Join(repoDir, path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != "old" {
+			t.Errorf("%s was modified, got %q", path, got)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(repoDir, "internal/generated/snippets/secretmanager/apiv1/main.go")); !os.IsNotExist(err) {
+		t.Errorf("the new snippet should not be in place, got %v", err)
+	}
+}
+
+func TestGenerateKeepsGoMod(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses shell scripts")
+	}
+	bin := t.TempDir()
+	protoc := `#!/bin/sh
+for arg; do
+  case "$arg" in
+    --go_out=*) out="${arg#--go_out=}" ;;
+  esac
+done
+mkdir -p "$out/cloud.google.com/go/secretmanager/apiv1"
+echo "package secretmanager" > "$out/cloud.google.com/go/secretmanager/apiv1/doc.go"
+`
+	if err := os.WriteFile(filepath.Join(bin, "protoc"), []byte(protoc), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	repoDir := t.TempDir()
+	googleapisDir := t.TempDir()
+	writeFiles(t, googleapisDir, map[string]string{"google/cloud/secretmanager/v1/service.proto": `syntax = "proto3";`})
+	goMod := "module cloud.google.com/go/secretmanager\n\ngo 1.23\n\nretract v1.0.0\n"
+	changes := "# Changes\n\n## 1.0.1\n"
+	writeFiles(t, repoDir, map[string]string{
+		"secretmanager/go.mod":              goMod,
+		"secretmanager/CHANGES.md":          changes,
+		"secretmanager/apiv1/old_client.go": "package secretmanager",
+	})
+	library := &config.Library{
+		Name:    "secretmanager",
+		Channel: "google/cloud/secretmanager/v1",
+	}
+	resolved := settings.Resolve(library, &config.Default{Output: "{name}/"})
+	if err := Generate(t.Context(), library, resolved, repoDir, googleapisDir, ""); err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]string{
+		"secretmanager/go.mod":     goMod,
+		"secretmanager/CHANGES.md": changes,
+	} {
+		got, err := os.ReadFile(filepath.Join(repoDir, path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, string(got)); diff != "" {
+			t.Errorf("%s mismatch (-want +got):\n%s", path, diff)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(repoDir, "secretmanager/apiv1/old_client.go")); !os.IsNotExist(err) {
+		t.Errorf("old_client.go should have been deleted, got %v", err)
+	}
+}
@@ -26,7 +26,7 @@ func TestInit(t *testing.T) {
 	want := &config.Default{
 		Output: "{name}",
 		Generate: &config.DefaultGenerate{
-			All:              true,
+			Auto:             true,
 			OneLibraryPer:    "api",
 			Transport:        "grpc+rest",
 			RestNumericEnums: true,
@@ -18,16 +18,35 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+
+	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/settings"
 )
 
+// Clean deletes the generated files of library, preserving go.mod, go.sum,
+// CHANGES.md and the files listed in library.Keep. The output directory is
+// relative to repoDir.
+func Clean(library *config.Library, resolved *settings.Settings, repoDir string) error {
+	return cleanOutputDirectory(filepath.Join(repoDir, resolved.Output), library.Keep)
+}
+
+// alwaysKept lists the files every Go module keeps across regeneration.
+// go.mod and go.sum are updated in place, and CHANGES.md is written by
+// releases.
+var alwaysKept = []string{"go.mod", "go.sum", "CHANGES.md"}
+
 // cleanOutputDirectory deletes everything in the output directory except files listed in keepPaths.
+// The files in alwaysKept are always preserved.
 func cleanOutputDirectory(outdir string, keepPaths []string) error {
 	// Check if directory exists
 	if _, err := os.Stat(outdir); os.IsNotExist(err) {
 		return nil
 	}
 
+	keepPaths = slices.Concat(keepPaths, alwaysKept)
+
 	// Build map of paths to keep (normalized to absolute paths)
 	keepMap := make(map[string]bool)
 	for _, keepPath := range keepPaths {
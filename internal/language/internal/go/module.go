@@ -0,0 +1,455 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golang
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/staging"
+)
+
+const (
+	// goVersion is the go directive written to new go.mod and go.work files.
+	goVersion = "1.23"
+
+	// rootModulePath is the import path prefix of all modules in the repository.
+	rootModulePath = "cloud.google.com/go"
+
+	// snippetsModulePath is the module path of the generated snippets.
+	snippetsModulePath = rootModulePath + "/internal/generated/snippets"
+
+	// snippetsDir is the directory of the snippets module, relative to the
+	// repository root.
+	snippetsDir = "internal/generated/snippets"
+)
+
+// sharedModules maps proto import prefixes to the Go module that contains the
+// generated code for those protos.
+var sharedModules = []struct {
+	prefix string
+	module string
+}{
+	{"google/api/", "google.golang.org/genproto/googleapis/api"},
+	{"google/cloud/location/", rootModulePath},
+	{"google/iam/v1/", rootModulePath + "/iam"},
+	{"google/longrunning/", rootModulePath + "/longrunning"},
+	{"google/protobuf/", "google.golang.org/protobuf"},
+	{"google/rpc/", "google.golang.org/genproto/googleapis/rpc"},
+	{"google/type/", "google.golang.org/genproto"},
+}
+
+// gapicModules are the modules every GAPIC client depends on.
+var gapicModules = []string{
+	"github.com/googleapis/gax-go/v2",
+	"google.golang.org/api",
+	"google.golang.org/grpc",
+	"google.golang.org/protobuf",
+}
+
+var protoImportRegex = regexp.MustCompile(`^\s*import\s+(?:public\s+|weak\s+)?"([^"]+)"\s*;`)
+
+// relocateGeneratedFiles moves the files protoc wrote under their import path
+// to their place in the monorepo. Library files move from
+// outdir/cloud.google.com/go/{name} to outdir, snippets move to snippetsOut.
+func relocateGeneratedFiles(outdir, snippetsOut string, library *config.Library) error {
+	importRoot := filepath.Join(outdir, filepath.FromSlash(rootModulePath))
+	if _, err := os.Stat(importRoot); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	rel := strings.TrimPrefix(modulePath(library), rootModulePath+"/")
+	if err := moveContents(filepath.Join(importRoot, filepath.FromSlash(rel)), outdir); err != nil {
+		return err
+	}
+	if err := moveContents(filepath.Join(importRoot, filepath.FromSlash(snippetsDir), filepath.FromSlash(rel)), snippetsOut); err != nil {
+		return err
+	}
+	// Remove the now empty cloud.google.com directory. Anything left in it
+	// belongs to another module, and is reported instead of deleted.
+	top := filepath.Join(outdir, strings.Split(rootModulePath, "/")[0])
+	if err := removeEmptyDirs(top); err != nil {
+		return err
+	}
+	var remaining []string
+	err := filepath.WalkDir(top, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(outdir, path)
+		if err != nil {
+			return err
+		}
+		remaining = append(remaining, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if len(remaining) != 0 {
+		return fmt.Errorf("generated files outside of module %s: %s", modulePath(library), strings.Join(remaining, ", "))
+	}
+	return nil
+}
+
+// removeEmptyDirs removes dir and its subdirectories if they do not contain
+// any files.
+func removeEmptyDirs(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	empty := true
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			empty = false
+			continue
+		}
+		sub := filepath.Join(dir, entry.Name())
+		if err := removeEmptyDirs(sub); err != nil {
+			return err
+		}
+		if _, err := os.Stat(sub); err == nil {
+			empty = false
+		}
+	}
+	if !empty {
+		return nil
+	}
+	return os.Remove(dir)
+}
+
+// moveContents moves every entry of src into dst, merging directories that
+// exist in both.
+func moveContents(src, dst string) error {
+	entries, err := os.ReadDir(src)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		from := filepath.Join(src, entry.Name())
+		to := filepath.Join(dst, entry.Name())
+		if info, err := os.Stat(to); err == nil && info.IsDir() && entry.IsDir() {
+			if err := moveContents(from, to); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.RemoveAll(to); err != nil {
+			return err
+		}
+		if err := os.Rename(from, to); err != nil {
+			return fmt.Errorf("failed to move %s: %w", from, err)
+		}
+	}
+	return nil
+}
+
+// requiredModules returns the modules a library depends on, derived from the
+// protos imported by its APIs.
+func requiredModules(googleapisDir string, apis []string, library *config.Library) ([]string, error) {
+	modules := map[string]bool{}
+	for _, apiPath := range apis {
+		api := goAPIConfig(library, apiPath)
+		if api == nil || !api.DisableGapic {
+			for _, m := range gapicModules {
+				modules[m] = true
+			}
+		}
+		protos, err := apiProtos(googleapisDir, apiPath, api)
+		if err != nil {
+			return nil, err
+		}
+		for _, pattern := range protos {
+			matches, err := filepath.Glob(filepath.Join(googleapisDir, pattern))
+			if err != nil {
+				return nil, err
+			}
+			for _, match := range matches {
+				imports, err := protoImports(match)
+				if err != nil {
+					return nil, err
+				}
+				for _, imp := range imports {
+					for _, shared := range sharedModules {
+						if strings.HasPrefix(imp, shared.prefix) {
+							modules[shared.module] = true
+						}
+					}
+				}
+			}
+		}
+	}
+	delete(modules, modulePath(library))
+
+	var result []string
+	for m := range modules {
+		result = append(result, m)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// protoImports returns the files imported by the proto file at path.
+func protoImports(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var imports []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		if m := protoImportRegex.FindStringSubmatch(scanner.Text()); m != nil {
+			imports = append(imports, m[1])
+		}
+	}
+	return imports, scanner.Err()
+}
+
+// writeGoMod creates or updates the go.mod file in outdir. Modules the
+// library depends on are pinned to the versions required by the repository
+// root module, so all libraries in the monorepo agree on them. Remaining
+// requirements are resolved by go mod tidy.
+func writeGoMod(ctx context.Context, outdir, repoRoot, googleapisDir string, apis []string, library *config.Library) error {
+	goModPath := filepath.Join(outdir, "go.mod")
+	if _, err := os.Stat(goModPath); errors.Is(err, fs.ErrNotExist) {
+		content := fmt.Sprintf("module %s\n\ngo %s\n", modulePath(library), goVersion)
+		if err := os.WriteFile(goModPath, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write go.mod: %w", err)
+		}
+	}
+
+	modules, err := requiredModules(googleapisDir, apis, library)
+	if err != nil {
+		return err
+	}
+	versions, err := moduleRequirements(ctx, filepath.Join(repoRoot, "go.mod"))
+	if err != nil {
+		return err
+	}
+	var args []string
+	for _, m := range modules {
+		if v, ok := versions[m]; ok {
+			args = append(args, fmt.Sprintf("-require=%s@%s", m, v))
+		}
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	return runGo(ctx, outdir, nil, append([]string{"mod", "edit"}, args...)...)
+}
+
+// moduleRequirements returns the required module versions of the go.mod file
+// at path, or an empty map if it does not exist.
+func moduleRequirements(ctx context.Context, path string) (map[string]string, error) {
+	versions := map[string]string{}
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return versions, nil
+	}
+	out, err := exec.CommandContext(ctx, "go", "mod", "edit", "-json", path).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var mod struct {
+		Require []struct {
+			Path    string
+			Version string
+		}
+	}
+	if err := json.Unmarshal(out, &mod); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for _, r := range mod.Require {
+		versions[r.Path] = r.Version
+	}
+	return versions, nil
+}
+
+// updateSnippetsModule makes sure the snippets module requires the library
+// and resolves it from its directory in the repository.
+func updateSnippetsModule(ctx context.Context, repoRoot, outdir string, library *config.Library) error {
+	dir := filepath.Join(repoRoot, filepath.FromSlash(snippetsDir))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	goModPath := filepath.Join(dir, "go.mod")
+	if _, err := os.Stat(goModPath); errors.Is(err, fs.ErrNotExist) {
+		content := fmt.Sprintf("module %s\n\ngo %s\n", snippetsModulePath, goVersion)
+		if err := os.WriteFile(goModPath, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write snippets go.mod: %w", err)
+		}
+	}
+	rel, err := filepath.Rel(dir, outdir)
+	if err != nil {
+		return err
+	}
+	mod := modulePath(library)
+	return runGo(ctx, dir, nil, "mod", "edit",
+		fmt.Sprintf("-require=%s@%s", mod, moduleVersion(library)),
+		fmt.Sprintf("-replace=%s=%s", mod, filepath.ToSlash(rel)))
+}
+
+// moduleVersion returns the version used to require library from other
+// modules in the repository.
+func moduleVersion(library *config.Library) string {
+	if library.Version != "" {
+		return "v" + strings.TrimPrefix(library.Version, "v")
+	}
+	if library.Go != nil && library.Go.ModulePathVersion != "" {
+		return library.Go.ModulePathVersion + ".0.0"
+	}
+	return "v0.0.0"
+}
+
+// updateGoWork keeps the go.work file in repoRoot in sync with the modules in
+// the repository: every directory containing a go.mod file is used, and uses
+// of directories without one are dropped. Other directives are preserved.
+func updateGoWork(ctx context.Context, repoRoot string) error {
+	goWorkPath := filepath.Join(repoRoot, "go.work")
+	if _, err := os.Stat(goWorkPath); errors.Is(err, fs.ErrNotExist) {
+		if err := os.WriteFile(goWorkPath, []byte(fmt.Sprintf("go %s\n", goVersion)), 0644); err != nil {
+			return fmt.Errorf("failed to write go.work: %w", err)
+		}
+	}
+
+	want, err := findModules(repoRoot)
+	if err != nil {
+		return err
+	}
+	out, err := exec.CommandContext(ctx, "go", "work", "edit", "-json", goWorkPath).Output()
+	if err != nil {
+		return fmt.Errorf("failed to read go.work: %w", err)
+	}
+	var work struct {
+		Use []struct {
+			DiskPath string
+		}
+	}
+	if err := json.Unmarshal(out, &work); err != nil {
+		return fmt.Errorf("failed to parse go.work: %w", err)
+	}
+
+	var args []string
+	have := map[string]bool{}
+	for _, use := range work.Use {
+		path := "./" + strings.TrimPrefix(filepath.ToSlash(filepath.Clean(use.DiskPath)), "./")
+		if path == "./." {
+			path = "."
+		}
+		have[path] = true
+		if !want[path] {
+			args = append(args, "-dropuse="+use.DiskPath)
+		}
+	}
+	var missing []string
+	for path := range want {
+		if !have[path] {
+			missing = append(missing, path)
+		}
+	}
+	sort.Strings(missing)
+	for _, path := range missing {
+		args = append(args, "-use="+path)
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	return runGo(ctx, repoRoot, nil, append([]string{"work", "edit"}, args...)...)
+}
+
+// findModules returns the directories under repoRoot that contain a go.mod
+// file, as paths relative to repoRoot (e.g., "./secretmanager").
+func findModules(repoRoot string) (map[string]bool, error) {
+	modules := map[string]bool{}
+	err := filepath.WalkDir(repoRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != repoRoot && (strings.HasPrefix(name, ".") || name == "testdata" || name == "vendor" || staging.IsTemporary(name)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() != "go.mod" {
+			return nil
+		}
+		rel, err := filepath.Rel(repoRoot, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			modules["."] = true
+		} else {
+			modules["./"+filepath.ToSlash(rel)] = true
+		}
+		return nil
+	})
+	return modules, err
+}
+
+// goModTidy runs go mod tidy in dir without network access. Modules are
+// resolved from the local module cache, which is used as the module proxy.
+func goModTidy(ctx context.Context, dir string) error {
+	out, err := exec.CommandContext(ctx, "go", "env", "GOMODCACHE").Output()
+	if err != nil {
+		return fmt.Errorf("failed to locate the module cache: %w", err)
+	}
+	proxy := "file://" + filepath.ToSlash(filepath.Join(strings.TrimSpace(string(out)), "cache", "download"))
+	env := []string{
+		"GOPROXY=" + proxy,
+		"GOSUMDB=off",
+		"GOWORK=off",
+		"GOFLAGS=-mod=mod",
+	}
+	if err := runGo(ctx, dir, env, "mod", "tidy"); err != nil {
+		return fmt.Errorf("go mod tidy failed: %w", err)
+	}
+	return nil
+}
+
+// runGo runs the go command in dir with additional environment variables.
+func runGo(ctx context.Context, dir string, env []string, args ...string) error {
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("go %s: %w", strings.Join(args, " "), err)
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golang
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/config"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for path, content := range files {
+		full := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRelocateGeneratedFiles(t *testing.T) {
+	outdir := t.TempDir()
+	snippetsOut := filepath.Join(t.TempDir(), "pubsub", "v2")
+	writeFiles(t, outdir, map[string]string{
+		"cloud.google.com/go/pubsub/v2/apiv1/publisher_client.go":                                      "",
+		"cloud.google.com/go/pubsub/v2/apiv1/pubsubpb/pubsub.pb.go":                                    "",
+		"cloud.google.com/go/internal/generated/snippets/pubsub/v2/apiv1/Publisher/main.go":            "",
+		"cloud.google.com/go/internal/generated/snippets/pubsub/v2/apiv1/snippet_metadata.pubsub.json": "",
+		"apiv1/handwritten.go": "",
+	})
+	library := &config.Library{Name: "pubsub", Go: &config.GoModule{ModulePathVersion: "v2"}}
+	if err := relocateGeneratedFiles(outdir, snippetsOut, library); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{
+		filepath.Join(outdir, "apiv1/publisher_client.go"),
+		filepath.Join(outdir, "apiv1/pubsubpb/pubsub.pb.go"),
+		filepath.Join(outdir, "apiv1/handwritten.go"),
+		filepath.Join(snippetsOut, "apiv1/Publisher/main.go"),
+		filepath.Join(snippetsOut, "apiv1/snippet_metadata.pubsub.json"),
+	} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("expected %s to exist: %v", path, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outdir, "cloud.google.com")); !os.IsNotExist(err) {
+		t.Errorf("cloud.google.com should have been removed")
+	}
+}
+
+func TestRelocateGeneratedFilesOtherModule(t *testing.T) {
+	outdir := t.TempDir()
+	writeFiles(t, outdir, map[string]string{
+		"cloud.google.com/go/secretmanager/apiv1/secret_manager_client.go": "",
+		"cloud.google.com/go/iam/apiv1/iampb/policy.pb.go":                 "",
+	})
+	library := &config.Library{Name: "secretmanager"}
+	err := relocateGeneratedFiles(outdir, filepath.Join(t.TempDir(), "secretmanager"), library)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if want := "cloud.google.com/go/iam/apiv1/iampb/policy.pb.go"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error %q does not mention %s", err, want)
+	}
+	if _, err := os.Stat(filepath.Join(outdir, "cloud.google.com/go/iam/apiv1/iampb/policy.pb.go")); err != nil {
+		t.Errorf("the files of the other module should not be deleted: %v", err)
+	}
+}
+
+func TestRequiredModules(t *testing.T) {
+	googleapisDir := t.TempDir()
+	writeFiles(t, googleapisDir, map[string]string{
+		"google/cloud/secretmanager/v1/service.proto": `syntax = "proto3";
+import "google/api/annotations.proto";
+import "google/iam/v1/iam_policy.proto";
+import public "google/protobuf/empty.proto";
+import "google/cloud/secretmanager/v1/resources.proto";
+`,
+		"google/cloud/secretmanager/v1/resources.proto": `syntax = "proto3";
+import "google/type/expr.proto";
+`,
+		"google/cloud/secretmanager/logging/v1/log.proto": `syntax = "proto3";
+import "google/longrunning/operations.proto";
+`,
+	})
+
+	for _, test := range []struct {
+		name    string
+		apis    []string
+		library *config.Library
+		want    []string
+	}{
+		{
+			name:    "gapic",
+			apis:    []string{"google/cloud/secretmanager/v1"},
+			library: &config.Library{Name: "secretmanager"},
+			want: []string{
+				"cloud.google.com/go/iam",
+				"github.com/googleapis/gax-go/v2",
+				"google.golang.org/api",
+				"google.golang.org/genproto",
+				"google.golang.org/genproto/googleapis/api",
+				"google.golang.org/grpc",
+				"google.golang.org/protobuf",
+			},
+		},
+		{
+			name: "proto only",
+			apis: []string{"google/cloud/secretmanager/logging/v1"},
+			library: &config.Library{
+				Name: "secretmanager",
+				Go: &config.GoModule{
+					APIs: []config.GoAPI{{Path: "google/cloud/secretmanager/logging/v1", DisableGapic: true}},
+				},
+			},
+			want: []string{"cloud.google.com/go/longrunning"},
+		},
+		{
+			name:    "own module is not required",
+			apis:    []string{"google/cloud/secretmanager/logging/v1"},
+			library: &config.Library{Name: "longrunning"},
+			want: []string{
+				"github.com/googleapis/gax-go/v2",
+				"google.golang.org/api",
+				"google.golang.org/grpc",
+				"google.golang.org/protobuf",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := requiredModules(googleapisDir, test.apis, test.library)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFindModules(t *testing.T) {
+	repoRoot := t.TempDir()
+	writeFiles(t, repoRoot, map[string]string{
+		"go.mod":                               "",
+		"secretmanager/go.mod":                 "",
+		"internal/generated/snippets/go.mod":   "",
+		"pubsub.librarian-staging/go.mod":      "",
+		"secretmanager/testdata/mod/go.mod":    "",
+		".git/go.mod":                          "",
+		"secretmanager/apiv1/secret_client.go": "",
+	})
+	got, err := findModules(repoRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]bool{
+		".":                             true,
+		"./secretmanager":               true,
+		"./internal/generated/snippets": true,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUpdateGoWork(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not found")
+	}
+	repoRoot := t.TempDir()
+	writeFiles(t, repoRoot, map[string]string{
+		"go.mod":               "module cloud.google.com/go\n\ngo 1.23\n",
+		"secretmanager/go.mod": "module cloud.google.com/go/secretmanager\n\ngo 1.23\n",
+		"go.work":              "go 1.23\n\nuse (\n\t.\n\t./removed\n)\n",
+	})
+	if err := updateGoWork(context.Background(), repoRoot); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(filepath.Join(repoRoot, "go.work"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(got), "./secretmanager") {
+		t.Errorf("go.work should use ./secretmanager, got:\n%s", got)
+	}
+	if strings.Contains(string(got), "./removed") {
+		t.Errorf("go.work should not use ./removed, got:\n%s", got)
+	}
+}
+
+func TestUpdateSnippetsModule(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not found")
+	}
+	repoRoot := t.TempDir()
+	library := &config.Library{Name: "secretmanager", Version: "1.2.3"}
+	if err := updateSnippetsModule(context.Background(), repoRoot, filepath.Join(repoRoot, "secretmanager"), library); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(filepath.Join(repoRoot, snippetsDir, "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"module cloud.google.com/go/internal/generated/snippets",
+		"require cloud.google.com/go/secretmanager v1.2.3",
+		"replace cloud.google.com/go/secretmanager => ../../../secretmanager",
+	} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("go.mod missing %q, got:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golang
+
+import (
+	"github.com/julieqiu/librarianx/internal/language/internal/toolchain"
+)
+
+// GenerateTools returns the external tools invoked when generating Go
+// libraries.
+func GenerateTools() []toolchain.Tool {
+	return []toolchain.Tool{
+		toolchain.Protoc(),
+		{
+			Name:        "protoc-gen-go",
+			VersionArgs: []string{"--version"},
+			Install:     "go install google.golang.org/protobuf/cmd/protoc-gen-go@latest",
+			Installer:   toolchain.GoInstaller("google.golang.org/protobuf/cmd/protoc-gen-go"),
+		},
+		{
+			Name:        "protoc-gen-go-grpc",
+			VersionArgs: []string{"--version"},
+			Install:     "go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@latest",
+			Installer:   toolchain.GoInstaller("google.golang.org/grpc/cmd/protoc-gen-go-grpc"),
+		},
+		{
+			Name:      "protoc-gen-go_gapic",
+			Install:   "go install github.com/googleapis/gapic-generator-go/cmd/protoc-gen-go_gapic@latest",
+			Installer: toolchain.GoInstaller("github.com/googleapis/gapic-generator-go/cmd/protoc-gen-go_gapic"),
+		},
+		{
+			Name:      "goimports",
+			Install:   "go install golang.org/x/tools/cmd/goimports@latest",
+			Installer: toolchain.GoInstaller("golang.org/x/tools/cmd/goimports"),
+		},
+		{
+			Name:        "go",
+			VersionArgs: []string{"version"},
+			MinVersion:  goVersion,
+			Install:     "see https://go.dev/doc/install",
+		},
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package googleapis locates the inputs of an API in a googleapis checkout
+// for the language backends.
+package googleapis
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/julieqiu/librarianx/internal/config"
+)
+
+// GRPCServiceConfig returns the gRPC service config (retry and timeout
+// settings) of apiPath: library.GRPCServiceConfig relative to the API
+// directory, otherwise the *_grpc_service_config.json in the API directory.
+// It returns an empty string if the API has no gRPC service config.
+func GRPCServiceConfig(library *config.Library, googleapisDir, apiPath string) string {
+	if library.GRPCServiceConfig != "" {
+		return filepath.Join(googleapisDir, apiPath, library.GRPCServiceConfig)
+	}
+	matches, err := filepath.Glob(filepath.Join(googleapisDir, apiPath, "*_grpc_service_config.json"))
+	if err == nil && len(matches) > 0 {
+		return matches[0]
+	}
+	return ""
+}
+
+// CopyProtos copies the protos of apiPath into dst, keeping their path
+// relative to the googleapis root.
+func CopyProtos(googleapisDir, apiPath, dst string) error {
+	matches, err := filepath.Glob(filepath.Join(googleapisDir, apiPath, "*.proto"))
+	if err != nil {
+		return err
+	}
+	target := filepath.Join(dst, filepath.FromSlash(apiPath))
+	if err := os.MkdirAll(target, 0755); err != nil {
+		return err
+	}
+	for _, src := range matches {
+		content, err := os.ReadFile(src)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(target, filepath.Base(src)), content, 0644); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", src, err)
+		}
+	}
+	return nil
+}
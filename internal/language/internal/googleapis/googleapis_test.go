@@ -0,0 +1,94 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googleapis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/config"
+)
+
+func TestGRPCServiceConfig(t *testing.T) {
+	googleapisDir := t.TempDir()
+	apiPath := "google/cloud/secretmanager/v1"
+	writeFile(t, filepath.Join(googleapisDir, apiPath, "secretmanager_grpc_service_config.json"), "{}")
+	for _, test := range []struct {
+		name    string
+		library *config.Library
+		apiPath string
+		want    string
+	}{
+		{
+			name:    "glob",
+			library: &config.Library{},
+			apiPath: apiPath,
+			want:    filepath.Join(googleapisDir, apiPath, "secretmanager_grpc_service_config.json"),
+		},
+		{
+			name:    "library override",
+			library: &config.Library{GRPCServiceConfig: "custom_grpc_service_config.json"},
+			apiPath: apiPath,
+			want:    filepath.Join(googleapisDir, apiPath, "custom_grpc_service_config.json"),
+		},
+		{
+			name:    "missing",
+			library: &config.Library{},
+			apiPath: "google/cloud/other/v1",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := GRPCServiceConfig(test.library, googleapisDir, test.apiPath)
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCopyProtos(t *testing.T) {
+	googleapisDir := t.TempDir()
+	apiPath := "google/cloud/secretmanager/v1"
+	writeFile(t, filepath.Join(googleapisDir, apiPath, "service.proto"), "syntax = \"proto3\";")
+	writeFile(t, filepath.Join(googleapisDir, apiPath, "BUILD.bazel"), "")
+
+	dst := t.TempDir()
+	if err := CopyProtos(googleapisDir, apiPath, dst); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(filepath.Join(dst, apiPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.Name())
+	}
+	if diff := cmp.Diff([]string{"service.proto"}, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,351 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package java provides functionality for generating Java client libraries.
+package java
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/googleapis"
+	"github.com/julieqiu/librarianx/internal/language/internal/settings"
+	"github.com/julieqiu/librarianx/internal/language/internal/staging"
+	"github.com/julieqiu/librarianx/internal/language/internal/toolchain"
+)
+
+const (
+	// defaultGroupID is the Maven group ID of client libraries.
+	defaultGroupID = "com.google.cloud"
+
+	// gapicSrcjar is the file the gapic-generator-java plugin writes into
+	// its output archive.
+	gapicSrcjar = "temp-codegen.srcjar"
+)
+
+// Create creates a new Java client library.
+func Create(ctx context.Context, library *config.Library, resolved *settings.Settings, repoDir, googleapisDir, serviceConfigPath string) error {
+	if err := toolchain.Verify(ctx, GenerateTools()); err != nil {
+		return err
+	}
+	return Generate(ctx, library, resolved, repoDir, googleapisDir, serviceConfigPath)
+}
+
+// Generate generates a Java client library.
+// Each API is generated into a proto-*, grpc-* and client module of the
+// library, which are scaffolded with pom.xml files the first time.
+// The library is generated into a staging directory next to the output directory,
+// which only replaces the output directory if generation succeeds.
+// The output directory is relative to repoDir.
+func Generate(ctx context.Context, library *config.Library, resolved *settings.Settings, repoDir, googleapisDir, serviceConfigPath string) error {
+	outdir, err := filepath.Abs(filepath.Join(repoDir, resolved.Output))
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path for output directory: %w", err)
+	}
+
+	apiPaths := config.GetLibraryAPIs(library)
+	if len(apiPaths) == 0 {
+		return fmt.Errorf("no APIs specified for library %s", library.Name)
+	}
+	sort.Strings(apiPaths)
+	modules := newModules(library, apiPaths, resolved.Transport)
+
+	// versions.txt lives in the repository root, next to librarian.yaml.
+	// New modules start at the library version.
+	versionsPath := filepath.Join(repoDir, versionsFile)
+	versions, err := readVersions(versionsPath)
+	if err != nil {
+		return err
+	}
+	version := initialVersion(library)
+	for _, artifact := range modules.artifacts() {
+		if _, ok := versions[artifact]; !ok {
+			versions[artifact] = moduleVersion{Released: version, Current: version}
+		}
+	}
+
+	stage, err := staging.NewKept(outdir, library.Keep, cleanOutputDirectory)
+	if err != nil {
+		return err
+	}
+	for _, apiPath := range apiPaths {
+		apiServiceConfig := library.APIServiceConfigs[apiPath]
+		if apiServiceConfig == "" {
+			apiServiceConfig = serviceConfigPath
+		}
+		if err := generateAPI(ctx, library, resolved, googleapisDir, apiPath, apiServiceConfig, stage.Dir, modules); err != nil {
+			return stage.Fail(fmt.Errorf("failed to generate API %s: %w", apiPath, err))
+		}
+	}
+	if err := scaffold(stage.Dir, modules, versions); err != nil {
+		return stage.Fail(err)
+	}
+	if err := stage.Commit(); err != nil {
+		return err
+	}
+	return addVersions(versionsPath, modules.artifacts(), version)
+}
+
+// initialVersion returns the version recorded for new modules of library.
+func initialVersion(library *config.Library) string {
+	if library.Version != "" {
+		return library.Version
+	}
+	return "0.1.0"
+}
+
+// modules describes the Maven modules of a library.
+type modules struct {
+	// GroupID is the group ID of the client module.
+	GroupID string
+
+	// ArtifactID is the artifact ID of the client module.
+	ArtifactID string
+
+	// Grpc reports whether grpc-* modules are generated.
+	Grpc bool
+
+	// APIs lists the API version suffixes (e.g. "v1"), one per proto module.
+	APIs []string
+}
+
+func newModules(library *config.Library, apiPaths []string, transport string) *modules {
+	m := &modules{
+		GroupID:    defaultGroupID,
+		ArtifactID: artifactID(library),
+		Grpc:       transport == "" || strings.Contains(transport, "grpc"),
+	}
+	if library.Java != nil && library.Java.GroupID != "" {
+		m.GroupID = library.Java.GroupID
+	}
+	for _, apiPath := range apiPaths {
+		m.APIs = append(m.APIs, path.Base(apiPath))
+	}
+	return m
+}
+
+// artifactID returns the Maven artifact ID of library.
+func artifactID(library *config.Library) string {
+	if library.Java != nil && library.Java.ArtifactID != "" {
+		return library.Java.ArtifactID
+	}
+	if strings.HasPrefix(library.Name, "google-") {
+		return library.Name
+	}
+	return "google-cloud-" + library.Name
+}
+
+// ProtoModule returns the proto module name for the API version api.
+func (m *modules) ProtoModule(api string) string {
+	return "proto-" + m.ArtifactID + "-" + api
+}
+
+// GrpcModule returns the grpc module name for the API version api.
+func (m *modules) GrpcModule(api string) string {
+	return "grpc-" + m.ArtifactID + "-" + api
+}
+
+// artifacts returns all artifact IDs of the library, client module first.
+func (m *modules) artifacts() []string {
+	artifacts := []string{m.ArtifactID}
+	for _, api := range m.APIs {
+		if m.Grpc {
+			artifacts = append(artifacts, m.GrpcModule(api))
+		}
+		artifacts = append(artifacts, m.ProtoModule(api))
+	}
+	return artifacts
+}
+
+// generateAPI runs protoc for a single API and lays out the results into
+// the modules of the library below outdir.
+func generateAPI(ctx context.Context, library *config.Library, resolved *settings.Settings, googleapisDir, apiPath, serviceConfigPath, outdir string, m *modules) error {
+	api := path.Base(apiPath)
+	protoDir := filepath.Join(outdir, m.ProtoModule(api))
+	grpcDir := ""
+	if m.Grpc {
+		grpcDir = filepath.Join(outdir, m.GrpcModule(api))
+	}
+
+	tmp, err := os.MkdirTemp("", "librarian-java-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	for _, dir := range []string{protoDir, grpcDir} {
+		if dir == "" {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Join(dir, "src", "main", "java"), 0755); err != nil {
+			return err
+		}
+	}
+
+	gapicOut := filepath.Join(tmp, "gapic.zip")
+	args := protocArgs(apiPath, protocOptions{
+		protoOut:          filepath.Join(protoDir, "src", "main", "java"),
+		grpcOut:           javaSrc(grpcDir),
+		gapicOut:          gapicOut,
+		transport:         resolved.Transport,
+		restNumericEnums:  resolved.RestNumericEnums,
+		grpcServiceConfig: googleapis.GRPCServiceConfig(library, googleapisDir, apiPath),
+		serviceConfig:     serviceConfigPath,
+	})
+	cmdStr := "protoc " + strings.Join(args, " ")
+	fmt.Fprintf(os.Stderr, "\nRunning: %s\n", cmdStr)
+	cmd := exec.CommandContext(ctx, "sh", "-c", cmdStr)
+	cmd.Dir = googleapisDir
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("protoc command failed: %w", err)
+	}
+
+	// The plugin writes a srcjar into the output archive
+	if err := unzip(gapicOut, tmp, func(name string) string { return name }); err != nil {
+		return err
+	}
+	if err := unzip(filepath.Join(tmp, gapicSrcjar), outdir, gapicLayout(m.ArtifactID, m.ProtoModule(api))); err != nil {
+		return err
+	}
+	return googleapis.CopyProtos(googleapisDir, apiPath, filepath.Join(protoDir, "src", "main", "proto"))
+}
+
+// protocOptions are the inputs of a single protoc invocation.
+type protocOptions struct {
+	protoOut          string
+	grpcOut           string
+	gapicOut          string
+	transport         string
+	restNumericEnums  bool
+	grpcServiceConfig string
+	serviceConfig     string
+}
+
+// protocArgs returns the protoc arguments generating apiPath. The
+// gapic-generator-java output goes into an archive, since the plugin emits a
+// single srcjar.
+func protocArgs(apiPath string, opts protocOptions) []string {
+	args := []string{
+		filepath.Join(apiPath, "*.proto"),
+		fmt.Sprintf("--java_out=%s", opts.protoOut),
+	}
+	if opts.grpcOut != "" {
+		args = append(args, fmt.Sprintf("--grpc-java_out=%s", opts.grpcOut))
+	}
+	args = append(args, fmt.Sprintf("--java_gapic_out=metadata:%s", opts.gapicOut))
+
+	var gapicOpts []string
+	if opts.transport != "" {
+		gapicOpts = append(gapicOpts, fmt.Sprintf("transport=%s", opts.transport))
+	}
+	if opts.restNumericEnums {
+		gapicOpts = append(gapicOpts, "rest-numeric-enums")
+	}
+	if opts.grpcServiceConfig != "" {
+		gapicOpts = append(gapicOpts, fmt.Sprintf("grpc-service-config=%s", opts.grpcServiceConfig))
+	}
+	if opts.serviceConfig != "" {
+		gapicOpts = append(gapicOpts, fmt.Sprintf("api-service-config=%s", opts.serviceConfig))
+	}
+	if len(gapicOpts) > 0 {
+		args = append(args, fmt.Sprintf("--java_gapic_opt=%s", strings.Join(gapicOpts, ",")))
+	}
+	return args
+}
+
+// javaSrc returns the Java source directory of the module in dir, or an
+// empty string if dir is empty.
+func javaSrc(dir string) string {
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "src", "main", "java")
+}
+
+// gapicLayout maps the entries of the gapic srcjar to their location in the
+// library. Client sources go into the client module, resource names into the
+// proto module and samples into samples/. Everything else is dropped.
+func gapicLayout(clientModule, protoModule string) func(name string) string {
+	return func(name string) string {
+		switch {
+		case strings.HasPrefix(name, "src/"):
+			return path.Join(clientModule, name)
+		case strings.HasPrefix(name, "proto/src/"):
+			return path.Join(protoModule, strings.TrimPrefix(name, "proto/"))
+		case strings.HasPrefix(name, "samples/"):
+			return name
+		}
+		return ""
+	}
+}
+
+// unzip extracts the archive at src into dst. rename maps each entry name
+// to its destination relative to dst; entries mapped to "" are skipped.
+func unzip(src, dst string, rename func(name string) string) error {
+	r, err := zip.OpenReader(src)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", src, err)
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		name := rename(f.Name)
+		if name == "" {
+			continue
+		}
+		target := filepath.Join(dst, filepath.FromSlash(name))
+		if !strings.HasPrefix(target, filepath.Clean(dst)+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid path %q in %s", f.Name, src)
+		}
+		if err := extractFile(f, target); err != nil {
+			return fmt.Errorf("failed to extract %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func extractFile(f *zip.File, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	out, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, rc); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
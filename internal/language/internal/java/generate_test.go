@@ -0,0 +1,261 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package java
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/config"
+)
+
+func TestProtocArgs(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		opts protocOptions
+		want []string
+	}{
+		{
+			name: "grpc+rest",
+			opts: protocOptions{
+				protoOut:          "/out/proto-google-cloud-secretmanager-v1/src/main/java",
+				grpcOut:           "/out/grpc-google-cloud-secretmanager-v1/src/main/java",
+				gapicOut:          "/tmp/gapic.zip",
+				transport:         "grpc+rest",
+				restNumericEnums:  true,
+				grpcServiceConfig: "/googleapis/google/cloud/secretmanager/v1/secretmanager_grpc_service_config.json",
+				serviceConfig:     "/googleapis/google/cloud/secretmanager/v1/secretmanager_v1.yaml",
+			},
+			want: []string{
+				"google/cloud/secretmanager/v1/*.proto",
+				"--java_out=/out/proto-google-cloud-secretmanager-v1/src/main/java",
+				"--grpc-java_out=/out/grpc-google-cloud-secretmanager-v1/src/main/java",
+				"--java_gapic_out=metadata:/tmp/gapic.zip",
+				"--java_gapic_opt=transport=grpc+rest,rest-numeric-enums,grpc-service-config=/googleapis/google/cloud/secretmanager/v1/secretmanager_grpc_service_config.json,api-service-config=/googleapis/google/cloud/secretmanager/v1/secretmanager_v1.yaml",
+			},
+		},
+		{
+			name: "rest only",
+			opts: protocOptions{
+				protoOut:  "/out/proto",
+				gapicOut:  "/tmp/gapic.zip",
+				transport: "rest",
+			},
+			want: []string{
+				"google/cloud/secretmanager/v1/*.proto",
+				"--java_out=/out/proto",
+				"--java_gapic_out=metadata:/tmp/gapic.zip",
+				"--java_gapic_opt=transport=rest",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := protocArgs("google/cloud/secretmanager/v1", test.opts)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestModules(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		library   *config.Library
+		transport string
+		want      []string
+	}{
+		{
+			name:      "grpc+rest",
+			library:   &config.Library{Name: "secretmanager"},
+			transport: "grpc+rest",
+			want: []string{
+				"google-cloud-secretmanager",
+				"grpc-google-cloud-secretmanager-v1",
+				"proto-google-cloud-secretmanager-v1",
+				"grpc-google-cloud-secretmanager-v1beta2",
+				"proto-google-cloud-secretmanager-v1beta2",
+			},
+		},
+		{
+			name:      "rest",
+			library:   &config.Library{Name: "secretmanager"},
+			transport: "rest",
+			want: []string{
+				"google-cloud-secretmanager",
+				"proto-google-cloud-secretmanager-v1",
+				"proto-google-cloud-secretmanager-v1beta2",
+			},
+		},
+		{
+			name:      "artifact id",
+			library:   &config.Library{Name: "secretmanager", Java: &config.JavaPackage{ArtifactID: "google-cloud-secrets"}},
+			transport: "rest",
+			want: []string{
+				"google-cloud-secrets",
+				"proto-google-cloud-secrets-v1",
+				"proto-google-cloud-secrets-v1beta2",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			apis := []string{"google/cloud/secretmanager/v1", "google/cloud/secretmanager/v1beta2"}
+			got := newModules(test.library, apis, test.transport).artifacts()
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnzipGapicLayout(t *testing.T) {
+	srcjar := filepath.Join(t.TempDir(), gapicSrcjar)
+	f, err := os.Create(srcjar)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := zip.NewWriter(f)
+	for _, name := range []string{
+		"src/main/java/com/google/cloud/secretmanager/v1/SecretManagerServiceClient.java",
+		"src/test/java/com/google/cloud/secretmanager/v1/SecretManagerServiceClientTest.java",
+		"proto/src/main/java/com/google/cloud/secretmanager/v1/SecretName.java",
+		"samples/snippets/generated/secretmanagerservice/create/SyncCreateSecret.java",
+		"gapic_metadata.json",
+	} {
+		if _, err := w.Create(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	outdir := t.TempDir()
+	if err := unzip(srcjar, outdir, gapicLayout("google-cloud-secretmanager", "proto-google-cloud-secretmanager-v1")); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	err = filepath.WalkDir(outdir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(outdir, path)
+		got = append(got, filepath.ToSlash(rel))
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"google-cloud-secretmanager/src/main/java/com/google/cloud/secretmanager/v1/SecretManagerServiceClient.java",
+		"google-cloud-secretmanager/src/test/java/com/google/cloud/secretmanager/v1/SecretManagerServiceClientTest.java",
+		"proto-google-cloud-secretmanager-v1/src/main/java/com/google/cloud/secretmanager/v1/SecretName.java",
+		"samples/snippets/generated/secretmanagerservice/create/SyncCreateSecret.java",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestScaffold(t *testing.T) {
+	outdir := t.TempDir()
+	m := newModules(&config.Library{Name: "secretmanager"}, []string{"google/cloud/secretmanager/v1"}, "grpc+rest")
+	versions := map[string]moduleVersion{
+		"google-cloud-secretmanager":          {Released: "2.1.0", Current: "2.1.0"},
+		"grpc-google-cloud-secretmanager-v1":  {Released: "2.1.0", Current: "2.1.0"},
+		"proto-google-cloud-secretmanager-v1": {Released: "2.1.0", Current: "2.1.0"},
+	}
+	handwritten := filepath.Join(outdir, "google-cloud-secretmanager", "pom.xml")
+	if err := os.MkdirAll(filepath.Dir(handwritten), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(handwritten, []byte("handwritten"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := scaffold(outdir, m, versions); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{
+		"pom.xml",
+		"grpc-google-cloud-secretmanager-v1/pom.xml",
+		"proto-google-cloud-secretmanager-v1/pom.xml",
+	} {
+		if _, err := os.Stat(filepath.Join(outdir, path)); err != nil {
+			t.Errorf("expected %s to exist: %v", path, err)
+		}
+	}
+	got, err := os.ReadFile(handwritten)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "handwritten" {
+		t.Errorf("existing pom.xml was overwritten: %s", got)
+	}
+}
+
+func TestCleanOutputDirectory(t *testing.T) {
+	outdir := t.TempDir()
+	for _, path := range []string{
+		"pom.xml",
+		"CHANGELOG.md",
+		"google-cloud-secretmanager/pom.xml",
+		"google-cloud-secretmanager/src/main/java/Client.java",
+		"google-cloud-secretmanager/src/main/java/Handwritten.java",
+		"proto-google-cloud-secretmanager-v1/src/main/java/Secret.java",
+	} {
+		full := filepath.Join(outdir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cleanOutputDirectory(outdir, []string{"google-cloud-secretmanager/src/main/java/Handwritten.java"}); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	err := filepath.WalkDir(outdir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || path == outdir {
+			return err
+		}
+		rel, err := filepath.Rel(outdir, path)
+		got = append(got, filepath.ToSlash(rel))
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"CHANGELOG.md",
+		"google-cloud-secretmanager",
+		"google-cloud-secretmanager/pom.xml",
+		"google-cloud-secretmanager/src",
+		"google-cloud-secretmanager/src/main",
+		"google-cloud-secretmanager/src/main/java",
+		"google-cloud-secretmanager/src/main/java/Handwritten.java",
+		"pom.xml",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
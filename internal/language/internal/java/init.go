@@ -0,0 +1,38 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package java
+
+import (
+	"github.com/julieqiu/librarianx/internal/config"
+)
+
+// ConfigDefault returns the default configuration for Java libraries.
+func ConfigDefault() *config.Default {
+	return &config.Default{
+		Output: "java-{name}/",
+		Generate: &config.DefaultGenerate{
+			Auto:             true,
+			OneLibraryPer:    "api",
+			Transport:        "grpc+rest",
+			RestNumericEnums: true,
+			ReleaseLevel:     "stable",
+		},
+		Release: &config.DefaultRelease{
+			TagFormat: "{name}-v{version}",
+			Remote:    "upstream",
+			Branch:    "main",
+		},
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package java
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/settings"
+)
+
+// alwaysKept lists the handwritten files every Java library keeps across
+// regeneration, in addition to all pom.xml files.
+var alwaysKept = []string{"CHANGELOG.md", "README.md"}
+
+// Clean deletes the generated files of library, preserving pom.xml files
+// and the files listed in library.Keep. The output directory is relative to
+// repoDir.
+func Clean(library *config.Library, resolved *settings.Settings, repoDir string) error {
+	return cleanOutputDirectory(filepath.Join(repoDir, resolved.Output), library.Keep)
+}
+
+// cleanOutputDirectory deletes everything in the output directory except
+// pom.xml files, CHANGELOG.md, README.md and the files listed in keepPaths.
+// Maven modules are scaffolded once, so their pom.xml files are never
+// regenerated.
+func cleanOutputDirectory(outdir string, keepPaths []string) error {
+	if _, err := os.Stat(outdir); os.IsNotExist(err) {
+		return nil
+	}
+
+	keep := map[string]bool{}
+	for _, path := range slices.Concat(keepPaths, alwaysKept) {
+		keep[strings.TrimSuffix(filepath.ToSlash(path), "/")] = true
+	}
+	err := filepath.WalkDir(outdir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() == "pom.xml" {
+			return nil
+		}
+		rel, err := filepath.Rel(outdir, path)
+		if err != nil {
+			return err
+		}
+		if isKept(filepath.ToSlash(rel), keep) {
+			return nil
+		}
+		return os.Remove(path)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clean %s: %w", outdir, err)
+	}
+	return removeEmptyDirs(outdir)
+}
+
+// isKept reports whether rel or one of its parent directories is kept.
+func isKept(rel string, keep map[string]bool) bool {
+	for p := rel; p != "." && p != "/"; p = filepath.ToSlash(filepath.Dir(p)) {
+		if keep[p] {
+			return true
+		}
+	}
+	return false
+}
+
+// removeEmptyDirs removes the empty directories below root.
+func removeEmptyDirs(root string) error {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(root, entry.Name())
+		if err := removeEmptyDirs(dir); err != nil {
+			return err
+		}
+		if children, err := os.ReadDir(dir); err == nil && len(children) == 0 {
+			if err := os.Remove(dir); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package java
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/semver"
+)
+
+// versionsFile records the released and current version of every Maven
+// module in the repository, one "artifact:released:current" per line.
+const versionsFile = "versions.txt"
+
+const versionsHeader = `# Format:
+# module:released-version:current-version
+`
+
+// moduleVersion is a line of versions.txt.
+type moduleVersion struct {
+	Released string
+	Current  string
+}
+
+// readVersions parses the versions.txt at path. A missing file has no
+// versions.
+func readVersions(path string) (map[string]moduleVersion, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]moduleVersion{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	versions := map[string]moduleVersion{}
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.Split(line, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("%s:%d: invalid line %q", path, i+1, line)
+		}
+		versions[parts[0]] = moduleVersion{Released: parts[1], Current: parts[2]}
+	}
+	return versions, nil
+}
+
+// addVersions records version for every artifact missing from the
+// versions.txt at path, creating the file if needed.
+func addVersions(path string, artifacts []string, version string) error {
+	versions, err := readVersions(path)
+	if err != nil {
+		return err
+	}
+	var added []string
+	for _, artifact := range artifacts {
+		if _, ok := versions[artifact]; ok {
+			continue
+		}
+		added = append(added, fmt.Sprintf("%s:%s:%s\n", artifact, version, version))
+	}
+	if len(added) == 0 {
+		return nil
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		content = []byte(versionsHeader + "\n")
+	} else if err != nil {
+		return err
+	}
+	if len(content) > 0 && !strings.HasSuffix(string(content), "\n") {
+		content = append(content, '\n')
+	}
+	content = append(content, strings.Join(added, "")...)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// BumpVersions bumps the version of every module in versions.txt, updates
+// the versions marked in pom.xml files and records the new library versions
+// in librarian.yaml.
+func BumpVersions(ctx context.Context, cfg *config.Config, configPath string) error {
+	if cfg.Versions == nil {
+		cfg.Versions = make(map[string]string)
+	}
+
+	content, err := os.ReadFile(versionsFile)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", versionsFile, err)
+	}
+	updated, bumped, err := bumpVersionsFile(string(content))
+	if err != nil {
+		return fmt.Errorf("failed to bump %s: %w", versionsFile, err)
+	}
+	if err := os.WriteFile(versionsFile, []byte(updated), 0644); err != nil {
+		return err
+	}
+
+	err = filepath.WalkDir(".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != "." && (strings.HasPrefix(d.Name(), ".") || d.Name() == "target") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() != "pom.xml" {
+			return nil
+		}
+		return updatePom(path, bumped)
+	})
+	if err != nil {
+		return err
+	}
+
+	for artifact, version := range bumped {
+		if strings.HasPrefix(artifact, "proto-") || strings.HasPrefix(artifact, "grpc-") {
+			continue
+		}
+		cfg.Versions[libraryName(cfg, artifact)] = version
+	}
+	return cfg.Write(configPath)
+}
+
+// bumpVersionsFile bumps the minor version of every module in the
+// versions.txt content and returns the new content and versions.
+func bumpVersionsFile(content string) (string, map[string]string, error) {
+	bumped := map[string]string{}
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		parts := strings.Split(trimmed, ":")
+		if len(parts) != 3 {
+			return "", nil, fmt.Errorf("invalid line %q", trimmed)
+		}
+		newVersion, err := semver.BumpMinor(parts[1])
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to bump %s: %w", parts[0], err)
+		}
+		lines[i] = fmt.Sprintf("%s:%s:%s", parts[0], newVersion, newVersion)
+		bumped[parts[0]] = newVersion
+	}
+	return strings.Join(lines, "\n"), bumped, nil
+}
+
+// versionMarker matches a version annotated for release tooling, e.g.
+// <version>1.2.0</version><!-- {x-version-update:google-cloud-foo:current} -->.
+var versionMarker = regexp.MustCompile(`<version>[^<]*</version>(\s*<!--\s*\{x-version-update:([^:]+):current\}\s*-->)`)
+
+// updatePom sets every marked version in the pom.xml at path to the new
+// version of its artifact.
+func updatePom(path string, versions map[string]string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	updated := versionMarker.ReplaceAllStringFunc(string(content), func(match string) string {
+		sub := versionMarker.FindStringSubmatch(match)
+		version, ok := versions[sub[2]]
+		if !ok {
+			return match
+		}
+		return "<version>" + version + "</version>" + sub[1]
+	})
+	if updated == string(content) {
+		return nil
+	}
+	return os.WriteFile(path, []byte(updated), 0644)
+}
+
+// libraryName returns the name of the library publishing artifact.
+func libraryName(cfg *config.Config, artifact string) string {
+	for _, library := range cfg.Libraries {
+		if artifactID(library) == artifact {
+			return library.Name
+		}
+	}
+	return strings.TrimPrefix(artifact, "google-cloud-")
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package java
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBumpVersionsFile(t *testing.T) {
+	content := versionsHeader + `
+google-cloud-secretmanager:2.1.0:2.1.1-SNAPSHOT
+proto-google-cloud-secretmanager-v1:2.1.0:2.1.1-SNAPSHOT
+`
+	got, bumped, err := bumpVersionsFile(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := versionsHeader + `
+google-cloud-secretmanager:2.2.0:2.2.0
+proto-google-cloud-secretmanager-v1:2.2.0:2.2.0
+`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	wantBumped := map[string]string{
+		"google-cloud-secretmanager":          "2.2.0",
+		"proto-google-cloud-secretmanager-v1": "2.2.0",
+	}
+	if diff := cmp.Diff(wantBumped, bumped); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAddVersions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), versionsFile)
+	if err := addVersions(path, []string{"google-cloud-secretmanager"}, "1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	if err := addVersions(path, []string{"google-cloud-secretmanager", "proto-google-cloud-secretmanager-v1"}, "0.1.0"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := readVersions(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]moduleVersion{
+		"google-cloud-secretmanager":          {Released: "1.0.0", Current: "1.0.0"},
+		"proto-google-cloud-secretmanager-v1": {Released: "0.1.0", Current: "0.1.0"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUpdatePom(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pom.xml")
+	content := `<project>
+  <version>2.1.0</version><!-- {x-version-update:google-cloud-secretmanager:current} -->
+  <dependency>
+    <artifactId>google-cloud-shared-dependencies</artifactId>
+    <version>3.0.0</version><!-- {x-version-update:google-cloud-shared-dependencies:current} -->
+  </dependency>
+  <dependency>
+    <artifactId>junit</artifactId>
+    <version>4.13.2</version>
+  </dependency>
+</project>
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := updatePom(path, map[string]string{"google-cloud-secretmanager": "2.2.0"}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Replace(content, "<version>2.1.0</version>", "<version>2.2.0</version>", 1)
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package java
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templatesFS embed.FS
+
+var templates = template.Must(template.New("").ParseFS(templatesFS, "templates/*.tmpl"))
+
+// pomData is the input of the pom.xml templates.
+type pomData struct {
+	*modules
+
+	// API is the API version of a proto or grpc module.
+	API string
+
+	versions map[string]moduleVersion
+}
+
+// Version returns the current version of artifact.
+func (d *pomData) Version(artifact string) string {
+	return d.versions[artifact].Current
+}
+
+// scaffold writes the pom.xml files of the library in outdir and each of its
+// modules. Existing pom.xml files are handwritten after the first
+// generation and never replaced.
+func scaffold(outdir string, m *modules, versions map[string]moduleVersion) error {
+	data := &pomData{modules: m, versions: versions}
+	if err := writePom(filepath.Join(outdir, "pom.xml"), "parent.pom.xml.tmpl", data); err != nil {
+		return err
+	}
+	if err := writePom(filepath.Join(outdir, m.ArtifactID, "pom.xml"), "client.pom.xml.tmpl", data); err != nil {
+		return err
+	}
+	for _, api := range m.APIs {
+		data := &pomData{modules: m, API: api, versions: versions}
+		if err := writePom(filepath.Join(outdir, m.ProtoModule(api), "pom.xml"), "proto.pom.xml.tmpl", data); err != nil {
+			return err
+		}
+		if !m.Grpc {
+			continue
+		}
+		if err := writePom(filepath.Join(outdir, m.GrpcModule(api), "pom.xml"), "grpc.pom.xml.tmpl", data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writePom renders the template name to path unless path already exists.
+func writePom(path, name string, data *pomData) error {
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package java
+
+import (
+	"github.com/julieqiu/librarianx/internal/language/internal/toolchain"
+)
+
+// GenerateTools returns the external tools invoked when generating Java
+// libraries.
+func GenerateTools() []toolchain.Tool {
+	return []toolchain.Tool{
+		toolchain.Protoc(),
+		{
+			Name:    "protoc-gen-java_gapic",
+			Install: "install gapic-generator-java from https://github.com/googleapis/sdk-platform-java",
+		},
+		{
+			Name:    "protoc-gen-grpc-java",
+			Install: "download protoc-gen-grpc-java from https://github.com/grpc/grpc-java/tree/master/compiler",
+		},
+	}
+}
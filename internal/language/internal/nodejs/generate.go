@@ -0,0 +1,228 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nodejs provides functionality for generating Node.js client libraries.
+package nodejs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/googleapis"
+	"github.com/julieqiu/librarianx/internal/language/internal/settings"
+	"github.com/julieqiu/librarianx/internal/language/internal/staging"
+	"github.com/julieqiu/librarianx/internal/language/internal/toolchain"
+	cp "github.com/otiai10/copy"
+)
+
+// initialVersion is the version of newly created packages.
+const initialVersion = "0.1.0"
+
+// Create creates a new Node.js client library.
+func Create(ctx context.Context, repo, repoDir string, library *config.Library, resolved *settings.Settings, googleapisDir, serviceConfigPath, defaultAPI string) error {
+	if err := toolchain.Verify(ctx, GenerateTools()); err != nil {
+		return err
+	}
+	if err := Generate(ctx, repo, repoDir, library, resolved, googleapisDir, serviceConfigPath, defaultAPI); err != nil {
+		return err
+	}
+
+	// Create CHANGELOG.md if it doesn't exist
+	changelogPath := filepath.Join(repoDir, resolved.Output, "CHANGELOG.md")
+	if _, err := os.Stat(changelogPath); errors.Is(err, fs.ErrNotExist) {
+		if err := os.WriteFile(changelogPath, []byte(changelogHeader), 0644); err != nil {
+			return fmt.Errorf("failed to write CHANGELOG.md: %w", err)
+		}
+	}
+	return nil
+}
+
+// Generate generates a Node.js client library.
+// All API versions are generated into the same package. The default API is
+// generated last, so its src/index.ts is the one exported by the package.
+// The library is generated into a staging directory next to the output directory,
+// which only replaces the output directory if generation succeeds.
+// The output directory is relative to repoDir.
+func Generate(ctx context.Context, repo, repoDir string, library *config.Library, resolved *settings.Settings, googleapisDir, serviceConfigPath, defaultAPI string) error {
+	outdir, err := filepath.Abs(filepath.Join(repoDir, resolved.Output))
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path for output directory: %w", err)
+	}
+
+	apiPaths := config.GetLibraryAPIs(library)
+	if len(apiPaths) == 0 {
+		return fmt.Errorf("no APIs specified for library %s", library.Name)
+	}
+
+	// Keep the released version unless librarian.yaml pins one
+	version := library.Version
+	if version == "" {
+		v, err := readPackageVersion(filepath.Join(outdir, "package.json"))
+		if err != nil {
+			return err
+		}
+		version = v
+	}
+	if version == "" {
+		version = initialVersion
+	}
+
+	stage, err := staging.NewKept(outdir, library.Keep, cleanOutputDirectory)
+	if err != nil {
+		return err
+	}
+	for _, apiPath := range orderAPIs(apiPaths, defaultAPI) {
+		apiServiceConfig := library.APIServiceConfigs[apiPath]
+		if apiServiceConfig == "" {
+			apiServiceConfig = serviceConfigPath
+		}
+		if err := generateAPI(ctx, library, resolved, googleapisDir, apiPath, apiServiceConfig, stage.Dir); err != nil {
+			return stage.Fail(fmt.Errorf("failed to generate API %s: %w", apiPath, err))
+		}
+	}
+	if err := scaffold(stage.Dir, library, resolved, version); err != nil {
+		return stage.Fail(err)
+	}
+	if serviceConfigPath != "" && repo != "" {
+		if err := config.GenerateRepoMetadata(library, "nodejs", repo, serviceConfigPath, stage.Dir, apiPaths); err != nil {
+			return stage.Fail(fmt.Errorf("failed to generate .repo-metadata.json: %w", err))
+		}
+	}
+	return stage.Commit()
+}
+
+// orderAPIs returns apiPaths with defaultAPI moved to the end.
+func orderAPIs(apiPaths []string, defaultAPI string) []string {
+	var ordered []string
+	var last []string
+	for _, apiPath := range apiPaths {
+		if apiPath == defaultAPI {
+			last = append(last, apiPath)
+			continue
+		}
+		ordered = append(ordered, apiPath)
+	}
+	return append(ordered, last...)
+}
+
+// packageName returns the npm package name of library.
+func packageName(library *config.Library) string {
+	if library.Nodejs != nil && library.Nodejs.PackageName != "" {
+		return library.Nodejs.PackageName
+	}
+	return "@google-cloud/" + strings.TrimPrefix(library.Name, "google-cloud-")
+}
+
+// generateAPI runs protoc for a single API and copies the results into
+// outdir. Files scaffolded by librarian are not copied, so the package keeps
+// its handwritten package.json and tsconfig.json.
+func generateAPI(ctx context.Context, library *config.Library, resolved *settings.Settings, googleapisDir, apiPath, serviceConfigPath, outdir string) error {
+	tmp, err := os.MkdirTemp("", "librarian-nodejs-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	args := protocArgs(apiPath, tmp, gapicOptions(library, resolved, googleapis.GRPCServiceConfig(library, googleapisDir, apiPath), serviceConfigPath))
+	cmdStr := "protoc " + strings.Join(args, " ")
+	fmt.Fprintf(os.Stderr, "\nRunning: %s\n", cmdStr)
+	cmd := exec.CommandContext(ctx, "sh", "-c", cmdStr)
+	cmd.Dir = googleapisDir
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("protoc command failed: %w", err)
+	}
+
+	err = cp.Copy(tmp, outdir, cp.Options{
+		Skip: func(info os.FileInfo, src, dest string) (bool, error) {
+			rel, err := filepath.Rel(tmp, src)
+			if err != nil {
+				return false, err
+			}
+			return isScaffolded(rel), nil
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy generated files: %w", err)
+	}
+	return googleapis.CopyProtos(googleapisDir, apiPath, filepath.Join(outdir, "protos"))
+}
+
+// protocArgs returns the protoc arguments generating apiPath into outdir.
+func protocArgs(apiPath, outdir string, opts []string) []string {
+	args := []string{
+		filepath.Join(apiPath, "*.proto"),
+		fmt.Sprintf("--typescript_gapic_out=%s", outdir),
+	}
+	if len(opts) > 0 {
+		args = append(args, fmt.Sprintf("--typescript_gapic_opt=%s", strings.Join(opts, ",")))
+	}
+	return args
+}
+
+// gapicOptions returns the options of the TypeScript gapic plugin.
+// The plugin generates grpc with a REST fallback by default, so the
+// transport is only passed when it is not "grpc+rest".
+func gapicOptions(library *config.Library, resolved *settings.Settings, grpcServiceConfig, serviceConfigPath string) []string {
+	opts := []string{"metadata", fmt.Sprintf("package-name=%s", packageName(library))}
+	if library.Nodejs != nil && library.Nodejs.MainService != "" {
+		opts = append(opts, fmt.Sprintf("main-service=%s", library.Nodejs.MainService))
+	}
+	if resolved.Transport != "" && resolved.Transport != "grpc+rest" {
+		opts = append(opts, fmt.Sprintf("transport=%s", resolved.Transport))
+	}
+	if resolved.RestNumericEnums {
+		opts = append(opts, "rest-numeric-enums")
+	}
+	if grpcServiceConfig != "" {
+		opts = append(opts, fmt.Sprintf("grpc-service-config=%s", grpcServiceConfig))
+	}
+	if serviceConfigPath != "" {
+		opts = append(opts, fmt.Sprintf("service-yaml=%s", serviceConfigPath))
+	}
+	return opts
+}
+
+// packageJSON is the subset of package.json used by librarian.
+type packageJSON struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Private bool   `json:"private"`
+}
+
+// readPackageVersion returns the version in the package.json at path, or an
+// empty string if the file does not exist.
+func readPackageVersion(path string) (string, error) {
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	var pkg packageJSON
+	if err := json.Unmarshal(contents, &pkg); err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return pkg.Version, nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodejs
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/settings"
+)
+
+func TestGapicOptions(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		library  *config.Library
+		resolved *settings.Settings
+		want     []string
+	}{
+		{
+			name:     "defaults",
+			library:  &config.Library{Name: "google-cloud-secretmanager"},
+			resolved: &settings.Settings{Transport: "grpc+rest", RestNumericEnums: true},
+			want: []string{
+				"metadata",
+				"package-name=@google-cloud/secretmanager",
+				"rest-numeric-enums",
+				"grpc-service-config=/googleapis/secretmanager_grpc_service_config.json",
+				"service-yaml=/googleapis/secretmanager_v1.yaml",
+			},
+		},
+		{
+			name: "overrides",
+			library: &config.Library{
+				Name:   "secretmanager",
+				Nodejs: &config.NodejsPackage{PackageName: "@google-cloud/secret-manager", MainService: "SecretManagerService"},
+			},
+			resolved: &settings.Settings{Transport: "rest"},
+			want: []string{
+				"metadata",
+				"package-name=@google-cloud/secret-manager",
+				"main-service=SecretManagerService",
+				"transport=rest",
+				"grpc-service-config=/googleapis/secretmanager_grpc_service_config.json",
+				"service-yaml=/googleapis/secretmanager_v1.yaml",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := gapicOptions(test.library, test.resolved, "/googleapis/secretmanager_grpc_service_config.json", "/googleapis/secretmanager_v1.yaml")
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProtocArgs(t *testing.T) {
+	got := protocArgs("google/cloud/secretmanager/v1", "/tmp/out", []string{"metadata", "rest-numeric-enums"})
+	want := []string{
+		"google/cloud/secretmanager/v1/*.proto",
+		"--typescript_gapic_out=/tmp/out",
+		"--typescript_gapic_opt=metadata,rest-numeric-enums",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOrderAPIs(t *testing.T) {
+	got := orderAPIs([]string{"google/cloud/secretmanager/v1", "google/cloud/secretmanager/v1beta2"}, "google/cloud/secretmanager/v1")
+	want := []string{"google/cloud/secretmanager/v1beta2", "google/cloud/secretmanager/v1"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestScaffold(t *testing.T) {
+	outdir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outdir, "tsconfig.json"), []byte("handwritten"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	library := &config.Library{Name: "google-cloud-secretmanager"}
+	resolved := &settings.Settings{Output: "packages/google-cloud-secretmanager/"}
+	if err := scaffold(outdir, library, resolved, "1.2.0"); err != nil {
+		t.Fatal(err)
+	}
+
+	contents, err := os.ReadFile(filepath.Join(outdir, "package.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got packageJSON
+	if err := json.Unmarshal(contents, &got); err != nil {
+		t.Fatalf("package.json is not valid JSON: %v\n%s", err, contents)
+	}
+	want := packageJSON{Name: "@google-cloud/secretmanager", Version: "1.2.0"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	tsconfig, err := os.ReadFile(filepath.Join(outdir, "tsconfig.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(tsconfig) != "handwritten" {
+		t.Errorf("existing tsconfig.json was overwritten: %s", tsconfig)
+	}
+}
+
+func TestCleanOutputDirectory(t *testing.T) {
+	outdir := t.TempDir()
+	for _, path := range []string{
+		"CHANGELOG.md",
+		"package.json",
+		"tsconfig.json",
+		".repo-metadata.json",
+		"src/v1/secret_manager_service_client.ts",
+		"src/helpers.ts",
+		"samples/quickstart.js",
+	} {
+		full := filepath.Join(outdir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cleanOutputDirectory(outdir, []string{"src/helpers.ts", "samples/"}); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	err := filepath.WalkDir(outdir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(outdir, path)
+		got = append(got, filepath.ToSlash(rel))
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"CHANGELOG.md",
+		"package.json",
+		"samples/quickstart.js",
+		"src/helpers.ts",
+		"tsconfig.json",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
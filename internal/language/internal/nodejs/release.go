@@ -0,0 +1,186 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodejs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/semver"
+)
+
+// changelogHeader is the title of every CHANGELOG.md.
+const changelogHeader = "# Changelog\n"
+
+// BumpVersions bumps the versions of all published package.json files,
+// adds a section for the new version to CHANGELOG.md and updates
+// librarian.yaml. The section lists the commits that changed the package
+// since its previous release tag.
+func BumpVersions(ctx context.Context, cfg *config.Config, configPath string) error {
+	if cfg.Versions == nil {
+		cfg.Versions = make(map[string]string)
+	}
+	date := time.Now().Format("2006-01-02")
+
+	err := filepath.WalkDir(".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != "." && (strings.HasPrefix(d.Name(), ".") || d.Name() == "node_modules" || d.Name() == "build") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() != "package.json" {
+			return nil
+		}
+
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var pkg packageJSON
+		if err := json.Unmarshal(contents, &pkg); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+
+		// Workspace roots and private packages are not published
+		if pkg.Version == "" || pkg.Private {
+			return nil
+		}
+
+		newVersion, err := semver.BumpMinor(pkg.Version)
+		if err != nil {
+			return fmt.Errorf("failed to bump version of %s: %w", path, err)
+		}
+		updated, err := setPackageVersion(string(contents), newVersion)
+		if err != nil {
+			return fmt.Errorf("failed to update %s: %w", path, err)
+		}
+		if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+			return err
+		}
+		dir := filepath.Dir(path)
+		name := libraryName(cfg, pkg.Name, dir)
+		notes, err := releaseNotes(ctx, dir, releaseTag(cfg, name, pkg.Version))
+		if err != nil {
+			return err
+		}
+		if err := updateChangelog(filepath.Join(dir, "CHANGELOG.md"), newVersion, date, notes); err != nil {
+			return err
+		}
+
+		// Update librarian.yaml
+		cfg.Versions[name] = newVersion
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Write updated config
+	return cfg.Write(configPath)
+}
+
+// versionField matches the top-level version of a package.json. npm writes
+// it before any nested object, so the first match is the package version.
+var versionField = regexp.MustCompile(`"version"\s*:\s*"[^"]*"`)
+
+// setPackageVersion replaces the version in the package.json contents,
+// preserving the rest of the file as written.
+func setPackageVersion(contents, version string) (string, error) {
+	loc := versionField.FindStringIndex(contents)
+	if loc == nil {
+		return "", errors.New("no version field found")
+	}
+	return contents[:loc[0]] + fmt.Sprintf(`"version": %q`, version) + contents[loc[1]:], nil
+}
+
+// releaseTag returns the git tag of version of the library name, using the
+// tag format of the repository.
+func releaseTag(cfg *config.Config, name, version string) string {
+	format := "{name}/v{version}"
+	if cfg.Default != nil && cfg.Default.Release != nil && cfg.Default.Release.TagFormat != "" {
+		format = cfg.Default.Release.TagFormat
+	}
+	tag := strings.ReplaceAll(format, "{name}", name)
+	return strings.ReplaceAll(tag, "{version}", version)
+}
+
+// releaseNotes returns the subjects of the commits that changed dir since
+// tag, newest first. If tag does not exist, for example because the package
+// was never released, all commits that changed dir are returned.
+func releaseNotes(ctx context.Context, dir, tag string) ([]string, error) {
+	args := []string{"log", "--format=%s"}
+	if err := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--quiet", "refs/tags/"+tag).Run(); err == nil {
+		args = append(args, tag+"..HEAD")
+	}
+	args = append(args, "--", dir)
+	out, err := exec.CommandContext(ctx, "git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the git history of %s: %w", dir, err)
+	}
+	var notes []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			notes = append(notes, line)
+		}
+	}
+	return notes, nil
+}
+
+// updateChangelog adds a section for version listing notes to the
+// CHANGELOG.md at path, creating the file if needed.
+func updateChangelog(path, version, date string, notes []string) error {
+	contents, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	body := strings.TrimPrefix(string(contents), changelogHeader)
+	section := fmt.Sprintf("\n## %s (%s)\n\n", version, date)
+	if len(notes) == 0 {
+		section += "- No changes since the previous release.\n"
+	}
+	for _, note := range notes {
+		section += "- " + note + "\n"
+	}
+	updated := changelogHeader + section + body
+	if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// libraryName returns the name of the library publishing the npm package
+// name in dir.
+func libraryName(cfg *config.Config, name, dir string) string {
+	for _, library := range cfg.Libraries {
+		if packageName(library) == name {
+			return library.Name
+		}
+	}
+	return filepath.Base(dir)
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodejs
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/config"
+)
+
+func TestBumpVersions(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	files := map[string]string{
+		"package.json": `{"name": "google-cloud-node", "private": true}`,
+		"packages/google-cloud-secretmanager/package.json": `{
+  "name": "@google-cloud/secret-manager",
+  "version": "6.1.1",
+  "dependencies": {
+    "google-gax": "^5.0.0"
+  }
+}
+`,
+		"packages/google-cloud-secretmanager/CHANGELOG.md":                  "# Changelog\n\n## 6.1.1 (2025-01-01)\n",
+		"packages/google-cloud-secretmanager/node_modules/gax/package.json": `{"name": "gax", "version": "1.0.0"}`,
+		"packages/google-cloud-tasks/package.json":                          `{"name": "@google-cloud/tasks", "version": "0.3.0"}`,
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := &config.Config{
+		Language: "nodejs",
+		Libraries: []*config.Library{
+			{Name: "secretmanager", Nodejs: &config.NodejsPackage{PackageName: "@google-cloud/secret-manager"}},
+		},
+	}
+	// secretmanager was released before, tasks was not.
+	runGit(t, "init")
+	runGit(t, "add", ".")
+	runGit(t, "commit", "-m", "chore: add packages")
+	runGit(t, "tag", "secretmanager/v6.1.1")
+	if err := os.WriteFile("packages/google-cloud-secretmanager/index.ts", nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, "add", ".")
+	runGit(t, "commit", "-m", "feat: add secret rotation")
+
+	if err := BumpVersions(t.Context(), cfg, filepath.Join(dir, "librarian.yaml")); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"secretmanager":      "6.2.0",
+		"google-cloud-tasks": "0.4.0",
+	}
+	if diff := cmp.Diff(want, cfg.Versions); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	got, err := os.ReadFile("packages/google-cloud-secretmanager/package.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantPackage := `{
+  "name": "@google-cloud/secret-manager",
+  "version": "6.2.0",
+  "dependencies": {
+    "google-gax": "^5.0.0"
+  }
+}
+`
+	if diff := cmp.Diff(wantPackage, string(got)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	got, err = os.ReadFile("packages/google-cloud-secretmanager/node_modules/gax/package.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != files["packages/google-cloud-secretmanager/node_modules/gax/package.json"] {
+		t.Errorf("node_modules should not be modified, got %s", got)
+	}
+	for path, want := range map[string]string{
+		"packages/google-cloud-secretmanager/CHANGELOG.md": "\n- feat: add secret rotation\n\n## 6.1.1 (2025-01-01)\n",
+		"packages/google-cloud-tasks/CHANGELOG.md":         ")\n\n- chore: add packages\n",
+	} {
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasSuffix(string(got), want) {
+			t.Errorf("%s = %q, want suffix %q", path, got, want)
+		}
+	}
+}
+
+func runGit(t *testing.T, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func TestUpdateChangelog(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		existing string
+		notes    []string
+		want     string
+	}{
+		{
+			name:  "new file",
+			notes: []string{"feat: add secret rotation"},
+			want:  "# Changelog\n\n## 0.2.0 (2025-06-01)\n\n- feat: add secret rotation\n",
+		},
+		{
+			name:     "existing",
+			existing: "# Changelog\n\n## 0.1.0 (2025-01-01)\n\n- Initial release.\n",
+			notes:    []string{"feat: add secret rotation", "fix: retry on UNAVAILABLE"},
+			want:     "# Changelog\n\n## 0.2.0 (2025-06-01)\n\n- feat: add secret rotation\n- fix: retry on UNAVAILABLE\n\n## 0.1.0 (2025-01-01)\n\n- Initial release.\n",
+		},
+		{
+			name:     "no changes",
+			existing: "# Changelog\n\n## 0.1.0 (2025-01-01)\n\n- Initial release.\n",
+			want:     "# Changelog\n\n## 0.2.0 (2025-06-01)\n\n- No changes since the previous release.\n\n## 0.1.0 (2025-01-01)\n\n- Initial release.\n",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "CHANGELOG.md")
+			if test.existing != "" {
+				if err := os.WriteFile(path, []byte(test.existing), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := updateChangelog(path, "0.2.0", "2025-06-01", test.notes); err != nil {
+				t.Fatal(err)
+			}
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, string(got)); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodejs
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/settings"
+)
+
+//go:embed templates/*.tmpl
+var templatesFS embed.FS
+
+var templates = template.Must(template.New("").ParseFS(templatesFS, "templates/*.tmpl"))
+
+// scaffoldedFiles maps the files librarian writes when a package is created
+// to their templates. They are handwritten afterwards and never replaced.
+var scaffoldedFiles = map[string]string{
+	"package.json":  "package.json.tmpl",
+	"tsconfig.json": "tsconfig.json.tmpl",
+}
+
+// isScaffolded reports whether rel, relative to the package root, is a
+// scaffolded file.
+func isScaffolded(rel string) bool {
+	_, ok := scaffoldedFiles[filepath.ToSlash(rel)]
+	return ok
+}
+
+// packageData is the input of the scaffolding templates.
+type packageData struct {
+	Name        string
+	Version     string
+	Description string
+	Directory   string
+}
+
+// scaffold writes the scaffolded files of library into outdir, unless they
+// already exist.
+func scaffold(outdir string, library *config.Library, resolved *settings.Settings, version string) error {
+	data := &packageData{
+		Name:        packageName(library),
+		Version:     version,
+		Description: fmt.Sprintf("%s client for Node.js", library.Name),
+		Directory:   filepath.ToSlash(filepath.Clean(resolved.Output)),
+	}
+	for file, name := range scaffoldedFiles {
+		path := filepath.Join(outdir, file)
+		if _, err := os.Stat(path); err == nil {
+			continue
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		var buf bytes.Buffer
+		if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+			return fmt.Errorf("failed to render %s: %w", name, err)
+		}
+		if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return nil
+}
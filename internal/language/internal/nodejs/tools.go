@@ -0,0 +1,37 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodejs
+
+import (
+	"github.com/julieqiu/librarianx/internal/language/internal/toolchain"
+)
+
+// GenerateTools returns the external tools invoked when generating Node.js
+// libraries.
+func GenerateTools() []toolchain.Tool {
+	return []toolchain.Tool{
+		toolchain.Protoc(),
+		{
+			Name:    "protoc-gen-typescript_gapic",
+			Install: "see https://github.com/googleapis/gapic-generator-typescript",
+		},
+	}
+}
+
+// ReleaseTools returns the external tools invoked when releasing Node.js
+// packages. The changelog entries are read from the git history.
+func ReleaseTools() []toolchain.Tool {
+	return []toolchain.Tool{toolchain.Git()}
+}
@@ -0,0 +1,273 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package protopatch applies documentation overrides to the comments in
+// .proto files. The backends that run protoc use it to fix the same broken
+// comments that sidekick fixes in its model.
+package protopatch
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bufbuild/protocompile/ast"
+	"github.com/bufbuild/protocompile/parser"
+	"github.com/bufbuild/protocompile/reporter"
+	"github.com/julieqiu/librarianx/internal/config"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Overlay is where protoc runs to compile the protos of an API with the
+// documentation overrides applied.
+type Overlay struct {
+	// Dir is the directory to run protoc from.
+	Dir string
+
+	// Args are the protoc arguments that make the patched protos shadow the
+	// ones in googleapis.
+	Args []string
+
+	temp string
+}
+
+// New copies the protos of the API at apiPath, relative to googleapisDir, into
+// a temporary directory and applies the overrides to their comments. It
+// returns an error listing all the overrides that do not apply.
+//
+// Without overrides, protoc runs from googleapisDir as usual. Call Close to
+// remove the temporary directory.
+func New(googleapisDir, apiPath string, overrides []config.DocumentationOverride) (*Overlay, error) {
+	if len(overrides) == 0 {
+		return &Overlay{Dir: googleapisDir}, nil
+	}
+	temp, err := os.MkdirTemp("", "librarian-protos-")
+	if err != nil {
+		return nil, err
+	}
+	overlay := &Overlay{
+		Dir:  temp,
+		Args: []string{"--proto_path=.", fmt.Sprintf("--proto_path=%s", googleapisDir)},
+		temp: temp,
+	}
+	if err := patchAPI(googleapisDir, temp, apiPath, overrides); err != nil {
+		overlay.Close()
+		return nil, err
+	}
+	return overlay, nil
+}
+
+// Close removes the patched protos.
+func (o *Overlay) Close() error {
+	if o.temp == "" {
+		return nil
+	}
+	return os.RemoveAll(o.temp)
+}
+
+// patchAPI copies the protos below apiPath from src to dst, applying the
+// overrides.
+func patchAPI(src, dst, apiPath string, overrides []config.DocumentationOverride) error {
+	comments := map[string]*comment{}
+	var files []*protoFile
+	root := filepath.Join(src, apiPath)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".proto" {
+			return err
+		}
+		name, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		file, err := readProtoFile(path, name)
+		if err != nil {
+			return err
+		}
+		for _, c := range file.comments {
+			comments[c.id] = c
+		}
+		files = append(files, file)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read the protos in %s: %w", apiPath, err)
+	}
+
+	var errs []error
+	for _, override := range overrides {
+		c, ok := comments[override.ID]
+		if !ok {
+			errs = append(errs, fmt.Errorf("cannot find element %s with a comment in the protos of %s to apply comment overrides", override.ID, apiPath))
+			continue
+		}
+		if !strings.Contains(c.text, override.Match) {
+			errs = append(errs, fmt.Errorf("comment override for %s did not match %q", override.ID, override.Match))
+			continue
+		}
+		c.text = strings.ReplaceAll(c.text, override.Match, override.Replace)
+		c.patched = true
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	for _, file := range files {
+		path := filepath.Join(dst, file.name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, file.render(), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// protoFile is a .proto file and the leading comments of its elements.
+type protoFile struct {
+	name     string
+	lines    []string
+	comments []*comment
+}
+
+// comment is the leading comment of an element. The comment spans the lines
+// [start, end) of the file. Only `//` comments are supported, as used in
+// googleapis.
+type comment struct {
+	id      string
+	start   int
+	end     int
+	indent  string
+	text    string
+	patched bool
+}
+
+func readProtoFile(path, name string) (*protoFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	handler := reporter.NewHandler(nil)
+	node, err := parser.Parse(name, bytes.NewReader(content), handler)
+	if err != nil {
+		return nil, err
+	}
+	result, err := parser.ResultFromAST(node, false, handler)
+	if err != nil {
+		return nil, err
+	}
+	file := &protoFile{name: name, lines: strings.Split(string(content), "\n")}
+	add := func(id string, n ast.Node) {
+		// Positions are 1-based, the comment ends on the line before the
+		// element.
+		if c := file.leadingComment(id, node.NodeInfo(n).Start().Line-1); c != nil {
+			file.comments = append(file.comments, c)
+		}
+	}
+	var addEnum func(prefix string, e *descriptorpb.EnumDescriptorProto)
+	addEnum = func(prefix string, e *descriptorpb.EnumDescriptorProto) {
+		id := prefix + "." + e.GetName()
+		add(id, result.EnumNode(e))
+		for _, v := range e.GetValue() {
+			add(id+"."+v.GetName(), result.EnumValueNode(v))
+		}
+	}
+	var addMessage func(prefix string, m *descriptorpb.DescriptorProto)
+	addMessage = func(prefix string, m *descriptorpb.DescriptorProto) {
+		id := prefix + "." + m.GetName()
+		if m.GetOptions().GetMapEntry() {
+			return
+		}
+		add(id, result.MessageNode(m))
+		for _, f := range m.GetField() {
+			add(id+"."+f.GetName(), result.FieldNode(f))
+		}
+		for _, e := range m.GetEnumType() {
+			addEnum(id, e)
+		}
+		for _, nested := range m.GetNestedType() {
+			addMessage(id, nested)
+		}
+	}
+	fd := result.FileDescriptorProto()
+	prefix := "." + fd.GetPackage()
+	for _, m := range fd.GetMessageType() {
+		addMessage(prefix, m)
+	}
+	for _, e := range fd.GetEnumType() {
+		addEnum(prefix, e)
+	}
+	for _, s := range fd.GetService() {
+		id := prefix + "." + s.GetName()
+		add(id, result.ServiceNode(s))
+		for _, m := range s.GetMethod() {
+			add(id+"."+m.GetName(), result.MethodNode(m))
+		}
+	}
+	return file, nil
+}
+
+// leadingComment returns the `//` comment ending on the line before the
+// element at the 0-based line, if any.
+func (f *protoFile) leadingComment(id string, line int) *comment {
+	start := line
+	for start > 0 && strings.HasPrefix(strings.TrimSpace(f.lines[start-1]), "//") {
+		start--
+	}
+	if start == line {
+		return nil
+	}
+	var text []string
+	for _, l := range f.lines[start:line] {
+		_, after, _ := strings.Cut(l, "//")
+		text = append(text, strings.TrimPrefix(after, " "))
+	}
+	first := f.lines[start]
+	return &comment{
+		id:     id,
+		start:  start,
+		end:    line,
+		indent: first[:len(first)-len(strings.TrimLeft(first, " \t"))],
+		text:   strings.Join(text, "\n"),
+	}
+}
+
+// render returns the file contents with the patched comments.
+func (f *protoFile) render() []byte {
+	lines := slices.Clone(f.lines)
+	// Replace the comments from the bottom up, so the line numbers of the
+	// comments above remain valid.
+	comments := slices.Clone(f.comments)
+	slices.SortFunc(comments, func(a, b *comment) int { return b.start - a.start })
+	for _, c := range comments {
+		if !c.patched {
+			continue
+		}
+		var patched []string
+		for _, l := range strings.Split(c.text, "\n") {
+			if l == "" {
+				patched = append(patched, c.indent+"//")
+				continue
+			}
+			patched = append(patched, c.indent+"// "+l)
+		}
+		lines = slices.Replace(lines, c.start, c.end, patched...)
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
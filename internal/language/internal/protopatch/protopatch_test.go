@@ -0,0 +1,150 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protopatch
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/config"
+)
+
+const testProto = `syntax = "proto3";
+
+package test.v1;
+
+// The secret service.
+service SecretService {
+  // Gets a secret.
+  rpc GetSecret(Secret) returns (Secret);
+}
+
+// A secret, with a tpyo.
+//
+// Second paragraph.
+message Secret {
+  // The secret name.
+  string name = 1;
+
+  map<string, string> labels = 2;
+
+  // A nested message.
+  message Nested {
+    // The state.
+    State state = 1;
+  }
+
+  // The states.
+  enum State {
+    // Unspecified.
+    STATE_UNSPECIFIED = 0;
+  }
+}
+`
+
+func writeTestProtos(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	apiDir := filepath.Join(dir, "test", "v1")
+	if err := os.MkdirAll(apiDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(apiDir, "secret.proto"), []byte(testProto), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestNew(t *testing.T) {
+	googleapisDir := writeTestProtos(t)
+	overlay, err := New(googleapisDir, "test/v1", []config.DocumentationOverride{
+		{ID: ".test.v1.Secret", Match: "tpyo", Replace: "typo"},
+		{ID: ".test.v1.Secret", Match: "Second paragraph.", Replace: "Second paragraph.\n\nThird paragraph."},
+		{ID: ".test.v1.SecretService.GetSecret", Match: "Gets", Replace: "Returns"},
+		{ID: ".test.v1.Secret.Nested.state", Match: "The state.", Replace: "The state of the secret."},
+		{ID: ".test.v1.Secret.State.STATE_UNSPECIFIED", Match: "Unspecified.", Replace: "Not set."},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer overlay.Close()
+
+	wantArgs := []string{"--proto_path=.", "--proto_path=" + googleapisDir}
+	if diff := cmp.Diff(wantArgs, overlay.Args); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+	got, err := os.ReadFile(filepath.Join(overlay.Dir, "test", "v1", "secret.proto"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.NewReplacer(
+		"// A secret, with a tpyo.\n//\n// Second paragraph.\n", "// A secret, with a typo.\n//\n// Second paragraph.\n//\n// Third paragraph.\n",
+		"  // Gets a secret.", "  // Returns a secret.",
+		"    // The state.", "    // The state of the secret.",
+		"    // Unspecified.", "    // Not set.",
+	).Replace(testProto)
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+
+	dir := overlay.Dir
+	if err := overlay.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("Close() should remove %s, got %v", dir, err)
+	}
+}
+
+func TestNewWithoutOverrides(t *testing.T) {
+	googleapisDir := writeTestProtos(t)
+	overlay, err := New(googleapisDir, "test/v1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if overlay.Dir != googleapisDir || overlay.Args != nil {
+		t.Errorf("New() = %+v, want protoc to run from %s", overlay, googleapisDir)
+	}
+	if err := overlay.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(googleapisDir); err != nil {
+		t.Errorf("Close() should not remove googleapis: %v", err)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	googleapisDir := writeTestProtos(t)
+	_, err := New(googleapisDir, "test/v1", []config.DocumentationOverride{
+		{ID: ".test.v1.Secret.name", Match: "not in the comment", Replace: "unused"},
+		{ID: ".test.v1.Secret.labels", Match: "a", Replace: "b"},
+		{ID: ".test.v1.Missing", Match: "a", Replace: "b"},
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	got := strings.Split(err.Error(), "\n")
+	want := []string{
+		`comment override for .test.v1.Secret.name did not match "not in the comment"`,
+		"cannot find element .test.v1.Secret.labels with a comment in the protos of test/v1 to apply comment overrides",
+		"cannot find element .test.v1.Missing with a comment in the protos of test/v1 to apply comment overrides",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+}
@@ -22,20 +22,15 @@ import (
 	"regexp"
 
 	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/settings"
 )
 
 // Create creates a new Python client library.
-// It creates changelog files for the new library.
-func Create(ctx context.Context, library *config.Library, defaults *config.Default, googleapisDir, serviceConfigPath, defaultOutput string) error {
-	// Get current working directory as repoDir
-	repoDir, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
-	}
-
+// It creates changelog files for the new library. The output directory is
+// relative to repoDir.
+func Create(ctx context.Context, library *config.Library, resolved *settings.Settings, repoDir, googleapisDir, serviceConfigPath string) error {
 	// 1. Update global CHANGELOG.md
-	globalChangelogSrc := filepath.Join(repoDir, "CHANGELOG.md")
-	globalChangelogDest := filepath.Join(defaultOutput, "CHANGELOG.md")
+	globalChangelog := filepath.Join(repoDir, "CHANGELOG.md")
 
 	// Create library config for changelog update
 	newLibraryConfig := map[string]interface{}{
@@ -45,13 +40,13 @@ func Create(ctx context.Context, library *config.Library, defaults *config.Defau
 
 	// The Python function calls _update_global_changelog with a list containing only the new library config.
 	allLibraries := []map[string]interface{}{newLibraryConfig}
-	if err := updateGlobalChangelog(globalChangelogSrc, globalChangelogDest, allLibraries); err != nil {
+	if err := updateGlobalChangelog(globalChangelog, globalChangelog, allLibraries); err != nil {
 		return fmt.Errorf("failed to update global changelog: %w", err)
 	}
 
 	// 2. Create a `CHANGELOG.md` for the new library
 	// 3. Create a `docs/CHANGELOG.md` file for the new library
-	if err := createNewChangelogForLibrary(library.Name, defaultOutput); err != nil {
+	if err := createNewChangelogForLibrary(library.Name, filepath.Join(repoDir, resolved.Output)); err != nil {
 		return fmt.Errorf("failed to create new library changelogs: %w", err)
 	}
 
@@ -112,10 +107,10 @@ func updateGlobalChangelog(changelogSrc, changelogDest string, allLibraries []ma
 }
 
 // createNewChangelogForLibrary creates a new CHANGELOG.md and docs/CHANGELOG.md
-// for a given library.
+// for a given library in its output directory.
 func createNewChangelogForLibrary(libraryID, outputDir string) error {
-	packageChangelogPath := filepath.Join(outputDir, "packages", libraryID, "CHANGELOG.md")
-	docsChangelogPath := filepath.Join(outputDir, "packages", libraryID, "docs", "CHANGELOG.md")
+	packageChangelogPath := filepath.Join(outputDir, "CHANGELOG.md")
+	docsChangelogPath := filepath.Join(outputDir, "docs", "CHANGELOG.md")
 
 	changelogContent := fmt.Sprintf("# Changelog\n\n[PyPI History][1]\n\n[1]: https://pypi.org/project/%s/#history\n", libraryID)
 
@@ -24,98 +24,74 @@ import (
 	"strings"
 
 	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/googleapis"
+	"github.com/julieqiu/librarianx/internal/language/internal/protopatch"
+	"github.com/julieqiu/librarianx/internal/language/internal/settings"
+	"github.com/julieqiu/librarianx/internal/language/internal/staging"
 )
 
-// PostProcess runs only the synthtool post-processor on an existing library.
-func PostProcess(ctx context.Context, repo string, library *config.Library, defaults *config.Default) error {
-	// Determine output directory
-	outdir := library.Path
-	if outdir == "" {
-		if defaults != nil {
-			outdir = strings.ReplaceAll(defaults.Output, "{name}", library.Name)
-		}
-	}
-
+// PostProcess runs only the post-processor on an existing library.
+// The output directory is relative to repoDir.
+func PostProcess(ctx context.Context, repo, repoDir string, library *config.Library, resolved *settings.Settings) error {
 	// Convert to absolute path
-	var err error
-	outdir, err = filepath.Abs(outdir)
+	outdir, err := filepath.Abs(filepath.Join(repoDir, resolved.Output))
 	if err != nil {
 		return fmt.Errorf("failed to get absolute path for output directory: %w", err)
 	}
 
-	// Copy files needed for post processing (e.g., client-post-processing YAML files)
-	if err := copyFilesNeededForPostProcessing(outdir, library, repo); err != nil {
+	// Copy files needed for post processing (e.g., .repo-metadata.json)
+	if err := copyInputFiles(outdir, library, repo); err != nil {
 		return fmt.Errorf("failed to copy files for post processing: %w", err)
 	}
 
-	// The post processor needs to run from the repository root
-	repoRoot := filepath.Dir(filepath.Dir(outdir))
-
-	// Run post processor
-	if err := runPostProcessor(repoRoot, library.Name); err != nil {
-		return fmt.Errorf("failed to run post processor: %w", err)
-	}
-
-	// Copy README.rst to docs/README.rst
-	if err := copyReadmeToDocsDir(outdir, library.Name); err != nil {
-		return fmt.Errorf("failed to copy README to docs: %w", err)
-	}
-
-	// Clean up files that shouldn't be in the final output
-	if err := cleanUpFilesAfterPostProcessing(outdir, library.Name); err != nil {
-		return fmt.Errorf("failed to cleanup after post processing: %w", err)
-	}
-
-	return nil
+	return postProcess(ctx, repoDir, outdir, library)
 }
 
 // Generate generates a Python client library.
 // Files and directories specified in library.Keep will be preserved during regeneration.
 // If library.Keep is not specified, a default list of paths is used.
-func Generate(ctx context.Context, language, repo string, library *config.Library, defaults *config.Default, googleapisDir, serviceConfigPath, defaultOutput, defaultAPI string) error {
-	// Determine output directory
-	outdir := library.Path
-	if outdir == "" {
-		// Use default output pattern if no explicit path
-		if defaults != nil {
-			outdir = strings.ReplaceAll(defaults.Output, "{name}", library.Name)
-		}
-	}
-
+// The output directory is relative to repoDir.
+// The library is generated into a staging directory next to the output directory,
+// which only replaces the output directory if generation and post-processing succeed.
+func Generate(ctx context.Context, language, repo, repoDir string, library *config.Library, resolved *settings.Settings, googleapisDir, serviceConfigPath, defaultAPI string) error {
 	// Convert to absolute path since protoc runs from a different directory
-	var err error
-	outdir, err = filepath.Abs(outdir)
+	outdir, err := filepath.Abs(filepath.Join(repoDir, resolved.Output))
 	if err != nil {
 		return fmt.Errorf("failed to get absolute path for output directory: %w", err)
 	}
 	fmt.Println(outdir)
 
-	// Clean output directory before generation
-	if err := cleanOutputDirectory(outdir, library.Keep); err != nil {
-		return fmt.Errorf("failed to clean output directory: %w", err)
-	}
-
-	// Create output directory
-	if err := os.MkdirAll(outdir, 0755); err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
-	}
-
 	// Get API paths to generate
 	apiPaths := config.GetLibraryAPIs(library)
 	if len(apiPaths) == 0 {
 		return fmt.Errorf("no APIs specified for library %s", library.Name)
 	}
 
-	// Get transport from library or defaults
-	transport := library.Transport
-	if transport == "" && defaults != nil && defaults.Generate != nil {
-		transport = defaults.Generate.Transport
+	stage, err := staging.NewKept(outdir, library.Keep, cleanOutputDirectory)
+	if err != nil {
+		return err
+	}
+	if err := generateLibrary(ctx, language, repo, library, resolved, googleapisDir, serviceConfigPath, stage.Dir, defaultAPI, apiPaths); err != nil {
+		return stage.Fail(err)
 	}
 
-	// Get rest_numeric_enums from library or defaults
-	restNumericEnums := defaults != nil && defaults.Generate != nil && defaults.Generate.RestNumericEnums
-	if library.RestNumericEnums != nil {
-		restNumericEnums = *library.RestNumericEnums
+	// The post processor operates on the library at its final location, so
+	// swap the staged library in first and put the original back on failure.
+	if err := stage.Swap(); err != nil {
+		return err
+	}
+	if err := postProcess(ctx, repoDir, outdir, library); err != nil {
+		return stage.Fail(err)
+	}
+	return stage.Done()
+}
+
+// generateLibrary runs protoc for all APIs of library and prepares the
+// inputs of the post processor, writing the results to outdir.
+func generateLibrary(ctx context.Context, language, repo string, library *config.Library, resolved *settings.Settings, googleapisDir, serviceConfigPath, outdir, defaultAPI string, apiPaths []string) error {
+	overrides, err := config.LibraryDocumentationOverrides(library, language)
+	if err != nil {
+		return err
 	}
 
 	// Generate each API with its own service config
@@ -123,13 +99,14 @@ func Generate(ctx context.Context, language, repo string, library *config.Librar
 		// Only generate unversioned package for the default (latest stable) API
 		isDefaultAPI := apiPath == defaultAPI
 
-		if err := generateAPI(ctx, apiPath, library, googleapisDir, apiServiceConfig, outdir, transport, restNumericEnums, isDefaultAPI); err != nil {
+		apiOverrides := config.DocumentationOverridesForAPI(overrides, apiPath)
+		if err := generateAPI(ctx, apiPath, library, googleapisDir, apiServiceConfig, outdir, resolved.Transport, resolved.RestNumericEnums, isDefaultAPI, apiOverrides); err != nil {
 			return fmt.Errorf("failed to generate API %s: %w", apiPath, err)
 		}
 	}
 
-	// Copy files needed for post processing (e.g., .repo-metadata.json, scripts)
-	if err := copyFilesNeededForPostProcessing(outdir, library, repo); err != nil {
+	// Copy files needed for post processing (e.g., .repo-metadata.json)
+	if err := copyInputFiles(outdir, library, repo); err != nil {
 		return fmt.Errorf("failed to copy files for post processing: %w", err)
 	}
 
@@ -139,29 +116,12 @@ func Generate(ctx context.Context, language, repo string, library *config.Librar
 			return fmt.Errorf("failed to generate .repo-metadata.json: %w", err)
 		}
 	}
-
-	// Run post processor (synthtool/owlbot)
-	// The post processor needs to run from the repository root, not the package directory
-	repoRoot := filepath.Dir(filepath.Dir(outdir)) // Go up two levels from packages/libname to repo root
-	if err := runPostProcessor(repoRoot, library.Name); err != nil {
-		return fmt.Errorf("failed to run post processor: %w", err)
-	}
-
-	// Copy README.rst to docs/README.rst
-	if err := copyReadmeToDocsDir(outdir, library.Name); err != nil {
-		return fmt.Errorf("failed to copy README to docs: %w", err)
-	}
-
-	// Clean up files that shouldn't be in the final output
-	if err := cleanUpFilesAfterPostProcessing(outdir, library.Name); err != nil {
-		return fmt.Errorf("failed to cleanup after post processing: %w", err)
-	}
-
 	return nil
 }
 
 // generateAPI generates code for a single API.
-func generateAPI(ctx context.Context, apiPath string, library *config.Library, googleapisDir, serviceConfigPath, outdir, transport string, restNumericEnums, isDefaultAPI bool) error {
+// The documentation overrides are applied to a copy of the protos.
+func generateAPI(ctx context.Context, apiPath string, library *config.Library, googleapisDir, serviceConfigPath, outdir, transport string, restNumericEnums, isDefaultAPI bool, overrides []config.DocumentationOverride) error {
 	// Check if this is a proto-only library
 	isProtoOnly := library.Python != nil && library.Python.IsProtoOnly
 
@@ -207,20 +167,7 @@ func generateAPI(ctx context.Context, apiPath string, library *config.Library, g
 		}
 
 		// Add gRPC service config (retry/timeout settings)
-		// Try library config first, then auto-discover
-		grpcConfigPath := ""
-		if library.GRPCServiceConfig != "" {
-			// GRPCServiceConfig is relative to the API directory
-			grpcConfigPath = filepath.Join(googleapisDir, apiPath, library.GRPCServiceConfig)
-		} else {
-			// Auto-discover: look for *_grpc_service_config.json in the API directory
-			apiDir := filepath.Join(googleapisDir, apiPath)
-			matches, err := filepath.Glob(filepath.Join(apiDir, "*_grpc_service_config.json"))
-			if err == nil && len(matches) > 0 {
-				grpcConfigPath = matches[0]
-			}
-		}
-		if grpcConfigPath != "" {
+		if grpcConfigPath := googleapis.GRPCServiceConfig(library, googleapisDir, apiPath); grpcConfigPath != "" {
 			opts = append(opts, fmt.Sprintf("retry-config=%s", grpcConfigPath))
 		}
 
@@ -243,11 +190,20 @@ func generateAPI(ctx context.Context, apiPath string, library *config.Library, g
 		cmdStr = "protoc " + strings.Join(args, " ")
 	}
 
+	overlay, err := protopatch.New(googleapisDir, apiPath, overrides)
+	if err != nil {
+		return err
+	}
+	defer overlay.Close()
+	if len(overlay.Args) > 0 {
+		cmdStr += " " + strings.Join(overlay.Args, " ")
+	}
+
 	// Debug: print the protoc command
 	fmt.Fprintf(os.Stderr, "\nRunning: %s\n", cmdStr)
 
 	cmd := exec.CommandContext(ctx, "sh", "-c", cmdStr)
-	cmd.Dir = googleapisDir
+	cmd.Dir = overlay.Dir
 	cmd.Stdout = os.Stderr
 	cmd.Stderr = os.Stderr
 
@@ -258,42 +214,44 @@ func generateAPI(ctx context.Context, apiPath string, library *config.Library, g
 	return nil
 }
 
-// copyFilesNeededForPostProcessing copies files needed during post processing.
-// This includes .repo-metadata.json and client-post-processing scripts from the input directory.
-func copyFilesNeededForPostProcessing(outdir string, library *config.Library, repo string) error {
+// copyInputFiles copies the files in the input directory of the library,
+// e.g. .repo-metadata.json, into outdir.
+func copyInputFiles(outdir string, library *config.Library, repo string) error {
 	if repo == "" {
 		return nil
 	}
 
-	inputDir := filepath.Join(repo, ".librarian", "generator-input")
-	if _, err := os.Stat(inputDir); os.IsNotExist(err) {
+	sourceDir := filepath.Join(repo, ".librarian", "generator-input", "packages", library.Name)
+	if _, err := os.Stat(sourceDir); os.IsNotExist(err) {
 		// No input directory, nothing to copy
 		return nil
 	}
 
-	pathToLibrary := filepath.Join("packages", library.Name)
-	sourceDir := filepath.Join(inputDir, pathToLibrary)
-
 	// Copy files from input/packages/{library_name} to output, excluding client-post-processing
-	if _, err := os.Stat(sourceDir); err == nil {
-		if err := copyDirExcluding(sourceDir, outdir, "client-post-processing"); err != nil {
-			return fmt.Errorf("failed to copy input files: %w", err)
-		}
+	if err := copyDirExcluding(sourceDir, outdir, "client-post-processing"); err != nil {
+		return fmt.Errorf("failed to copy input files: %w", err)
 	}
+	return nil
+}
 
-	// Create scripts/client-post-processing directory
-	scriptsDir := filepath.Join(outdir, pathToLibrary, "scripts", "client-post-processing")
-	if err := os.MkdirAll(scriptsDir, 0755); err != nil {
-		return fmt.Errorf("failed to create scripts directory: %w", err)
+// copyPostProcessingScripts copies the client-post-processing YAML files that
+// apply to the library into outdir/scripts/client-post-processing, where
+// synthtool looks for them. It returns the copied files, so they can be
+// removed once synthtool is done.
+func copyPostProcessingScripts(outdir, libraryName, repoDir string) ([]string, error) {
+	if repoDir == "" {
+		return nil, nil
 	}
+	pathToLibrary := filepath.Join("packages", libraryName)
+	scriptsDir := filepath.Join(outdir, "scripts", "client-post-processing")
 
-	// Copy relevant client-post-processing YAML files
 	// Try both locations: .librarian/generator-input/client-post-processing and synthtool-input
 	postProcessingDirs := []string{
-		filepath.Join(inputDir, "client-post-processing"),
-		filepath.Join(repo, "synthtool-input"),
+		filepath.Join(repoDir, ".librarian", "generator-input", "client-post-processing"),
+		filepath.Join(repoDir, "synthtool-input"),
 	}
 
+	var copied []string
 	for _, postProcessingDir := range postProcessingDirs {
 		yamlFiles, err := filepath.Glob(filepath.Join(postProcessingDir, "*.yaml"))
 		if err != nil {
@@ -308,15 +266,38 @@ func copyFilesNeededForPostProcessing(outdir string, library *config.Library, re
 			}
 
 			// Check if the file references this library's path
-			if strings.Contains(string(content), pathToLibrary+"/") {
-				destPath := filepath.Join(scriptsDir, filepath.Base(yamlFile))
-				if err := copyFile(yamlFile, destPath); err != nil {
-					return fmt.Errorf("failed to copy post-processing file %s: %w", yamlFile, err)
-				}
+			if !strings.Contains(string(content), pathToLibrary+"/") {
+				continue
+			}
+			if err := os.MkdirAll(scriptsDir, 0755); err != nil {
+				return copied, fmt.Errorf("failed to create scripts directory: %w", err)
 			}
+			destPath := filepath.Join(scriptsDir, filepath.Base(yamlFile))
+			if err := copyFile(yamlFile, destPath); err != nil {
+				return copied, fmt.Errorf("failed to copy post-processing file %s: %w", yamlFile, err)
+			}
+			copied = append(copied, destPath)
 		}
 	}
+	return copied, nil
+}
 
+// removePostProcessingScripts removes the files copied by
+// copyPostProcessingScripts, and the directories created for them if they
+// are empty.
+func removePostProcessingScripts(outdir string, copied []string) error {
+	for _, f := range copied {
+		if err := os.Remove(f); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if len(copied) == 0 {
+		return nil
+	}
+	// os.Remove fails on directories that are not empty, which are kept.
+	scriptsDir := filepath.Join(outdir, "scripts")
+	_ = os.Remove(filepath.Join(scriptsDir, "client-post-processing"))
+	_ = os.Remove(scriptsDir)
 	return nil
 }
 
@@ -385,7 +366,7 @@ func generateRepoMetadataFile(outdir string, library *config.Library, language,
 }
 
 // runPostProcessor runs the synthtool post processor on the output directory.
-func runPostProcessor(outdir, libraryName string) error {
+func runPostProcessor(ctx context.Context, outdir, libraryName string) error {
 	pathToLibrary := filepath.Join("packages", libraryName)
 
 	fmt.Fprintf(os.Stderr, "\nRunning Python post-processor...\n")
@@ -395,7 +376,7 @@ func runPostProcessor(outdir, libraryName string) error {
 from synthtool.languages import python_mono_repo
 python_mono_repo.owlbot_main(%q)
 `, pathToLibrary)
-	cmd := exec.Command("python3", "-c", pythonCode)
+	cmd := exec.CommandContext(ctx, "python3", "-c", pythonCode)
 	cmd.Dir = outdir
 	cmd.Stdout = os.Stderr
 	cmd.Stderr = os.Stderr
@@ -409,10 +390,10 @@ python_mono_repo.owlbot_main(%q)
 	// This is required for proto-only libraries which are not GAPIC
 	noxfilePath := filepath.Join(outdir, pathToLibrary, "noxfile.py")
 	if _, err := os.Stat(noxfilePath); os.IsNotExist(err) {
-		if err := runIsort(outdir); err != nil {
+		if err := runIsort(ctx, outdir); err != nil {
 			return err
 		}
-		if err := runBlackFormatter(outdir); err != nil {
+		if err := runBlackFormatter(ctx, outdir); err != nil {
 			return err
 		}
 	}
@@ -421,36 +402,11 @@ python_mono_repo.owlbot_main(%q)
 	return nil
 }
 
-// runIsort runs the isort import sorter on Python files in the output directory.
-// The --fss flag forces strict alphabetical sorting within sections.
-func runIsort(outdir string) error {
-	fmt.Fprintf(os.Stderr, "\nRunning: isort --fss %s\n", outdir)
-	cmd := exec.Command("isort", "--fss", outdir)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("isort failed: %w\nOutput: %s", err, string(output))
-	}
-	return nil
-}
-
-// runBlackFormatter runs the black code formatter on Python files in the output directory.
-// Black enforces double quotes and consistent Python formatting.
-func runBlackFormatter(outdir string) error {
-	fmt.Fprintf(os.Stderr, "\nRunning: black %s\n", outdir)
-	cmd := exec.Command("black", outdir)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("black formatter failed: %w\nOutput: %s", err, string(output))
-	}
-	return nil
-}
-
-// copyReadmeToDocsDir copies README.rst to docs/README.rst.
+// copyReadmeToDocsDir copies README.rst to docs/README.rst in libraryDir.
 // This handles symlinks properly by reading content and writing a real file.
-func copyReadmeToDocsDir(outdir, libraryName string) error {
-	pathToLibrary := filepath.Join(outdir, "packages", libraryName)
-	sourcePath := filepath.Join(pathToLibrary, "README.rst")
-	docsPath := filepath.Join(pathToLibrary, "docs")
+func copyReadmeToDocsDir(libraryDir string) error {
+	sourcePath := filepath.Join(libraryDir, "README.rst")
+	docsPath := filepath.Join(libraryDir, "docs")
 	destPath := filepath.Join(docsPath, "README.rst")
 
 	// If source doesn't exist, nothing to copy
@@ -500,13 +456,5 @@ func cleanUpFilesAfterPostProcessing(outdir, libraryName string) error {
 	os.Remove(filepath.Join(pathToLibrary, "CHANGELOG.md"))
 	os.Remove(filepath.Join(pathToLibrary, "docs", "CHANGELOG.md"))
 
-	// Remove client-post-processing YAML files
-	scriptsPath := filepath.Join(pathToLibrary, "scripts", "client-post-processing")
-	if yamlFiles, err := filepath.Glob(filepath.Join(scriptsPath, "*.yaml")); err == nil {
-		for _, f := range yamlFiles {
-			os.Remove(f)
-		}
-	}
-
 	return nil
 }
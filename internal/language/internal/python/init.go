@@ -33,6 +33,8 @@ const (
 
 // Init initializes a default Python config and sets up the Python environment.
 // It returns the default config and the Python sources configuration.
+// synthtool is not installed here, it is only needed for libraries with a
+// custom owlbot.py and installed when one of them is post-processed.
 func Init(ctx context.Context, cacheDir string) (*config.Default, *config.PythonSources, error) {
 	if err := downloadGoogleCloudPython(cacheDir, GoogleCloudPythonCommit); err != nil {
 		return nil, nil, err
@@ -40,9 +42,6 @@ func Init(ctx context.Context, cacheDir string) (*config.Default, *config.Python
 	if err := copySynthtoolInput(); err != nil {
 		return nil, nil, err
 	}
-	if err := installSynthtool(ctx, cacheDir, SynthtoolCommit); err != nil {
-		return nil, nil, err
-	}
 
 	sources := &config.PythonSources{
 		GoogleCloudPython: &config.Source{
@@ -19,8 +19,17 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/settings"
 )
 
+// Clean deletes the generated files of library, preserving the files listed
+// in library.Keep. The output directory is relative to repoDir.
+func Clean(library *config.Library, resolved *settings.Settings, repoDir string) error {
+	return cleanOutputDirectory(filepath.Join(repoDir, resolved.Output), library.Keep)
+}
+
 // cleanOutputDirectory deletes everything in the output directory except files listed in keepPaths.
 func cleanOutputDirectory(outdir string, keepPaths []string) error {
 	// Check if directory exists
@@ -0,0 +1,343 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/julieqiu/librarianx/internal/config"
+)
+
+//go:embed all:templates
+var templates embed.FS
+
+// templateData is the data used to render the post-processing templates.
+type templateData struct {
+	// Metadata is the content of the library's .repo-metadata.json.
+	Metadata *config.RepoMetadata
+
+	// Year is the copyright year for generated files.
+	Year string
+
+	// PackagePath is the directory of the unversioned package (e.g., "google/cloud/secretmanager").
+	PackagePath string
+
+	// Namespace is the import path of the unversioned package (e.g., "google.cloud.secretmanager").
+	Namespace string
+
+	// VersionedPackages lists the versioned packages (e.g., "secretmanager_v1").
+	VersionedPackages []string
+
+	// ReleaseBadge is the support level shown in README.rst.
+	ReleaseBadge string
+
+	// ReleaseBadgeColor is the color of the release badge.
+	ReleaseBadgeColor string
+
+	// Dependencies are the requirements listed in setup.py.
+	Dependencies []string
+}
+
+// baseDependencies are the requirements of every GAPIC library.
+var baseDependencies = []string{
+	"google-api-core[grpc] >= 1.34.1, <3.0.0,!=2.0.*,!=2.1.*,!=2.2.*,!=2.3.*,!=2.4.*,!=2.5.*,!=2.6.*,!=2.7.*,!=2.8.*,!=2.9.*,!=2.10.*",
+	"google-auth >= 2.14.1, <3.0.0,!=2.24.0,!=2.25.0",
+	"proto-plus >= 1.22.3, <2.0.0",
+	"proto-plus >= 1.25.0, <2.0.0; python_version >= '3.13'",
+	"protobuf>=3.20.2,<7.0.0,!=4.21.0,!=4.21.1,!=4.21.2,!=4.21.3,!=4.21.4,!=4.21.5",
+}
+
+// iamDependency is required by libraries using the IAM protos.
+const iamDependency = "grpc-google-iam-v1 >= 0.14.0, <1.0.0"
+
+// postProcess runs the post-processing steps on the library in outdir.
+//
+// Libraries with a custom owlbot.py are post-processed by synthtool. All
+// other libraries are post-processed natively: the standard templates are
+// rendered, README.rst is copied to docs/ and the code is formatted with
+// isort and black.
+func postProcess(ctx context.Context, repoDir, outdir string, library *config.Library) error {
+	if _, err := os.Stat(filepath.Join(outdir, "owlbot.py")); err == nil {
+		return runOwlBot(ctx, repoDir, outdir, library.Name)
+	}
+
+	fmt.Fprintf(os.Stderr, "\nRunning Python post-processor...\n")
+	if err := renderTemplates(outdir, library); err != nil {
+		return fmt.Errorf("failed to render templates: %w", err)
+	}
+	if err := copyReadmeToDocsDir(outdir); err != nil {
+		return fmt.Errorf("failed to copy README to docs: %w", err)
+	}
+	if err := runIsort(ctx, outdir); err != nil {
+		return err
+	}
+	if err := runBlackFormatter(ctx, outdir); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Python post-processor ran successfully.\n")
+	return nil
+}
+
+// renderTemplates renders the standard library templates into outdir.
+//
+// Templates are only rendered for GAPIC libraries with a .repo-metadata.json.
+// Files matched by library.Keep are never overwritten.
+func renderTemplates(outdir string, library *config.Library) error {
+	if library.Python != nil && library.Python.IsProtoOnly {
+		return nil
+	}
+	metadata, err := readRepoMetadata(outdir)
+	if err != nil {
+		return err
+	}
+	if metadata == nil {
+		return nil
+	}
+	data, err := newTemplateData(outdir, library, metadata)
+	if err != nil {
+		return err
+	}
+
+	funcs := template.FuncMap{
+		"underline": func(title, prefix string) string {
+			return strings.Repeat("=", len(prefix)+len(title))
+		},
+	}
+	return fs.WalkDir(templates, "templates", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel := strings.TrimSuffix(strings.TrimPrefix(path, "templates/"), ".tmpl")
+		if isKept(rel, library.Keep) {
+			return nil
+		}
+		tmpl, err := template.New(d.Name()).Funcs(funcs).ParseFS(templates, path)
+		if err != nil {
+			return err
+		}
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, data); err != nil {
+			return fmt.Errorf("failed to render %s: %w", rel, err)
+		}
+		return writeTextFile(filepath.Join(outdir, rel), sb.String())
+	})
+}
+
+// newTemplateData builds the template data from the generated package layout.
+func newTemplateData(outdir string, library *config.Library, metadata *config.RepoMetadata) (*templateData, error) {
+	packagePath, versioned, err := findPackages(outdir)
+	if err != nil {
+		return nil, err
+	}
+	// The year is part of the library configuration, so regenerating a
+	// library does not change its files.
+	if library.CopyrightYear == "" {
+		return nil, fmt.Errorf("no copyright_year configured for library %s", library.Name)
+	}
+	dependencies := slices.Clone(baseDependencies)
+	usesIAM, err := importsModule(outdir, "google.iam.v1")
+	if err != nil {
+		return nil, err
+	}
+	if usesIAM {
+		dependencies = append(dependencies, iamDependency)
+	}
+	badge, color := "stable", "brightgreen"
+	if metadata.ReleaseLevel == "preview" {
+		badge, color = "preview", "orange"
+	}
+	return &templateData{
+		Metadata:          metadata,
+		Year:              library.CopyrightYear,
+		PackagePath:       packagePath,
+		Namespace:         strings.ReplaceAll(packagePath, "/", "."),
+		VersionedPackages: versioned,
+		ReleaseBadge:      badge,
+		ReleaseBadgeColor: color,
+		Dependencies:      dependencies,
+	}, nil
+}
+
+// importsModule reports whether any of the generated Python files in outdir
+// imports module. Only whole module names match, so google.iam.v1 does not
+// match google.iam.v1beta.
+func importsModule(outdir, module string) (bool, error) {
+	name := regexp.QuoteMeta(module)
+	pattern, err := regexp.Compile(`(?m)^\s*(?:from\s+` + name + `(?:\.[\w.]+)?\s+import\b|import\s+` + name + `(?:[\s.,]|$))`)
+	if err != nil {
+		return false, err
+	}
+	found := false
+	err = filepath.WalkDir(filepath.Join(outdir, "google"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil || found || d.IsDir() || filepath.Ext(path) != ".py" {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		found = pattern.Match(content)
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+	return found, nil
+}
+
+// findPackages locates the generated packages in outdir using their
+// gapic_version.py files. It returns the path of the unversioned package and
+// the names of the versioned packages.
+func findPackages(outdir string) (string, []string, error) {
+	var packages []string
+	err := filepath.WalkDir(filepath.Join(outdir, "google"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() != "gapic_version.py" {
+			return nil
+		}
+		rel, err := filepath.Rel(outdir, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		packages = append(packages, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", nil, err
+	}
+	if len(packages) == 0 {
+		return "", nil, fmt.Errorf("no gapic_version.py found in %s", outdir)
+	}
+
+	// The unversioned package is the one closest to the root, with ties
+	// broken alphabetically.
+	sort.Slice(packages, func(i, j int) bool {
+		di, dj := strings.Count(packages[i], "/"), strings.Count(packages[j], "/")
+		if di != dj {
+			return di < dj
+		}
+		return packages[i] < packages[j]
+	})
+	var versioned []string
+	for _, p := range packages {
+		name := filepath.Base(p)
+		if versionedPackage.MatchString(name) {
+			versioned = append(versioned, name)
+		}
+	}
+	sort.Strings(versioned)
+	return packages[0], versioned, nil
+}
+
+// versionedPackage matches the names of versioned packages, e.g.
+// `secretmanager_v1` or `bigquery_storage_v1beta2`.
+var versionedPackage = regexp.MustCompile(`_v\d+`)
+
+// readRepoMetadata reads .repo-metadata.json from outdir. It returns nil if
+// the file does not exist.
+func readRepoMetadata(outdir string) (*config.RepoMetadata, error) {
+	content, err := os.ReadFile(filepath.Join(outdir, ".repo-metadata.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var metadata config.RepoMetadata
+	if err := json.Unmarshal(content, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse .repo-metadata.json: %w", err)
+	}
+	return &metadata, nil
+}
+
+// isKept reports whether rel is matched by one of the keep paths.
+func isKept(rel string, keepPaths []string) bool {
+	for _, keep := range keepPaths {
+		keep = strings.TrimSuffix(filepath.ToSlash(keep), "/")
+		if rel == keep || strings.HasPrefix(rel, keep+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// runOwlBot runs the synthtool post processor on a library with a custom
+// owlbot.py. synthtool needs to run from the repository root repoDir, with
+// the client-post-processing scripts of the library next to it. The scripts
+// are removed afterwards.
+func runOwlBot(ctx context.Context, repoDir, outdir, libraryName string) (err error) {
+	if repoDir == "" {
+		return fmt.Errorf("no repository directory for library %s", libraryName)
+	}
+	if err := ensureSynthtool(ctx); err != nil {
+		return err
+	}
+	copied, err := copyPostProcessingScripts(outdir, libraryName, repoDir)
+	defer func() {
+		err = errors.Join(err, removePostProcessingScripts(outdir, copied))
+	}()
+	if err != nil {
+		return fmt.Errorf("failed to copy files for post processing: %w", err)
+	}
+	if err := runPostProcessor(ctx, repoDir, libraryName); err != nil {
+		return fmt.Errorf("failed to run post processor: %w", err)
+	}
+	if err := copyReadmeToDocsDir(outdir); err != nil {
+		return fmt.Errorf("failed to copy README to docs: %w", err)
+	}
+	if err := cleanUpFilesAfterPostProcessing(repoDir, libraryName); err != nil {
+		return fmt.Errorf("failed to cleanup after post processing: %w", err)
+	}
+	return nil
+}
+
+// runIsort runs the isort import sorter on Python files in the output directory.
+// The --fss flag forces strict alphabetical sorting within sections.
+func runIsort(ctx context.Context, outdir string) error {
+	fmt.Fprintf(os.Stderr, "\nRunning: isort --fss %s\n", outdir)
+	cmd := exec.CommandContext(ctx, "isort", "--fss", outdir)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("isort failed: %w\nOutput: %s", err, string(output))
+	}
+	return nil
+}
+
+// runBlackFormatter runs the black code formatter on Python files in the output directory.
+// Black enforces double quotes and consistent Python formatting.
+func runBlackFormatter(ctx context.Context, outdir string) error {
+	fmt.Fprintf(os.Stderr, "\nRunning: black %s\n", outdir)
+	cmd := exec.CommandContext(ctx, "black", outdir)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("black formatter failed: %w\nOutput: %s", err, string(output))
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/config"
+)
+
+func TestRenderTemplates(t *testing.T) {
+	outdir := t.TempDir()
+	for path, content := range map[string]string{
+		".repo-metadata.json": `{
+    "name": "secretmanager",
+    "name_pretty": "Secret Manager",
+    "product_documentation": "https://cloud.google.com/secret-manager/",
+    "client_documentation": "https://cloud.google.com/python/docs/reference/secretmanager/latest",
+    "issue_tracker": "",
+    "release_level": "stable",
+    "distribution_name": "google-cloud-secret-manager",
+    "api_description": "Stores sensitive data such as API keys, passwords, and certificates."
+}`,
+		"google/cloud/secretmanager/gapic_version.py":         `__version__ = "1.2.3"`,
+		"google/cloud/secretmanager_v1/gapic_version.py":      `__version__ = "1.2.3"`,
+		"google/cloud/secretmanager_v1beta2/gapic_version.py": `__version__ = "1.2.3"`,
+		"google/cloud/secretmanager_v1/services/client.py":    "from google.iam.v1 import iam_policy_pb2\n",
+		"noxfile.py": "# handwritten",
+	} {
+		if err := writeTextFile(filepath.Join(outdir, path), content); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	library := &config.Library{
+		Name:          "google-cloud-secret-manager",
+		CopyrightYear: "2025",
+		Keep:          []string{"noxfile.py"},
+	}
+	if err := renderTemplates(outdir, library); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		path string
+		want []string
+	}{
+		{
+			path: "README.rst",
+			want: []string{
+				"Python Client for Secret Manager\n================================\n",
+				"`Secret Manager`_: Stores sensitive data such as API keys, passwords, and certificates.",
+				"pip install google-cloud-secret-manager",
+			},
+		},
+		{
+			path: "docs/index.rst",
+			want: []string{
+				"secretmanager_v1/services_",
+				"secretmanager_v1beta2/types_",
+				"For a list of all ``google-cloud-secret-manager`` releases:",
+			},
+		},
+		{
+			path: ".coveragerc",
+			want: []string{"google/cloud/secretmanager/gapic_version.py"},
+		},
+		{
+			path: ".flake8",
+			want: []string{"Copyright 2025 Google LLC"},
+		},
+		{
+			path: "setup.py",
+			want: []string{
+				`name = "google-cloud-secret-manager"`,
+				`"google/cloud/secretmanager/gapic_version.py"`,
+				`    "grpc-google-iam-v1 >= 0.14.0, <1.0.0",`,
+			},
+		},
+		{
+			path: "setup.cfg",
+			want: []string{"universal = 1"},
+		},
+	} {
+		t.Run(test.path, func(t *testing.T) {
+			got, err := os.ReadFile(filepath.Join(outdir, test.path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range test.want {
+				if !strings.Contains(string(got), want) {
+					t.Errorf("%s missing %q, got:\n%s", test.path, want, got)
+				}
+			}
+		})
+	}
+
+	got, err := os.ReadFile(filepath.Join(outdir, "noxfile.py"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("# handwritten", string(got)); diff != "" {
+		t.Errorf("kept noxfile.py was overwritten (-want +got):\n%s", diff)
+	}
+}
+
+func TestRenderTemplatesWithoutMetadata(t *testing.T) {
+	outdir := t.TempDir()
+	if err := renderTemplates(outdir, &config.Library{Name: "googleapis-common-protos"}); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(outdir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected no files to be rendered, got %d", len(entries))
+	}
+}
+
+func TestRenderTemplatesWithoutCopyrightYear(t *testing.T) {
+	outdir := t.TempDir()
+	for path, content := range map[string]string{
+		".repo-metadata.json":                            `{"name": "secretmanager"}`,
+		"google/cloud/secretmanager/gapic_version.py":    `__version__ = "1.2.3"`,
+		"google/cloud/secretmanager_v1/gapic_version.py": `__version__ = "1.2.3"`,
+	} {
+		if err := writeTextFile(filepath.Join(outdir, path), content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := renderTemplates(outdir, &config.Library{Name: "google-cloud-secret-manager"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if want := "copyright_year"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error %q does not mention %s", err, want)
+	}
+}
+
+func TestImportsModule(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"from import", "from google.iam.v1 import iam_policy_pb2\n", true},
+		{"from submodule", "from google.iam.v1.types import policy\n", true},
+		{"import", "import google.iam.v1\n", true},
+		{"import submodule", "import google.iam.v1.iam_policy_pb2 as iam\n", true},
+		{"other version", "from google.iam.v1beta import iam_policy_pb2\n", false},
+		{"other import", "import google.iam.v1beta\n", false},
+		{"comment", "# see google.iam.v1\n", false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			outdir := t.TempDir()
+			if err := writeTextFile(filepath.Join(outdir, "google/cloud/secretmanager_v1/client.py"), test.content); err != nil {
+				t.Fatal(err)
+			}
+			got, err := importsModule(outdir, "google.iam.v1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("importsModule() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFindPackages(t *testing.T) {
+	outdir := t.TempDir()
+	for _, path := range []string{
+		"google/cloud/bigquery_storage/gapic_version.py",
+		"google/cloud/bigquery_storage_v1/gapic_version.py",
+		"google/cloud/bigquery_storage_v1beta2/gapic_version.py",
+		"google/cloud/bigquery_storage_vendored/gapic_version.py",
+	} {
+		if err := writeTextFile(filepath.Join(outdir, path), ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	gotPackage, gotVersioned, err := findPackages(outdir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("google/cloud/bigquery_storage", gotPackage); diff != "" {
+		t.Errorf("package mismatch (-want +got):\n%s", diff)
+	}
+	wantVersioned := []string{"bigquery_storage_v1", "bigquery_storage_v1beta2"}
+	if diff := cmp.Diff(wantVersioned, gotVersioned); diff != "" {
+		t.Errorf("versioned packages mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPostProcessingScripts(t *testing.T) {
+	repo := t.TempDir()
+	outdir := filepath.Join(repo, "packages", "google-cloud-secret-manager")
+	for path, content := range map[string]string{
+		".librarian/generator-input/client-post-processing/secretmanager.yaml": "paths: [packages/google-cloud-secret-manager/README.rst]",
+		"synthtool-input/other.yaml":                            "paths: [packages/google-cloud-other/README.rst]",
+		"packages/google-cloud-secret-manager/scripts/fixup.py": "# handwritten",
+	} {
+		if err := writeTextFile(filepath.Join(repo, path), content); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	copied, err := copyPostProcessingScripts(outdir, "google-cloud-secret-manager", repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(outdir, "scripts", "client-post-processing", "secretmanager.yaml")}
+	if diff := cmp.Diff(want, copied); diff != "" {
+		t.Errorf("mismatch in copied files (-want +got):\n%s", diff)
+	}
+
+	if err := removePostProcessingScripts(outdir, copied); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(outdir, "scripts", "client-post-processing")); !os.IsNotExist(err) {
+		t.Errorf("expected the client-post-processing directory to be removed, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outdir, "scripts", "fixup.py")); err != nil {
+		t.Errorf("expected other scripts to be kept: %v", err)
+	}
+}
@@ -19,9 +19,11 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"github.com/julieqiu/librarianx/internal/fetch"
+	"github.com/julieqiu/librarianx/internal/language/internal/toolchain"
 )
 
 // installSynthtool installs synthtool from a specific commit of github.com/googleapis/synthtool.
@@ -43,3 +45,39 @@ func installSynthtool(ctx context.Context, cacheDir, commit string) error {
 
 	return nil
 }
+
+// ensureSynthtool installs synthtool at SynthtoolCommit, unless the python3
+// on the PATH can import it already, e.g. because a pinned version is
+// activated.
+func ensureSynthtool(ctx context.Context) error {
+	if err := exec.CommandContext(ctx, "python3", "-c", "import synthtool").Run(); err == nil {
+		return nil
+	}
+	downloadDir, err := os.MkdirTemp("", "librarian-synthtool-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(downloadDir)
+	return installSynthtool(ctx, downloadDir, SynthtoolCommit)
+}
+
+// installPinnedSynthtool installs synthtool at commit into a virtual
+// environment in dir, with a bin/synthtool wrapper so the installation can
+// be found on the PATH.
+func installPinnedSynthtool(ctx context.Context, dir, commit string) error {
+	downloadDir, err := os.MkdirTemp("", "librarian-synthtool-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(downloadDir)
+
+	synthtoolDir, err := fetch.DownloadAndExtractTarball("github.com/googleapis/synthtool", commit, downloadDir)
+	if err != nil {
+		return fmt.Errorf("failed to download synthtool: %w", err)
+	}
+	if err := toolchain.PipInstall(ctx, dir, synthtoolDir); err != nil {
+		return err
+	}
+	wrapper := "#!/bin/sh\nexec \"$(dirname \"$0\")/python3\" -m synthtool \"$@\"\n"
+	return os.WriteFile(filepath.Join(dir, "bin", "synthtool"), []byte(wrapper), 0755)
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"github.com/julieqiu/librarianx/internal/language/internal/toolchain"
+)
+
+// GenerateTools returns the external tools invoked when generating Python
+// libraries.
+func GenerateTools() []toolchain.Tool {
+	return []toolchain.Tool{
+		toolchain.Protoc(),
+		{
+			Name:      "protoc-gen-python_gapic",
+			Install:   "pip install gapic-generator",
+			Installer: toolchain.PipInstaller("gapic-generator"),
+		},
+		{
+			Name:        "python3",
+			VersionArgs: []string{"--version"},
+			MinVersion:  "3.9",
+			Install:     "see https://www.python.org/downloads/",
+		},
+		{
+			Name:        "isort",
+			VersionArgs: []string{"--version-number"},
+			MinVersion:  "5.0",
+			Install:     "pip install isort",
+			Installer:   toolchain.PipInstaller("isort"),
+		},
+		{
+			Name:        "black",
+			VersionArgs: []string{"--version"},
+			MinVersion:  "23.0",
+			Install:     "pip install black",
+			Installer:   toolchain.PipInstaller("black"),
+		},
+	}
+}
+
+// SynthtoolTool returns the synthtool post-processor. It is only checked
+// when its commit is pinned in librarian.yaml; the pinned commit is
+// installed into a virtual environment whose python3 then runs the post
+// processor.
+func SynthtoolTool() toolchain.Tool {
+	return toolchain.Tool{
+		Name:      "synthtool",
+		Install:   "run `librarian doctor --install`",
+		Installer: installPinnedSynthtool,
+	}
+}
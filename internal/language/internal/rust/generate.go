@@ -23,6 +23,9 @@ import (
 	"strings"
 
 	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/settings"
+	"github.com/julieqiu/librarianx/internal/language/internal/staging"
+	"github.com/julieqiu/librarianx/internal/language/internal/toolchain"
 	sidekickconfig "github.com/julieqiu/librarianx/internal/sidekick/config"
 	"github.com/julieqiu/librarianx/internal/sidekick/parser"
 	sidekickrust "github.com/julieqiu/librarianx/internal/sidekick/rust"
@@ -30,24 +33,33 @@ import (
 )
 
 // Create creates a new Rust client library.
-func Create(ctx context.Context, library *config.Library, defaults *config.Default, googleapisDir, serviceConfigPath, defaultOutput string) error {
-	if err := sidekick.VerifyRustTools(); err != nil {
+func Create(ctx context.Context, library *config.Library, resolved *settings.Settings, repoDir, googleapisDir, serviceConfigPath string) error {
+	if err := toolchain.Verify(ctx, GenerateTools()); err != nil {
+		return err
+	}
+	outdir, err := outputDir(library, resolved, repoDir)
+	if err != nil {
 		return err
 	}
-	outdir := filepath.Join(defaultOutput, strings.TrimPrefix(library.Channel, "google/"))
 	if _, err := os.Stat(outdir); os.IsNotExist(err) {
 		if err := sidekick.PrepareCargoWorkspace(outdir); err != nil {
 			return err
 		}
 	}
-	if err := Generate(ctx, library, defaults, googleapisDir, serviceConfigPath, defaultOutput); err != nil {
+	if err := Generate(ctx, library, resolved, repoDir, googleapisDir, serviceConfigPath); err != nil {
 		return err
 	}
 	return sidekick.PostGenerate(outdir)
 }
 
 // Generate generates a Rust client library.
-func Generate(ctx context.Context, library *config.Library, defaults *config.Default, googleapisDir, serviceConfigPath, defaultOutput string) error {
+// The crate is generated into a staging directory next to the output directory,
+// which only replaces the output directory if generation and formatting succeed.
+func Generate(ctx context.Context, library *config.Library, resolved *settings.Settings, repoDir, googleapisDir, serviceConfigPath string) error {
+	outdir, err := outputDir(library, resolved, repoDir)
+	if err != nil {
+		return err
+	}
 	sidekickConfig, err := toSidekickConfig(library, googleapisDir, serviceConfigPath)
 	if err != nil {
 		return err
@@ -57,24 +69,53 @@ func Generate(ctx context.Context, library *config.Library, defaults *config.Def
 		return err
 	}
 
-	outdir := filepath.Join(defaultOutput, strings.TrimPrefix(library.Channel, "google/"))
-
-	// Clean output directory before generation
-	if err := cleanOutputDirectory(outdir, library.Keep); err != nil {
-		return fmt.Errorf("failed to clean output directory: %w", err)
-	}
-
+	// New crates are added to the cargo workspace at their final location.
 	if _, err := os.Stat(outdir); os.IsNotExist(err) {
 		if err := sidekick.PrepareCargoWorkspace(outdir); err != nil {
 			return err
 		}
 	}
-	if err := sidekickrust.Generate(model, outdir, sidekickConfig); err != nil {
+
+	stage, err := staging.NewKept(outdir, library.Keep, cleanOutputDirectory)
+	if err != nil {
 		return err
 	}
+	if err := sidekickrust.Generate(model, stage.Dir, sidekickConfig); err != nil {
+		return stage.Fail(err)
+	}
 
+	// cargo fmt only formats workspace members, so swap the staged crate in
+	// first and put the original back on failure.
+	if err := stage.Swap(); err != nil {
+		return err
+	}
+	if err := postProcess(ctx, library, repoDir, outdir); err != nil {
+		return stage.Fail(err)
+	}
+	return stage.Done()
+}
+
+// outputDir returns the directory of the crate for library, relative to
+// repoDir. Unless the library sets its path, crates are generated into the
+// directory of their channel below the default output, e.g.
+// google/cloud/secretmanager/v1 into {output}/cloud/secretmanager/v1.
+// It is an error if library has no channel, which would otherwise resolve to
+// the default output itself.
+func outputDir(library *config.Library, resolved *settings.Settings, repoDir string) (string, error) {
+	if library.Path != "" {
+		return filepath.Join(repoDir, resolved.Output), nil
+	}
+	if library.Channel == "" {
+		return "", fmt.Errorf("no channel for library %s", library.Name)
+	}
+	return filepath.Join(repoDir, resolved.Output, strings.TrimPrefix(library.Channel, "google/")), nil
+}
+
+// postProcess formats and checks the generated crate in outdir.
+func postProcess(ctx context.Context, library *config.Library, repoDir, outdir string) error {
 	// Run cargo fmt from the workspace root
 	cmd := exec.CommandContext(ctx, "cargo", "fmt", "--package", library.Name)
+	cmd.Dir = repoDir
 	if output, err := cmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("cargo fmt failed: %w\n%s", err, output)
 	}
@@ -101,49 +142,25 @@ func toSidekickConfig(library *config.Library, googleapisDir, serviceConfig stri
 		Codec: buildCodec(library),
 	}
 
-	// Add documentation overrides
-	// Start with global overrides from googleapis, filtered to only include
-	// overrides that are relevant to this API
-	globalOverrides, err := config.ReadDocumentationOverrides()
+	// Add the global and library documentation overrides
+	docOverrides, err := config.LibraryDocumentationOverrides(library, "rust")
 	if err != nil {
 		return nil, err
 	}
-
-	var allOverrides []config.RustDocumentationOverride
-	// Filter global overrides to only include ones for this API
-	// Convert API path (e.g., "google/cloud/developerconnect/v1") to proto package prefix (e.g., ".google.cloud.developerconnect.v1.")
-	apiPrefix := "." + strings.ReplaceAll(library.Channel, "/", ".") + "."
-	for _, override := range globalOverrides {
-		if strings.HasPrefix(override.ID, apiPrefix) {
-			allOverrides = append(allOverrides, override)
-		}
-	}
-
-	// Add library-specific overrides (these can override global ones)
-	if library.Rust != nil {
-		allOverrides = append(allOverrides, library.Rust.DocumentationOverrides...)
-	}
-
-	if len(allOverrides) > 0 {
-		sidekickCfg.CommentOverrides = make([]sidekickconfig.DocumentationOverride, len(allOverrides))
-		for i, override := range allOverrides {
-			sidekickCfg.CommentOverrides[i] = sidekickconfig.DocumentationOverride{
-				ID:      override.ID,
-				Match:   override.Match,
-				Replace: override.Replace,
-			}
-		}
+	for _, override := range docOverrides {
+		sidekickCfg.CommentOverrides = append(sidekickCfg.CommentOverrides, sidekickconfig.DocumentationOverride{
+			ID:      override.ID,
+			Match:   override.Match,
+			Replace: override.Replace,
+		})
 	}
 
 	// Add pagination overrides if any
-	if library.Rust != nil && len(library.Rust.PaginationOverrides) > 0 {
-		sidekickCfg.PaginationOverrides = make([]sidekickconfig.PaginationOverride, len(library.Rust.PaginationOverrides))
-		for i, override := range library.Rust.PaginationOverrides {
-			sidekickCfg.PaginationOverrides[i] = sidekickconfig.PaginationOverride{
-				ID:        override.ID,
-				ItemField: override.ItemField,
-			}
-		}
+	for _, override := range config.LibraryPaginationOverrides(library, "rust") {
+		sidekickCfg.PaginationOverrides = append(sidekickCfg.PaginationOverrides, sidekickconfig.PaginationOverride{
+			ID:        override.ID,
+			ItemField: override.ItemField,
+		})
 	}
 
 	return sidekickCfg, nil
@@ -23,7 +23,7 @@ func ConfigDefault() *config.Default {
 	return &config.Default{
 		Output: "src/generated/",
 		Generate: &config.DefaultGenerate{
-			All:           true,
+			Auto:          true,
 			OneLibraryPer: "version",
 			ReleaseLevel:  "stable",
 		},
@@ -46,6 +46,11 @@ func ConfigDefault() *config.Default {
 					Package: "google-cloud-api",
 					Source:  "google.api",
 				},
+				{
+					Name:    "auth",
+					Package: "google-cloud-auth",
+					UsedIf:  "media",
+				},
 				{
 					Name:    "async-trait",
 					Package: "async-trait",
@@ -82,6 +87,11 @@ func ConfigDefault() *config.Default {
 					Package: "google-cloud-type",
 					Source:  "google.type",
 				},
+				{
+					Name:    "http",
+					Package: "http",
+					UsedIf:  "media",
+				},
 				{
 					Name:    "iam_v1",
 					Package: "google-cloud-iam-v1",
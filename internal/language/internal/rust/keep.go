@@ -19,8 +19,21 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/settings"
 )
 
+// Clean deletes the generated files of library, preserving Cargo.toml files
+// and the files listed in library.Keep.
+func Clean(library *config.Library, resolved *settings.Settings, repoDir string) error {
+	outdir, err := outputDir(library, resolved, repoDir)
+	if err != nil {
+		return err
+	}
+	return cleanOutputDirectory(outdir, library.Keep)
+}
+
 // cleanOutputDirectory deletes everything in the output directory except files listed in keepPaths.
 // For Rust, if keepPaths is empty, all Cargo.toml files are automatically preserved.
 func cleanOutputDirectory(outdir string, keepPaths []string) error {
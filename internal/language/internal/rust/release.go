@@ -18,12 +18,14 @@ import (
 	"context"
 	"fmt"
 	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
 	"slices"
 	"strings"
 
 	"github.com/julieqiu/librarianx/internal/config"
+	sidekickconfig "github.com/julieqiu/librarianx/internal/sidekick/config"
 	rustrelease "github.com/julieqiu/librarianx/internal/sidekick/rust_release"
 	"github.com/pelletier/go-toml/v2"
 )
@@ -99,3 +101,38 @@ func BumpVersions(ctx context.Context, cfg *config.Config, configPath string) er
 	// Write updated config
 	return cfg.Write(configPath)
 }
+
+// Publish publishes all crates changed since the last release. With dryRun,
+// the crates are verified but not uploaded.
+func Publish(ctx context.Context, cfg *config.Config, dryRun bool) error {
+	release := &sidekickconfig.Release{
+		Remote: "upstream",
+		Branch: "main",
+	}
+	if cfg.Default != nil && cfg.Default.Release != nil {
+		if cfg.Default.Release.Remote != "" {
+			release.Remote = cfg.Default.Release.Remote
+		}
+		if cfg.Default.Release.Branch != "" {
+			release.Branch = cfg.Default.Release.Branch
+		}
+	}
+	if tools := cargoTools(cfg.Tools); len(tools) > 0 {
+		release.Tools = map[string][]sidekickconfig.Tool{"cargo": tools}
+	}
+	return rustrelease.Publish(release, dryRun, false)
+}
+
+// cargoTools returns the crates pinned in the tools section of
+// librarian.yaml, sorted by name, so the release installs the pinned
+// versions of cargo subcommands.
+func cargoTools(pins *config.Tools) []sidekickconfig.Tool {
+	if pins == nil {
+		return nil
+	}
+	var tools []sidekickconfig.Tool
+	for _, name := range slices.Sorted(maps.Keys(pins.Cargo)) {
+		tools = append(tools, sidekickconfig.Tool{Name: name, Version: pins.Cargo[name]})
+	}
+	return tools
+}
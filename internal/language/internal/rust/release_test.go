@@ -22,8 +22,29 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/julieqiu/librarianx/internal/config"
+	sidekickconfig "github.com/julieqiu/librarianx/internal/sidekick/config"
 )
 
+func TestCargoTools(t *testing.T) {
+	pins := &config.Tools{
+		Protoc: "25.1",
+		Cargo: map[string]string{
+			"cargo-semver-checks": "0.36.0",
+			"cargo-workspaces":    "0.3.6",
+		},
+	}
+	want := []sidekickconfig.Tool{
+		{Name: "cargo-semver-checks", Version: "0.36.0"},
+		{Name: "cargo-workspaces", Version: "0.3.6"},
+	}
+	if diff := cmp.Diff(want, cargoTools(pins)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if got := cargoTools(nil); got != nil {
+		t.Errorf("cargoTools(nil) = %v, want nil", got)
+	}
+}
+
 func TestBumpVersions(t *testing.T) {
 	tmpDir := t.TempDir()
 	t.Chdir(tmpDir)
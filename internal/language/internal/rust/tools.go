@@ -0,0 +1,55 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rust
+
+import (
+	"github.com/julieqiu/librarianx/internal/language/internal/toolchain"
+)
+
+// cargo is used both to generate and to release crates.
+var cargo = toolchain.Tool{
+	Name:        "cargo",
+	VersionArgs: []string{"--version"},
+	Install:     "see https://www.rust-lang.org/learn/get-started",
+}
+
+// GenerateTools returns the external tools invoked when generating Rust
+// crates.
+func GenerateTools() []toolchain.Tool {
+	return []toolchain.Tool{
+		cargo,
+		{
+			Name:        "taplo",
+			VersionArgs: []string{"--version"},
+			Install:     "cargo install taplo-cli",
+			Crate:       "taplo-cli",
+			Installer:   toolchain.CargoInstaller("taplo-cli"),
+		},
+		{
+			Name:        "typos",
+			VersionArgs: []string{"--version"},
+			Install:     "cargo install typos-cli",
+			Crate:       "typos-cli",
+			Installer:   toolchain.CargoInstaller("typos-cli"),
+		},
+		toolchain.Git(),
+	}
+}
+
+// ReleaseTools returns the external tools invoked when releasing Rust
+// crates.
+func ReleaseTools() []toolchain.Tool {
+	return []toolchain.Tool{cargo, toolchain.Git()}
+}
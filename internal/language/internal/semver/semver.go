@@ -0,0 +1,43 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package semver computes release versions for the language backends.
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BumpMinor returns the next minor version, dropping any pre-release or
+// build suffix such as -SNAPSHOT or +1. Packages before 1.0.0 treat minor
+// versions as breaking, so 0.1.2 becomes 0.2.0.
+func BumpMinor(version string) (string, error) {
+	core, _, _ := strings.Cut(version, "+")
+	core, _, _ = strings.Cut(core, "-")
+	components := strings.Split(core, ".")
+	if len(components) != 3 {
+		return "", fmt.Errorf("invalid version %q", version)
+	}
+	major, err := strconv.Atoi(components[0])
+	if err != nil {
+		return "", fmt.Errorf("invalid version %q: %w", version, err)
+	}
+	minor, err := strconv.Atoi(components[1])
+	if err != nil {
+		return "", fmt.Errorf("invalid version %q: %w", version, err)
+	}
+	return fmt.Sprintf("%d.%d.0", major, minor+1), nil
+}
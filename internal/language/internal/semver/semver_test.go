@@ -0,0 +1,51 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semver
+
+import "testing"
+
+func TestBumpMinor(t *testing.T) {
+	for _, test := range []struct {
+		version string
+		want    string
+	}{
+		{"0.1.0", "0.2.0"},
+		{"0.1.5", "0.2.0"},
+		{"1.2.3", "1.3.0"},
+		{"2.0.0-wip", "2.1.0"},
+		{"2.1.4-SNAPSHOT", "2.2.0"},
+		{"1.0.0+1", "1.1.0"},
+	} {
+		t.Run(test.version, func(t *testing.T) {
+			got, err := BumpMinor(test.version)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("BumpMinor(%q) = %q, want %q", test.version, got, test.want)
+			}
+		})
+	}
+}
+
+func TestBumpMinorInvalid(t *testing.T) {
+	for _, version := range []string{"", "1.2", "a.2.3", "1.b.3"} {
+		t.Run(version, func(t *testing.T) {
+			if _, err := BumpMinor(version); err == nil {
+				t.Errorf("BumpMinor(%q) should fail", version)
+			}
+		})
+	}
+}
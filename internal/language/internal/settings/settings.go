@@ -0,0 +1,79 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package settings resolves per-library settings against the repository
+// defaults, so every language backend applies the same precedence rules.
+package settings
+
+import (
+	"strings"
+
+	"github.com/julieqiu/librarianx/internal/config"
+)
+
+// Settings are the settings of a single library after applying the
+// repository defaults. Library settings always take precedence.
+type Settings struct {
+	// Output is the output directory of the library. It is library.Path if
+	// set, otherwise the default output with {name} replaced by the library
+	// name.
+	Output string `json:"output,omitempty"`
+
+	// Transport is the transport protocol (e.g., "grpc+rest", "grpc").
+	Transport string `json:"transport,omitempty"`
+
+	// RestNumericEnums indicates whether to use numeric enums in REST.
+	RestNumericEnums bool `json:"rest_numeric_enums,omitempty"`
+
+	// ReleaseLevel is the release level ("stable" or "preview").
+	ReleaseLevel string `json:"release_level,omitempty"`
+}
+
+// Resolve returns the settings of library, falling back to defaults for
+// everything the library does not set. defaults may be nil.
+func Resolve(library *config.Library, defaults *config.Default) *Settings {
+	s := &Settings{
+		Output:       OutputDir(library, defaults),
+		Transport:    library.Transport,
+		ReleaseLevel: library.ReleaseLevel,
+	}
+	var generate *config.DefaultGenerate
+	if defaults != nil {
+		generate = defaults.Generate
+	}
+	if s.Transport == "" && generate != nil {
+		s.Transport = generate.Transport
+	}
+	if s.ReleaseLevel == "" && generate != nil {
+		s.ReleaseLevel = generate.ReleaseLevel
+	}
+	if library.RestNumericEnums != nil {
+		s.RestNumericEnums = *library.RestNumericEnums
+	} else if generate != nil {
+		s.RestNumericEnums = generate.RestNumericEnums
+	}
+	return s
+}
+
+// OutputDir returns the output directory of library: library.Path if set,
+// otherwise the default output with {name} replaced by the library name.
+func OutputDir(library *config.Library, defaults *config.Default) string {
+	if library.Path != "" {
+		return library.Path
+	}
+	if defaults == nil {
+		return ""
+	}
+	return strings.ReplaceAll(defaults.Output, "{name}", library.Name)
+}
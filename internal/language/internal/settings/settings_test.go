@@ -0,0 +1,80 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package settings
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/config"
+)
+
+func TestResolve(t *testing.T) {
+	defaults := &config.Default{
+		Output: "packages/{name}/",
+		Generate: &config.DefaultGenerate{
+			Transport:        "grpc+rest",
+			RestNumericEnums: true,
+			ReleaseLevel:     "stable",
+		},
+	}
+	disabled := false
+	for _, test := range []struct {
+		name     string
+		library  *config.Library
+		defaults *config.Default
+		want     *Settings
+	}{
+		{
+			name:     "defaults",
+			library:  &config.Library{Name: "google-cloud-secret-manager"},
+			defaults: defaults,
+			want: &Settings{
+				Output:           "packages/google-cloud-secret-manager/",
+				Transport:        "grpc+rest",
+				RestNumericEnums: true,
+				ReleaseLevel:     "stable",
+			},
+		},
+		{
+			name: "library overrides",
+			library: &config.Library{
+				Name:             "google-cloud-secret-manager",
+				Path:             "custom/secretmanager",
+				Transport:        "grpc",
+				RestNumericEnums: &disabled,
+				ReleaseLevel:     "preview",
+			},
+			defaults: defaults,
+			want: &Settings{
+				Output:       "custom/secretmanager",
+				Transport:    "grpc",
+				ReleaseLevel: "preview",
+			},
+		},
+		{
+			name:    "no defaults",
+			library: &config.Library{Name: "secretmanager", Transport: "grpc"},
+			want:    &Settings{Transport: "grpc"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := Resolve(test.library, test.defaults)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staging
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Clean deletes everything in dir except the paths listed in keep, which are
+// relative to dir. A kept directory is kept with all of its contents, and
+// directories containing kept paths are cleaned recursively. Clean does
+// nothing if dir does not exist.
+func Clean(dir string, keep []string) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+	for _, entry := range entries {
+		if err := cleanPath(dir, entry.Name(), keep); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cleanPath deletes rel, relative to dir, unless it is kept. Directories
+// containing kept files are cleaned recursively.
+func cleanPath(dir, rel string, keep []string) error {
+	path := filepath.Join(dir, rel)
+	slashRel := filepath.ToSlash(rel)
+	var isParent bool
+	for _, k := range keep {
+		k = strings.TrimSuffix(filepath.ToSlash(k), "/")
+		if slashRel == k || strings.HasPrefix(slashRel, k+"/") {
+			return nil
+		}
+		if strings.HasPrefix(k, slashRel+"/") {
+			isParent = true
+		}
+	}
+	if !isParent {
+		if err := os.RemoveAll(path); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		return nil
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %w", path, err)
+	}
+	for _, entry := range entries {
+		if err := cleanPath(dir, filepath.Join(rel, entry.Name()), keep); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staging
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClean(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"CHANGELOG.md":             "kept",
+		"generated.txt":            "old",
+		"lib/generated.txt":        "old",
+		"lib/src/handwritten.txt":  "kept",
+		"test/handwritten_test.go": "kept",
+	})
+	if err := Clean(dir, []string{"CHANGELOG.md", "lib/src/handwritten.txt", "test/"}); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"CHANGELOG.md": "kept",
+		filepath.FromSlash("lib/src/handwritten.txt"):  "kept",
+		filepath.FromSlash("test/handwritten_test.go"): "kept",
+	}
+	if diff := cmp.Diff(want, readFiles(t, dir)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCleanMissingDirectory(t *testing.T) {
+	if err := Clean(filepath.Join(t.TempDir(), "missing"), nil); err != nil {
+		t.Error(err)
+	}
+}
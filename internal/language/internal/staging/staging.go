@@ -0,0 +1,180 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package staging provides transactional replacement of generated output
+// directories.
+//
+// Generators write into a staging directory next to the output directory.
+// The staging directory only replaces the output directory once generation
+// succeeds, so a failing protoc or post-processor run never leaves a library
+// deleted or half written.
+package staging
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	cp "github.com/otiai10/copy"
+)
+
+const (
+	stagingSuffix = ".librarian-staging"
+	backupSuffix  = ".librarian-backup"
+)
+
+// Staging is a staging directory for a single output directory.
+type Staging struct {
+	// Dir is the staging directory that generators should write into.
+	Dir string
+
+	outdir  string
+	backup  string
+	swapped bool
+}
+
+// New creates a staging directory next to outdir.
+//
+// If outdir exists, its contents are copied into the staging directory and
+// clean is called on the copy. Backends pass their keep-aware cleanup
+// function, so the staging directory starts out with exactly the files the
+// backend preserves across regeneration. Any staging directory left over from
+// a previous failed run is replaced.
+func New(outdir string, clean func(dir string) error) (*Staging, error) {
+	outdir = filepath.Clean(outdir)
+	s := &Staging{
+		Dir:    outdir + stagingSuffix,
+		outdir: outdir,
+		backup: outdir + backupSuffix,
+	}
+	if err := os.RemoveAll(s.Dir); err != nil {
+		return nil, fmt.Errorf("failed to remove stale staging directory %s: %w", s.Dir, err)
+	}
+	if _, err := os.Stat(outdir); err == nil {
+		if err := cp.Copy(outdir, s.Dir); err != nil {
+			return nil, fmt.Errorf("failed to copy %s to staging directory: %w", outdir, err)
+		}
+		if clean != nil {
+			if err := clean(s.Dir); err != nil {
+				return nil, err
+			}
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	return s, nil
+}
+
+// NewKept creates a staging directory for regenerating a library into
+// outdir. The staging directory starts out with only the files that clean
+// keeps, given the library's keep list, so the output directory is left
+// untouched until the staging directory is committed.
+func NewKept(outdir string, keep []string, clean func(dir string, keep []string) error) (*Staging, error) {
+	return New(outdir, func(dir string) error {
+		if err := clean(dir, keep); err != nil {
+			return fmt.Errorf("failed to clean output directory: %w", err)
+		}
+		return nil
+	})
+}
+
+// Swap moves the staging directory into place and sets the original output
+// directory aside. Call Restore to undo the swap, or Done to discard the
+// original output directory.
+//
+// Swap is used by backends whose post-processors must run at the final
+// location of the library.
+func (s *Staging) Swap() error {
+	if err := os.RemoveAll(s.backup); err != nil {
+		return fmt.Errorf("failed to remove stale backup directory %s: %w", s.backup, err)
+	}
+	hasOutdir := true
+	if _, err := os.Stat(s.outdir); errors.Is(err, os.ErrNotExist) {
+		hasOutdir = false
+	}
+	if hasOutdir {
+		if err := os.Rename(s.outdir, s.backup); err != nil {
+			return fmt.Errorf("failed to move %s aside: %w", s.outdir, err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(s.outdir), 0755); err != nil {
+		return err
+	}
+	if err := os.Rename(s.Dir, s.outdir); err != nil {
+		if hasOutdir {
+			if rerr := os.Rename(s.backup, s.outdir); rerr != nil {
+				return fmt.Errorf("failed to move staging directory into place: %w (restoring %s also failed: %v)", err, s.outdir, rerr)
+			}
+		}
+		return fmt.Errorf("failed to move staging directory into place: %w", err)
+	}
+	s.swapped = true
+	return nil
+}
+
+// Restore undoes a previous Swap. The original output directory is put back
+// in place and the generated tree is moved back to the staging directory, so
+// it can be inspected.
+func (s *Staging) Restore() error {
+	if !s.swapped {
+		return nil
+	}
+	if err := os.Rename(s.outdir, s.Dir); err != nil {
+		return fmt.Errorf("failed to move %s back to staging directory: %w", s.outdir, err)
+	}
+	if _, err := os.Stat(s.backup); err == nil {
+		if err := os.Rename(s.backup, s.outdir); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", s.outdir, err)
+		}
+	}
+	s.swapped = false
+	return nil
+}
+
+// Done discards the original output directory after a successful Swap.
+func (s *Staging) Done() error {
+	if err := os.RemoveAll(s.backup); err != nil {
+		return fmt.Errorf("failed to remove backup directory %s: %w", s.backup, err)
+	}
+	return nil
+}
+
+// Commit replaces the output directory with the staging directory.
+func (s *Staging) Commit() error {
+	if err := s.Swap(); err != nil {
+		return err
+	}
+	return s.Done()
+}
+
+// Fail is called when generation fails. It restores the original output
+// directory if needed and returns err annotated with the location of the
+// staging directory, which is kept for debugging.
+func (s *Staging) Fail(err error) error {
+	if rerr := s.Restore(); rerr != nil {
+		return errors.Join(err, rerr)
+	}
+	return fmt.Errorf("%w (generated output kept in %s)", err, s.Dir)
+}
+
+// IsTemporary reports whether name is the base name of a staging or backup
+// directory. Tools that scan the repository use it to skip these directories.
+func IsTemporary(name string) bool {
+	return strings.HasSuffix(name, stagingSuffix) || strings.HasSuffix(name, backupSuffix)
+}
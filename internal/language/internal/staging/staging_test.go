@@ -0,0 +1,215 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staging
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCommit(t *testing.T) {
+	outdir := filepath.Join(t.TempDir(), "secretmanager")
+	writeFiles(t, outdir, map[string]string{
+		"keep.txt":      "kept",
+		"generated.txt": "old",
+	})
+
+	s, err := New(outdir, func(dir string) error {
+		return os.Remove(filepath.Join(dir, "generated.txt"))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFiles(t, s.Dir, map[string]string{"generated.txt": "new"})
+	if err := s.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"keep.txt":      "kept",
+		"generated.txt": "new",
+	}
+	if diff := cmp.Diff(want, readFiles(t, outdir)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	for _, dir := range []string{s.Dir, s.backup} {
+		if _, err := os.Stat(dir); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s should not exist after commit, got %v", dir, err)
+		}
+	}
+}
+
+func TestCommitNewDirectory(t *testing.T) {
+	outdir := filepath.Join(t.TempDir(), "packages", "secretmanager")
+	s, err := New(outdir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFiles(t, s.Dir, map[string]string{"generated.txt": "new"})
+	if err := s.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"generated.txt": "new"}
+	if diff := cmp.Diff(want, readFiles(t, outdir)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFailBeforeSwap(t *testing.T) {
+	outdir := filepath.Join(t.TempDir(), "secretmanager")
+	original := map[string]string{"generated.txt": "old"}
+	writeFiles(t, outdir, original)
+
+	s, err := New(outdir, func(dir string) error {
+		return os.Remove(filepath.Join(dir, "generated.txt"))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFiles(t, s.Dir, map[string]string{"partial.txt": "partial"})
+	if err := s.Fail(errors.New("protoc failed")); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if diff := cmp.Diff(original, readFiles(t, outdir)); diff != "" {
+		t.Errorf("output directory mismatch (-want +got):\n%s", diff)
+	}
+	want := map[string]string{"partial.txt": "partial"}
+	if diff := cmp.Diff(want, readFiles(t, s.Dir)); diff != "" {
+		t.Errorf("staging directory mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFailAfterSwap(t *testing.T) {
+	outdir := filepath.Join(t.TempDir(), "secretmanager")
+	original := map[string]string{"generated.txt": "old"}
+	writeFiles(t, outdir, original)
+
+	s, err := New(outdir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFiles(t, s.Dir, map[string]string{"generated.txt": "new"})
+	if err := s.Swap(); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"generated.txt": "new"}
+	if diff := cmp.Diff(want, readFiles(t, outdir)); diff != "" {
+		t.Errorf("output directory after swap mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := s.Fail(errors.New("post processor failed")); err == nil {
+		t.Fatal("expected an error")
+	}
+	if diff := cmp.Diff(original, readFiles(t, outdir)); diff != "" {
+		t.Errorf("output directory mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(want, readFiles(t, s.Dir)); diff != "" {
+		t.Errorf("staging directory mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewReplacesStaleStaging(t *testing.T) {
+	outdir := filepath.Join(t.TempDir(), "secretmanager")
+	writeFiles(t, outdir, map[string]string{"generated.txt": "old"})
+	writeFiles(t, outdir+stagingSuffix, map[string]string{"stale.txt": "stale"})
+
+	s, err := New(outdir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"generated.txt": "old"}
+	if diff := cmp.Diff(want, readFiles(t, s.Dir)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewKept(t *testing.T) {
+	outdir := filepath.Join(t.TempDir(), "secretmanager")
+	writeFiles(t, outdir, map[string]string{
+		"keep.txt":      "kept",
+		"generated.txt": "old",
+	})
+
+	var gotKeep []string
+	s, err := NewKept(outdir, []string{"keep.txt"}, func(dir string, keep []string) error {
+		gotKeep = keep
+		return os.Remove(filepath.Join(dir, "generated.txt"))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"keep.txt"}, gotKeep); diff != "" {
+		t.Errorf("keep mismatch (-want +got):\n%s", diff)
+	}
+	want := map[string]string{"keep.txt": "kept"}
+	if diff := cmp.Diff(want, readFiles(t, s.Dir)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewKeptCleanFailure(t *testing.T) {
+	outdir := filepath.Join(t.TempDir(), "secretmanager")
+	writeFiles(t, outdir, map[string]string{"generated.txt": "old"})
+
+	cleanErr := errors.New("clean failed")
+	_, err := NewKept(outdir, nil, func(string, []string) error {
+		return cleanErr
+	})
+	if !errors.Is(err, cleanErr) {
+		t.Errorf("got %v, want %v", err, cleanErr)
+	}
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func readFiles(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	got := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		got[rel] = string(content)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
@@ -0,0 +1,277 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toolchain
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/julieqiu/librarianx/internal/config"
+)
+
+// Installer installs version of a tool into dir, so that the tool ends up
+// in dir/bin.
+type Installer func(ctx context.Context, dir, version string) error
+
+// Pin sets the version of every tool pinned in pins. pins may be nil.
+func Pin(tools []Tool, pins *config.Tools) []Tool {
+	if pins == nil {
+		return tools
+	}
+	var pinned []Tool
+	for _, tool := range tools {
+		switch {
+		case tool.Name == "protoc":
+			tool.Version = pins.Protoc
+		case tool.Name == "synthtool":
+			tool.Version = pins.Synthtool
+		case tool.Crate != "":
+			tool.Version = pins.Cargo[tool.Crate]
+		default:
+			tool.Version = pins.Plugins[tool.Name]
+		}
+		pinned = append(pinned, tool)
+	}
+	return pinned
+}
+
+// installedMarker is the file written into the installation directory of a
+// tool once its installer succeeds.
+const installedMarker = ".installed"
+
+// Dir returns the directory the pinned version of tool is installed into:
+// {cacheDir}/tools/{name}/{version}.
+func Dir(cacheDir string, tool Tool) string {
+	return filepath.Join(cacheDir, "tools", tool.Name, tool.Version)
+}
+
+// isInstalled reports whether path is the executable of the pinned version
+// of tool in the librarian cache, i.e. .../tools/{name}/{version}/bin/{name}.
+func isInstalled(path string, tool Tool) bool {
+	bin := filepath.Dir(path)
+	versionDir := filepath.Dir(bin)
+	nameDir := filepath.Dir(versionDir)
+	return filepath.Base(bin) == "bin" &&
+		filepath.Base(versionDir) == tool.Version &&
+		filepath.Base(nameDir) == tool.Name &&
+		filepath.Base(filepath.Dir(nameDir)) == "tools"
+}
+
+// Activate puts the pinned versions of tools installed in cacheDir first on
+// the PATH of the current process, so backends invoke them instead of the
+// tools installed on the system.
+func Activate(cacheDir string, tools []Tool) error {
+	path := filepath.SplitList(os.Getenv("PATH"))
+	for _, tool := range tools {
+		if tool.Version == "" {
+			continue
+		}
+		dir := Dir(cacheDir, tool)
+		if _, err := os.Stat(filepath.Join(dir, installedMarker)); err != nil {
+			continue
+		}
+		bin := filepath.Join(dir, "bin")
+		if len(path) > 0 && path[0] == bin {
+			continue
+		}
+		path = append([]string{bin}, path...)
+	}
+	return os.Setenv("PATH", strings.Join(path, string(os.PathListSeparator)))
+}
+
+// Install installs the pinned version of every tool into cacheDir, unless it
+// is installed already.
+func Install(ctx context.Context, cacheDir string, tools []Tool) error {
+	var errs []error
+	for _, tool := range tools {
+		if tool.Version == "" {
+			continue
+		}
+		dir := Dir(cacheDir, tool)
+		if _, err := os.Stat(filepath.Join(dir, installedMarker)); err == nil {
+			continue
+		}
+		if tool.Installer == nil {
+			errs = append(errs, fmt.Errorf("%s %s cannot be installed by librarian; %s", tool.Name, tool.Version, tool.Install))
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "Installing %s %s into %s\n", tool.Name, tool.Version, dir)
+		if err := installInto(ctx, dir, tool); err != nil {
+			errs = append(errs, fmt.Errorf("failed to install %s %s: %w", tool.Name, tool.Version, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// installInto runs the installer of tool in dir and writes the installed
+// marker on success, so a failed or interrupted installation is never
+// mistaken for an installed tool. The installation cannot happen elsewhere
+// and be moved into dir: virtual environments hardcode their path in the
+// scripts they contain.
+func installInto(ctx context.Context, dir string, tool Tool) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if err := tool.Installer(ctx, dir, tool.Version); err != nil {
+		return errors.Join(err, os.RemoveAll(dir))
+	}
+	return os.WriteFile(filepath.Join(dir, installedMarker), nil, 0644)
+}
+
+// GoInstaller returns an installer running `go install pkg@version`.
+func GoInstaller(pkg string) Installer {
+	return func(ctx context.Context, dir, version string) error {
+		if !strings.HasPrefix(version, "v") {
+			version = "v" + version
+		}
+		cmd := exec.CommandContext(ctx, "go", "install", pkg+"@"+version)
+		cmd.Env = append(os.Environ(), "GOBIN="+filepath.Join(dir, "bin"))
+		return run(cmd)
+	}
+}
+
+// PipInstaller returns an installer creating a virtual environment with
+// package pkg at the requested version.
+func PipInstaller(pkg string) Installer {
+	return func(ctx context.Context, dir, version string) error {
+		return PipInstall(ctx, dir, fmt.Sprintf("%s==%s", pkg, version))
+	}
+}
+
+// PipInstall creates a virtual environment in dir and installs the pip
+// requirement spec into it.
+func PipInstall(ctx context.Context, dir, spec string) error {
+	if err := run(exec.CommandContext(ctx, "python3", "-m", "venv", dir)); err != nil {
+		return err
+	}
+	return run(exec.CommandContext(ctx, filepath.Join(dir, "bin", "pip"), "install", spec))
+}
+
+// CargoInstaller returns an installer running `cargo install crate@version`.
+func CargoInstaller(crate string) Installer {
+	return func(ctx context.Context, dir, version string) error {
+		return run(exec.CommandContext(ctx, "cargo", "install", "--locked", "--root", dir, crate+"@"+version))
+	}
+}
+
+// installProtoc downloads a protoc release into dir.
+func installProtoc(ctx context.Context, dir, version string) error {
+	url, err := protocURL(version, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return err
+	}
+	archive, err := os.CreateTemp("", "protoc-*.zip")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+	if _, err := io.Copy(archive, resp.Body); err != nil {
+		return fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	return extractZip(archive.Name(), dir)
+}
+
+// protocURL returns the URL of the protoc release for the platform.
+func protocURL(version, goos, goarch string) (string, error) {
+	arch := map[string]string{"amd64": "x86_64", "arm64": "aarch_64"}[goarch]
+	platform := map[string]string{"linux": "linux", "darwin": "osx"}[goos]
+	if arch == "" || platform == "" {
+		return "", fmt.Errorf("no protoc release for %s/%s", goos, goarch)
+	}
+	version = strings.TrimPrefix(version, "v")
+	return fmt.Sprintf("https://github.com/protocolbuffers/protobuf/releases/download/v%s/protoc-%s-%s-%s.zip", version, version, platform, arch), nil
+}
+
+// extractZip extracts the archive at src into dst, keeping the executable
+// bits of the files.
+func extractZip(src, dst string) error {
+	r, err := zip.OpenReader(src)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	for _, f := range r.File {
+		target := filepath.Join(dst, filepath.FromSlash(f.Name))
+		if !strings.HasPrefix(target, filepath.Clean(dst)+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid path %q in %s", f.Name, src)
+		}
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := extractFile(f, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractFile(f *zip.File, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, f.Mode().Perm()|0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, rc); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func run(cmd *exec.Cmd) error {
+	fmt.Fprintf(os.Stderr, "Running: %s\n", strings.Join(cmd.Args, " "))
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed: %w", strings.Join(cmd.Args, " "), err)
+	}
+	return nil
+}
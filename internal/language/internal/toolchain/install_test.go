@@ -0,0 +1,204 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toolchain
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/config"
+)
+
+func TestPin(t *testing.T) {
+	tools := []Tool{
+		{Name: "protoc"},
+		{Name: "protoc-gen-go_gapic"},
+		{Name: "taplo", Crate: "taplo-cli"},
+		{Name: "synthtool"},
+		{Name: "git"},
+	}
+	pins := &config.Tools{
+		Protoc:    "25.1",
+		Plugins:   map[string]string{"protoc-gen-go_gapic": "v0.53.1"},
+		Synthtool: "abc123",
+		Cargo:     map[string]string{"taplo-cli": "0.9.3"},
+	}
+	var got []string
+	for _, tool := range Pin(tools, pins) {
+		got = append(got, tool.Name+"@"+tool.Version)
+	}
+	want := []string{"protoc@25.1", "protoc-gen-go_gapic@v0.53.1", "taplo@0.9.3", "synthtool@abc123", "git@"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(tools, Pin(tools, nil)); diff != "" {
+		t.Errorf("Pin without pins changed the tools (-want +got):\n%s", diff)
+	}
+}
+
+func TestIsInstalled(t *testing.T) {
+	tool := Tool{Name: "protoc-gen-go_gapic", Version: "v0.53.1"}
+	for _, test := range []struct {
+		path string
+		want bool
+	}{
+		{"/home/user/.librarian/cache/tools/protoc-gen-go_gapic/v0.53.1/bin/protoc-gen-go_gapic", true},
+		{"/home/user/.librarian/cache/tools/protoc-gen-go_gapic/v0.52.0/bin/protoc-gen-go_gapic", false},
+		{"/home/user/go/bin/protoc-gen-go_gapic", false},
+	} {
+		if got := isInstalled(test.path, tool); got != test.want {
+			t.Errorf("isInstalled(%q) = %t, want %t", test.path, got, test.want)
+		}
+	}
+}
+
+func TestProtocURL(t *testing.T) {
+	for _, test := range []struct {
+		version, goos, goarch string
+		want                  string
+		wantErr               bool
+	}{
+		{
+			version: "25.1", goos: "linux", goarch: "amd64",
+			want: "https://github.com/protocolbuffers/protobuf/releases/download/v25.1/protoc-25.1-linux-x86_64.zip",
+		},
+		{
+			version: "v28.3", goos: "darwin", goarch: "arm64",
+			want: "https://github.com/protocolbuffers/protobuf/releases/download/v28.3/protoc-28.3-osx-aarch_64.zip",
+		},
+		{version: "25.1", goos: "windows", goarch: "amd64", wantErr: true},
+	} {
+		got, err := protocURL(test.version, test.goos, test.goarch)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("protocURL(%q, %q, %q): expected an error", test.version, test.goos, test.goarch)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("protocURL(%q, %q, %q) = %q, want %q", test.version, test.goos, test.goarch, got, test.want)
+		}
+	}
+}
+
+func TestInstallAndActivate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses shell scripts")
+	}
+	cacheDir := t.TempDir()
+	var installs int
+	tool := Tool{
+		Name:    "fake-plugin",
+		Version: "1.0.0",
+		Installer: func(ctx context.Context, dir, version string) error {
+			installs++
+			script := "#!/bin/sh\necho fake-plugin " + version + "\n"
+			if err := os.MkdirAll(filepath.Join(dir, "bin"), 0755); err != nil {
+				return err
+			}
+			return os.WriteFile(filepath.Join(dir, "bin", "fake-plugin"), []byte(script), 0755)
+		},
+	}
+	tools := []Tool{tool, {Name: "unpinned"}}
+	for range 2 {
+		if err := Install(t.Context(), cacheDir, tools); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if installs != 1 {
+		t.Errorf("installer ran %d times, want 1", installs)
+	}
+
+	t.Setenv("PATH", t.TempDir())
+	if err := Activate(cacheDir, tools); err != nil {
+		t.Fatal(err)
+	}
+	got := check(t.Context(), tool)
+	if got.Err != nil {
+		t.Fatal(got.Err)
+	}
+	if want := filepath.Join(cacheDir, "tools", "fake-plugin", "1.0.0", "bin", "fake-plugin"); got.Path != want {
+		t.Errorf("path = %q, want %q", got.Path, want)
+	}
+}
+
+func TestInstallFailure(t *testing.T) {
+	cacheDir := t.TempDir()
+	tool := Tool{
+		Name:    "fake-plugin",
+		Version: "1.0.0",
+		Installer: func(ctx context.Context, dir, version string) error {
+			if err := os.MkdirAll(filepath.Join(dir, "bin"), 0755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(filepath.Join(dir, "bin", "fake-plugin"), nil, 0755); err != nil {
+				return err
+			}
+			return errors.New("interrupted")
+		},
+	}
+	if err := Install(t.Context(), cacheDir, []Tool{tool}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if _, err := os.Stat(Dir(cacheDir, tool)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the partial installation to be removed, got %v", err)
+	}
+}
+
+func TestInstallPip(t *testing.T) {
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("skipping test because python3 is not installed")
+	}
+	// Installing the version of pip bundled with the interpreter does not
+	// need network access.
+	output, err := exec.Command(python, "-c", "import ensurepip; print(ensurepip.version())").Output()
+	if err != nil {
+		t.Skipf("skipping test because ensurepip is not available: %v", err)
+	}
+	tool := Tool{Name: "pip", Version: strings.TrimSpace(string(output)), Installer: PipInstaller("pip")}
+	cacheDir := t.TempDir()
+	if err := Install(t.Context(), cacheDir, []Tool{tool}); err != nil {
+		t.Fatal(err)
+	}
+	// The console scripts of a virtual environment refer to the interpreter
+	// by its absolute path, so they only work if the environment was not
+	// moved after its creation.
+	pip := filepath.Join(Dir(cacheDir, tool), "bin", "pip")
+	if output, err := exec.Command(pip, "--version").CombinedOutput(); err != nil {
+		t.Fatalf("%s --version failed: %v\n%s", pip, err, output)
+	}
+}
+
+func TestInstallWithoutInstaller(t *testing.T) {
+	err := Install(t.Context(), t.TempDir(), []Tool{{Name: "java", Version: "21", Install: "install a JDK"}})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if want := "java 21 cannot be installed by librarian"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error %q does not contain %q", err, want)
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package toolchain verifies the external tools invoked by the language
+// backends, so a missing or outdated tool is reported before generation
+// starts instead of failing halfway with an exec error.
+package toolchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Tool is an external executable a backend invokes.
+type Tool struct {
+	// Name is the executable, looked up on the PATH.
+	Name string
+
+	// VersionArgs are the arguments that print the tool version. Tools
+	// without version arguments, such as protoc plugins, are only checked
+	// for presence.
+	VersionArgs []string
+
+	// MinVersion is the oldest supported version, if any.
+	MinVersion string
+
+	// Install tells the user how to install the tool.
+	Install string
+
+	// Version is the exact version pinned in the tools section of
+	// librarian.yaml, if any.
+	Version string
+
+	// Crate is the crate providing the tool, for tools installed with
+	// `cargo install`. Their versions are pinned by crate name.
+	Crate string
+
+	// Installer installs a version of the tool, see Install. Nil if
+	// librarian cannot install the tool.
+	Installer Installer
+}
+
+// Result is the outcome of checking a single tool.
+type Result struct {
+	Tool Tool
+
+	// Path is the resolved executable, empty if the tool was not found.
+	Path string
+
+	// Version is the installed version, if the tool reports one.
+	Version string
+
+	// Err is set if the tool is missing, broken or too old.
+	Err error
+}
+
+// Protoc returns the protocol buffer compiler used by every protoc-based
+// backend.
+func Protoc() Tool {
+	return Tool{
+		Name:        "protoc",
+		VersionArgs: []string{"--version"},
+		MinVersion:  "3.21",
+		Install:     "download a release from https://github.com/protocolbuffers/protobuf/releases",
+		Installer:   installProtoc,
+	}
+}
+
+// Git returns the git tool used to release libraries.
+func Git() Tool {
+	return Tool{
+		Name:        "git",
+		VersionArgs: []string{"--version"},
+		Install:     "see https://github.com/git-guides/install-git",
+	}
+}
+
+// Check checks every tool in tools.
+func Check(ctx context.Context, tools []Tool) []Result {
+	var results []Result
+	for _, tool := range tools {
+		results = append(results, check(ctx, tool))
+	}
+	return results
+}
+
+// Verify checks every tool in tools and returns an error describing all
+// problems found.
+func Verify(ctx context.Context, tools []Tool) error {
+	var errs []error
+	for _, result := range Check(ctx, tools) {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("missing or outdated tools, run `librarian doctor` for details:\n%w", errors.Join(errs...))
+}
+
+func check(ctx context.Context, tool Tool) Result {
+	result := Result{Tool: tool}
+	path, err := exec.LookPath(tool.Name)
+	if err != nil {
+		result.Err = fmt.Errorf("%s not found; %s", tool.Name, tool.Install)
+		return result
+	}
+	result.Path = path
+	if len(tool.VersionArgs) == 0 {
+		// The version of a pinned tool that cannot report it is known
+		// from its location in the cache.
+		if tool.Version != "" {
+			if !isInstalled(path, tool) {
+				result.Err = fmt.Errorf("%s at %s is not the pinned version %s; run `librarian doctor --install`", tool.Name, path, tool.Version)
+				return result
+			}
+			result.Version = tool.Version
+		}
+		return result
+	}
+
+	out, err := exec.CommandContext(ctx, path, tool.VersionArgs...).CombinedOutput()
+	if err != nil {
+		result.Err = fmt.Errorf("%s %s failed: %w\n%s", tool.Name, strings.Join(tool.VersionArgs, " "), err, out)
+		return result
+	}
+	result.Version = parseVersion(string(out))
+	if tool.Version != "" {
+		if result.Version != strings.TrimPrefix(tool.Version, "v") {
+			result.Err = fmt.Errorf("%s %s does not match the pinned version %s; run `librarian doctor --install`", tool.Name, result.Version, tool.Version)
+		}
+		return result
+	}
+	if tool.MinVersion == "" {
+		return result
+	}
+	if result.Version == "" {
+		result.Err = fmt.Errorf("%s: cannot determine version from %q", tool.Name, strings.TrimSpace(string(out)))
+		return result
+	}
+	if compareVersions(result.Version, tool.MinVersion) < 0 {
+		result.Err = fmt.Errorf("%s %s is older than the required %s; %s", tool.Name, result.Version, tool.MinVersion, tool.Install)
+	}
+	return result
+}
+
+var versionPattern = regexp.MustCompile(`\d+(\.\d+)+`)
+
+// parseVersion returns the first dotted version number in out, e.g. 25.1
+// for "libprotoc 25.1" or 1.23.4 for "go version go1.23.4 linux/amd64".
+func parseVersion(out string) string {
+	return versionPattern.FindString(out)
+}
+
+// compareVersions compares two dotted version numbers. Missing components
+// are zero, so 1.23 and 1.23.0 are equal.
+func compareVersions(a, b string) int {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toolchain
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	for _, test := range []struct {
+		out  string
+		want string
+	}{
+		{"libprotoc 25.1\n", "25.1"},
+		{"go version go1.23.4 linux/amd64\n", "1.23.4"},
+		{"Python 3.11.2\n", "3.11.2"},
+		{"black, 24.1.0 (compiled: yes)\nPython (CPython) 3.11.2\n", "24.1.0"},
+		{"Dart SDK version: 3.9.0 (stable) on \"linux_x64\"\n", "3.9.0"},
+		{"no version here", ""},
+	} {
+		if got := parseVersion(test.out); got != test.want {
+			t.Errorf("parseVersion(%q) = %q, want %q", test.out, got, test.want)
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	for _, test := range []struct {
+		a, b string
+		want int
+	}{
+		{"25.1", "3.21", 1},
+		{"3.12.4", "3.21", -1},
+		{"1.23", "1.23.0", 0},
+		{"1.23.4", "1.23", 1},
+	} {
+		if got := compareVersions(test.a, test.b); got != test.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses shell scripts")
+	}
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho 'fake-tool 1.2.3'\n"
+	if err := os.WriteFile(filepath.Join(dir, "fake-tool"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	for _, test := range []struct {
+		name    string
+		tool    Tool
+		version string
+		wantErr string
+	}{
+		{
+			name:    "new enough",
+			tool:    Tool{Name: "fake-tool", VersionArgs: []string{"--version"}, MinVersion: "1.2"},
+			version: "1.2.3",
+		},
+		{
+			name:    "too old",
+			tool:    Tool{Name: "fake-tool", VersionArgs: []string{"--version"}, MinVersion: "2.0", Install: "upgrade it"},
+			version: "1.2.3",
+			wantErr: "fake-tool 1.2.3 is older than the required 2.0; upgrade it",
+		},
+		{
+			name:    "pinned",
+			tool:    Tool{Name: "fake-tool", VersionArgs: []string{"--version"}, Version: "v1.2.3"},
+			version: "1.2.3",
+		},
+		{
+			name:    "pinned mismatch",
+			tool:    Tool{Name: "fake-tool", VersionArgs: []string{"--version"}, MinVersion: "1.0", Version: "1.2.4"},
+			version: "1.2.3",
+			wantErr: "fake-tool 1.2.3 does not match the pinned version 1.2.4",
+		},
+		{
+			name:    "pinned without version output",
+			tool:    Tool{Name: "fake-tool", Version: "1.2.3"},
+			wantErr: "is not the pinned version 1.2.3",
+		},
+		{
+			name: "presence only",
+			tool: Tool{Name: "fake-tool"},
+		},
+		{
+			name:    "missing",
+			tool:    Tool{Name: "librarian-missing-tool", Install: "install it"},
+			wantErr: "librarian-missing-tool not found; install it",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := check(t.Context(), test.tool)
+			if got.Version != test.version {
+				t.Errorf("version = %q, want %q", got.Version, test.version)
+			}
+			if test.wantErr == "" {
+				if got.Err != nil {
+					t.Errorf("unexpected error: %v", got.Err)
+				}
+				return
+			}
+			if got.Err == nil || !strings.Contains(got.Err.Error(), test.wantErr) {
+				t.Errorf("error = %v, want %q", got.Err, test.wantErr)
+			}
+		})
+	}
+}
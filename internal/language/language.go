@@ -0,0 +1,173 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package language
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"sync"
+
+	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language/internal/settings"
+	"github.com/julieqiu/librarianx/internal/language/internal/toolchain"
+)
+
+// ErrNotSupported is returned by backends for operations their language does
+// not support.
+var ErrNotSupported = errors.New("not supported")
+
+// Settings are the settings of a single library after applying the
+// repository defaults.
+type Settings = settings.Settings
+
+// Tool is an external executable a backend invokes.
+type Tool = toolchain.Tool
+
+// Operations a backend reports its tools for.
+const (
+	OperationGenerate = "generate"
+	OperationRelease  = "release"
+)
+
+// Language is a client library generator for one programming language.
+//
+// Built-in languages are registered with Register. Any other language is
+// served by an external executable named librarian-{language} on the PATH,
+// see doc/language-protocol.md.
+type Language interface {
+	// Init returns the default configuration for a new repository. It may
+	// also fill in language-specific parts of cfg, such as sources.
+	Init(ctx context.Context, cacheDir string, cfg *config.Config) (*config.Default, error)
+
+	// Create generates a new library, including its scaffolding files.
+	Create(ctx context.Context, req *Request) error
+
+	// Generate regenerates an existing library.
+	Generate(ctx context.Context, req *Request) error
+
+	// PostProcess runs only the post-processing step on a generated library.
+	PostProcess(ctx context.Context, req *Request) error
+
+	// Release bumps the versions of all libraries and records them in
+	// cfg.Versions, which is then written to configPath.
+	Release(ctx context.Context, cfg *config.Config, configPath string) error
+
+	// Publish uploads released libraries to the package registry.
+	Publish(ctx context.Context, cfg *config.Config, dryRun bool) error
+
+	// Clean deletes the generated files of a library, preserving kept files.
+	Clean(ctx context.Context, req *Request) error
+
+	// Tools returns the external tools the backend invokes for operation,
+	// OperationGenerate or OperationRelease.
+	Tools(cfg *config.Config, operation string) []Tool
+}
+
+// Request describes the library a Language operates on.
+type Request struct {
+	// Repo is the repository of the libraries (e.g., "googleapis/google-cloud-python").
+	Repo string
+
+	// RepoDir is the root directory of the repository, i.e. the directory
+	// containing librarian.yaml.
+	RepoDir string
+
+	// Library is the library configuration.
+	Library *config.Library
+
+	// Defaults is the repository default configuration.
+	Defaults *config.Default
+
+	// Settings are the library settings resolved against Defaults.
+	Settings *Settings
+
+	// GoogleapisDir is the root of the googleapis checkout.
+	GoogleapisDir string
+
+	// ServiceConfigPath is the service config of the library's API.
+	ServiceConfigPath string
+
+	// DefaultChannel is the API path of the library's default version.
+	DefaultChannel string
+}
+
+// NewRequest returns a request for library with its settings resolved
+// against defaults.
+func NewRequest(repo, repoDir string, library *config.Library, defaults *config.Default, googleapisDir, serviceConfigPath string) *Request {
+	return &Request{
+		Repo:              repo,
+		RepoDir:           repoDir,
+		Library:           library,
+		Defaults:          defaults,
+		Settings:          settings.Resolve(library, defaults),
+		GoogleapisDir:     googleapisDir,
+		ServiceConfigPath: serviceConfigPath,
+		DefaultChannel:    getDefaultChannel(library),
+	}
+}
+
+// dartDefaults returns the repository-wide Dart settings, or nil.
+func (r *Request) dartDefaults() *config.DartDefault {
+	if r.Defaults == nil {
+		return nil
+	}
+	return r.Defaults.Dart
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Language{}
+)
+
+// Register makes a language available under name. It panics if name is
+// already registered.
+func Register(name string, lang Language) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("language %q registered twice", name))
+	}
+	registry[name] = lang
+}
+
+// Lookup returns the language registered under name. If there is none, it
+// looks for an external backend named librarian-{name} on the PATH.
+func Lookup(name string) (Language, error) {
+	registryMu.RLock()
+	lang, ok := registry[name]
+	registryMu.RUnlock()
+	if ok {
+		return lang, nil
+	}
+	if path, err := exec.LookPath(externalPrefix + name); err == nil {
+		return NewExternal(name, path), nil
+	}
+	return nil, fmt.Errorf("unsupported language: %s", name)
+}
+
+// Languages returns the names of the registered languages, sorted.
+func Languages() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	var names []string
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
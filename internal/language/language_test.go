@@ -0,0 +1,369 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package language
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/config"
+)
+
+func TestLanguages(t *testing.T) {
+	want := []string{"dart", "go", "java", "nodejs", "python", "rust"}
+	if diff := cmp.Diff(want, Languages()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLookupUnknown(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	if _, err := Lookup("cobol"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestNotSupported(t *testing.T) {
+	err := PostProcess(t.Context(), "rust", "", "", &config.Library{Name: "google-cloud-secretmanager-v1"}, &config.Default{})
+	if !errors.Is(err, ErrNotSupported) {
+		t.Errorf("got %v, want ErrNotSupported", err)
+	}
+}
+
+// writeBackend writes an external backend that records its argument and
+// request in dir and prints response.
+func writeBackend(t *testing.T, dir, name, response string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("external backend test uses a shell script")
+	}
+	script := `#!/bin/sh
+echo "$1" > "` + dir + `/command"
+cat > "` + dir + `/request.json"
+cat <<'RESPONSE'
+` + response + `
+RESPONSE
+`
+	if err := os.WriteFile(filepath.Join(dir, "librarian-"+name), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readRequest(t *testing.T, dir string) (string, map[string]any) {
+	t.Helper()
+	command, err := os.ReadFile(filepath.Join(dir, "command"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "request.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var req map[string]any
+	if err := json.Unmarshal(data, &req); err != nil {
+		t.Fatal(err)
+	}
+	return string(command), req
+}
+
+func TestExternalGenerate(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	writeBackend(t, dir, "ruby", "{}")
+
+	library := &config.Library{
+		Name:              "google-cloud-secretmanager",
+		Channel:           "google/cloud/secretmanager/v1",
+		APIServiceConfigs: map[string]string{"google/cloud/secretmanager/v1": "google/cloud/secretmanager/v1/secretmanager_v1.yaml"},
+	}
+	defaults := &config.Default{
+		Output:   "{name}/",
+		Generate: &config.DefaultGenerate{Transport: "grpc+rest", OneLibraryPer: "api"},
+	}
+	repoDir := t.TempDir()
+	if err := Generate(t.Context(), "api", "ruby", "googleapis/google-cloud-ruby", repoDir, library, defaults, "/googleapis"); err != nil {
+		t.Fatal(err)
+	}
+
+	command, got := readRequest(t, dir)
+	if command != "generate\n" {
+		t.Errorf("command = %q, want %q", command, "generate\n")
+	}
+	want := map[string]any{
+		"protocol": float64(1),
+		"command":  "generate",
+		"language": "ruby",
+		"repo":     "googleapis/google-cloud-ruby",
+		"repo_dir": repoDir,
+		"library": map[string]any{
+			"name":    "google-cloud-secretmanager",
+			"channel": "google/cloud/secretmanager/v1",
+		},
+		"defaults": map[string]any{
+			"output": "{name}/",
+			"generate": map[string]any{
+				"one_library_per": "api",
+				"transport":       "grpc+rest",
+			},
+		},
+		"settings": map[string]any{
+			"output":    "google-cloud-secretmanager/",
+			"transport": "grpc+rest",
+		},
+		"googleapis_dir":  "/googleapis",
+		"service_config":  "google/cloud/secretmanager/v1/secretmanager_v1.yaml",
+		"default_channel": "google/cloud/secretmanager/v1",
+		"api_service_configs": map[string]any{
+			"google/cloud/secretmanager/v1": "google/cloud/secretmanager/v1/secretmanager_v1.yaml",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("request mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestExternalInit(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	writeBackend(t, dir, "ruby", `{"defaults": {"output": "{name}/", "generate": {"one_library_per": "api"}}}`)
+
+	got, err := Init(t.Context(), "ruby", "/cache", &config.Config{Language: "ruby"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &config.Default{
+		Output:   "{name}/",
+		Generate: &config.DefaultGenerate{OneLibraryPer: "api"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestExternalRelease(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Chdir(t.TempDir())
+	writeBackend(t, dir, "ruby", `{"versions": {"google-cloud-secretmanager": "2.1.0"}}`)
+
+	cfg := &config.Config{Language: "ruby", Versions: map[string]string{"google-cloud-storage": "1.0.0"}}
+	if err := Release(t.Context(), cfg, "librarian.yaml"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := config.Read("librarian.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"google-cloud-secretmanager": "2.1.0",
+		"google-cloud-storage":       "1.0.0",
+	}
+	if diff := cmp.Diff(want, got.Versions); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestExternalError(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	writeBackend(t, dir, "ruby", `{"error": "protoc-gen-ruby_cloud not found"}`)
+
+	err := Clean(t.Context(), "api", "ruby", "", "", &config.Library{Name: "google-cloud-secretmanager"}, nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if want := "protoc-gen-ruby_cloud not found"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error %q does not contain %q", err, want)
+	}
+}
+
+func TestCleanChannels(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("external backend test uses a shell script")
+	}
+	dir := t.TempDir()
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	script := `#!/bin/sh
+cat >> "` + dir + `/requests.json"
+echo "{}"
+`
+	if err := os.WriteFile(filepath.Join(dir, "librarian-ruby"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	library := &config.Library{
+		Name:     "google-cloud-secretmanager",
+		Channels: []string{"google/cloud/secretmanager/v1", "google/cloud/secretmanager/v1beta2"},
+	}
+	if err := Clean(t.Context(), "channel", "ruby", "", t.TempDir(), library, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(filepath.Join(dir, "requests.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var got []string
+	for dec := json.NewDecoder(f); dec.More(); {
+		var req struct {
+			Library struct {
+				Channel string `json:"channel"`
+			} `json:"library"`
+		}
+		if err := dec.Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, req.Library.Channel)
+	}
+	if diff := cmp.Diff(library.Channels, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCleanChannelsWithoutAPIs(t *testing.T) {
+	if err := Clean(t.Context(), "channel", "rust", "", t.TempDir(), &config.Library{Name: "google-cloud-type"}, nil); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestBuiltinCleanUsesRequestSettings(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		lang Language
+	}{
+		{"go", goLanguage{}},
+		{"java", javaLanguage{}},
+		{"nodejs", nodejsLanguage{}},
+		{"python", pythonLanguage{}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			repoDir := t.TempDir()
+			for _, name := range []string{"resolved/generated.go", "resolved/keep.go", "default/generated.go"} {
+				path := filepath.Join(repoDir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, nil, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			library := &config.Library{Name: "secretmanager", Keep: []string{"keep.go"}}
+			req := NewRequest("", repoDir, library, &config.Default{Output: "default/"}, "", "")
+			req.Settings.Output = "resolved/"
+			if err := test.lang.Clean(t.Context(), req); err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			err := filepath.WalkDir(repoDir, func(path string, d os.DirEntry, err error) error {
+				if err != nil || d.IsDir() {
+					return err
+				}
+				rel, err := filepath.Rel(repoDir, path)
+				got = append(got, filepath.ToSlash(rel))
+				return err
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := []string{"default/generated.go", "resolved/keep.go"}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDoctor(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("PATH", dir)
+
+	results, err := Doctor(t.Context(), "rust", &config.Config{Language: "rust"}, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, result := range results {
+		got = append(got, result.Tool.Name)
+		if result.Err == nil {
+			t.Errorf("%s: expected an error with an empty PATH", result.Tool.Name)
+		}
+	}
+	want := []string{"cargo", "taplo", "typos", "git"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPreflight(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	err := Preflight(t.Context(), &config.Config{Language: "go"}, t.TempDir(), OperationGenerate)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{"protoc not found", "protoc-gen-go_gapic not found", "librarian doctor"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+
+	// Go releases do not invoke any tools
+	if err := Preflight(t.Context(), &config.Config{Language: "go"}, t.TempDir(), OperationRelease); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestBuiltinCleanChannelUsesRepoDir(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		lang   Language
+		output string
+		kept   string
+	}{
+		{"dart", dartLanguage{}, "generated/google_cloud_secretmanager_v1", "CHANGELOG.md"},
+		{"rust", rustLanguage{}, "generated/cloud/secretmanager/v1", "Cargo.toml"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			repoDir := t.TempDir()
+			for _, name := range []string{"lib.src", test.kept} {
+				path := filepath.Join(repoDir, test.output, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, nil, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			library := &config.Library{Name: "secretmanager", Channel: "google/cloud/secretmanager/v1"}
+			req := NewRequest("", repoDir, library, &config.Default{Output: "generated/"}, "", "")
+			if err := test.lang.Clean(t.Context(), req); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := os.Stat(filepath.Join(repoDir, test.output, "lib.src")); !os.IsNotExist(err) {
+				t.Errorf("lib.src should have been deleted, got %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(repoDir, test.output, test.kept)); err != nil {
+				t.Errorf("%s should have been kept: %v", test.kept, err)
+			}
+		})
+	}
+}
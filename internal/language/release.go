@@ -16,18 +16,26 @@ package language
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/julieqiu/librarianx/internal/config"
-	"github.com/julieqiu/librarianx/internal/language/internal/rust"
 )
 
-// Release bumps versions for the given language.
+// Release bumps the versions of all libraries in the repository and updates
+// librarian.yaml.
 func Release(ctx context.Context, cfg *config.Config, configPath string) error {
-	switch cfg.Language {
-	case "rust":
-		return rust.BumpVersions(ctx, cfg, configPath)
-	default:
-		return fmt.Errorf("release not supported for language: %s", cfg.Language)
+	lang, err := Lookup(cfg.Language)
+	if err != nil {
+		return err
 	}
+	return lang.Release(ctx, cfg, configPath)
+}
+
+// Publish uploads released libraries to the package registry of the
+// repository's language.
+func Publish(ctx context.Context, cfg *config.Config, dryRun bool) error {
+	lang, err := Lookup(cfg.Language)
+	if err != nil {
+		return err
+	}
+	return lang.Publish(ctx, cfg, dryRun)
 }
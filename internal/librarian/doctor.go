@@ -0,0 +1,126 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language"
+	"github.com/urfave/cli/v3"
+)
+
+// doctorCommand checks the tools the language backend invokes.
+func doctorCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "doctor",
+		Usage:     "check that the tools needed by the language backend are installed",
+		UsageText: "librarian doctor [--install] [language]",
+		Description: `Check every external tool the language backend invokes during generate
+and release, including minimum versions. Tools pinned in the tools section
+of librarian.yaml must match the pinned version exactly.
+
+The language defaults to the language in librarian.yaml. The tool versions
+pinned in librarian.yaml apply whenever the file exists, also if a language
+is given.
+
+With --install, the pinned tool versions are first installed into the
+librarian cache, where generate and release find them.
+
+Example:
+  librarian doctor
+  librarian doctor python
+  librarian doctor --install`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "install",
+				Usage: "install the tool versions pinned in librarian.yaml into the librarian cache",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return runDoctor(ctx, os.Stdout, cmd.Args().Get(0), cmd.Bool("install"))
+		},
+	}
+}
+
+func runDoctor(ctx context.Context, w io.Writer, lang string, install bool) error {
+	cfg, err := doctorConfig(configPath, lang, install)
+	if err != nil {
+		return err
+	}
+
+	cache, err := cacheDir()
+	if err != nil {
+		return err
+	}
+	if install {
+		if err := language.InstallTools(ctx, cfg, cache); err != nil {
+			return err
+		}
+	}
+	results, err := language.Doctor(ctx, cfg.Language, cfg, cache)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Checking tools for %s...\n", cfg.Language)
+	var problems int
+	for _, result := range results {
+		if result.Err != nil {
+			problems++
+			fmt.Fprintf(w, "  ✗ %v\n", result.Err)
+			continue
+		}
+		version := result.Version
+		if version == "" {
+			version = "installed"
+		}
+		fmt.Fprintf(w, "  ✓ %s %s (%s)\n", result.Tool.Name, version, result.Path)
+	}
+	if problems > 0 {
+		return fmt.Errorf("found %d problem(s)", problems)
+	}
+	fmt.Fprintln(w, "All tools are installed.")
+	return nil
+}
+
+// doctorConfig returns the configuration the tools of lang are checked
+// against. The configuration at path is read whenever it exists, so the
+// tool versions pinned in it apply even if lang is given; lang overrides its
+// language. Without a configuration file, lang is required and --install is
+// not possible.
+func doctorConfig(path, lang string, install bool) (*config.Config, error) {
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		if install {
+			return nil, fmt.Errorf("--install requires %s, which pins the tool versions", path)
+		}
+		if lang == "" {
+			return nil, fmt.Errorf("%s not found, pass the language to check", path)
+		}
+		return &config.Config{Language: lang}, nil
+	}
+	cfg, err := config.Read(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if lang != "" {
+		cfg.Language = lang
+	}
+	return cfg, nil
+}
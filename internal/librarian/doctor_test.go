@@ -0,0 +1,89 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/config"
+)
+
+func TestDoctorConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "librarian.yaml")
+	content := "language: python\ntools:\n  protoc: \"25.1\"\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		name    string
+		lang    string
+		install bool
+		want    *config.Config
+	}{
+		{
+			name: "language from config",
+			want: &config.Config{Language: "python", Tools: &config.Tools{Protoc: "25.1"}},
+		},
+		{
+			name: "language argument keeps pinned tools",
+			lang: "go",
+			want: &config.Config{Language: "go", Tools: &config.Tools{Protoc: "25.1"}},
+		},
+		{
+			name:    "install",
+			lang:    "python",
+			install: true,
+			want:    &config.Config{Language: "python", Tools: &config.Tools{Protoc: "25.1"}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := doctorConfig(path, test.lang, test.install)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDoctorConfigWithoutFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "librarian.yaml")
+	got, err := doctorConfig(path, "go", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&config.Config{Language: "go"}, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	for _, test := range []struct {
+		name    string
+		lang    string
+		install bool
+	}{
+		{name: "no language"},
+		{name: "install", lang: "go", install: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := doctorConfig(path, test.lang, test.install); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -27,7 +27,7 @@ func generateCommand() *cli.Command {
 	return &cli.Command{
 		Name:      "generate",
 		Usage:     "generate a client library",
-		UsageText: "librarian generate [--all] [library-name]",
+		UsageText: "librarian generate [--all] [--clean] [library-name]",
 		Description: `Generate a client library from googleapis.
 
 For Python (api-level bundling):
@@ -37,7 +37,10 @@ For Rust (channel-level libraries):
   librarian generate google-cloud-secretmanager-v1
 
 Generate all APIs:
-  librarian generate --all`,
+  librarian generate --all
+
+Delete the generated files of a library, preserving kept files:
+  librarian generate --clean google-cloud-secretmanager`,
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
 				Name:  "all",
@@ -47,6 +50,10 @@ Generate all APIs:
 				Name:  "post-process-only",
 				Usage: "run only the post-processor (synthtool) without regenerating code",
 			},
+			&cli.BoolFlag{
+				Name:  "clean",
+				Usage: "delete the generated files of the library, preserving kept files, without regenerating code",
+			},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			if cmd.Bool("all") {
@@ -57,17 +64,32 @@ Generate all APIs:
 			}
 			name := cmd.Args().Get(0)
 			postProcessOnly := cmd.Bool("post-process-only")
-			return runGenerate(ctx, name, false, postProcessOnly)
+			cleanOnly := cmd.Bool("clean")
+			if postProcessOnly && cleanOnly {
+				return fmt.Errorf("--post-process-only and --clean cannot be used together")
+			}
+			return runGenerate(ctx, name, false, postProcessOnly, cleanOnly)
 		},
 	}
 }
 
-func runGenerate(ctx context.Context, name string, newLibrary, postProcessOnly bool) error {
+func runGenerate(ctx context.Context, name string, newLibrary, postProcessOnly, cleanOnly bool) error {
 	cfg, err := config.Read(configPath)
 	if err != nil {
 		return err
 	}
 
+	// Cleaning only deletes files, so it does not need the generator tools.
+	if !cleanOnly {
+		cache, err := cacheDir()
+		if err != nil {
+			return err
+		}
+		if err := language.Preflight(ctx, cfg, cache, language.OperationGenerate); err != nil {
+			return err
+		}
+	}
+
 	if cfg.Sources == nil || cfg.Sources.Googleapis == nil {
 		return fmt.Errorf("no googleapis source configured in %s", configPath)
 	}
@@ -93,20 +115,34 @@ func runGenerate(ctx context.Context, name string, newLibrary, postProcessOnly b
 		return fmt.Errorf("one_library_per must be set in librarian.yaml under default.generate.one_library_per")
 	}
 
-	return generateLibrary(ctx, cfg, googleapisDir, library, cfg.Default.Generate.OneLibraryPer, newLibrary, postProcessOnly)
+	return generateLibrary(ctx, cfg, googleapisDir, library, cfg.Default.Generate.OneLibraryPer, newLibrary, postProcessOnly, cleanOnly)
 }
 
 // generateLibrary prepares and generates a library.
-func generateLibrary(ctx context.Context, cfg *config.Config, googleapisDir string, library *config.Library, oneLibraryPer string, newLibrary, postProcessOnly bool) error {
+func generateLibrary(ctx context.Context, cfg *config.Config, googleapisDir string, library *config.Library, oneLibraryPer string, newLibrary, postProcessOnly, cleanOnly bool) error {
 	// Check if generation is disabled
 	if library.Generate != nil && library.Generate.Disabled {
 		fmt.Printf("  ⊘ %s (generation disabled)\n", library.Name)
 		return nil
 	}
 
+	root, err := repoDir()
+	if err != nil {
+		return err
+	}
+
+	// If clean, delete the generated files instead of regenerating them
+	if cleanOnly {
+		if err := language.Clean(ctx, oneLibraryPer, cfg.Language, cfg.Repo, root, library, cfg.Default); err != nil {
+			return err
+		}
+		fmt.Printf("  ✓ %s (cleaned)\n", library.Name)
+		return nil
+	}
+
 	// If post-process-only, skip code generation
 	if postProcessOnly {
-		if err := language.PostProcess(ctx, cfg.Language, cfg.Repo, library, cfg.Default); err != nil {
+		if err := language.PostProcess(ctx, cfg.Language, cfg.Repo, root, library, cfg.Default); err != nil {
 			return err
 		}
 		fmt.Printf("  ✓ %s (post-processed)\n", library.Name)
@@ -115,11 +151,11 @@ func generateLibrary(ctx context.Context, cfg *config.Config, googleapisDir stri
 
 	// Generate the library (cleanup happens inside language-specific Generate functions)
 	if newLibrary {
-		if err := language.Create(ctx, cfg.Language, cfg.Repo, library, cfg.Default, googleapisDir, "", cfg.Default.Output); err != nil {
+		if err := language.Create(ctx, cfg.Language, cfg.Repo, root, library, cfg.Default, googleapisDir, ""); err != nil {
 			return err
 		}
 	} else {
-		if err := language.Generate(ctx, oneLibraryPer, cfg.Language, cfg.Repo, library, cfg.Default, googleapisDir); err != nil {
+		if err := language.Generate(ctx, oneLibraryPer, cfg.Language, cfg.Repo, root, library, cfg.Default, googleapisDir); err != nil {
 			return err
 		}
 	}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/julieqiu/librarianx/internal/config"
+	"github.com/julieqiu/librarianx/internal/language"
 )
 
 // runGenerateAll generates all APIs found in the googleapis repository.
@@ -28,6 +29,14 @@ func runGenerateAll(ctx context.Context) error {
 		return err
 	}
 
+	cache, err := cacheDir()
+	if err != nil {
+		return err
+	}
+	if err := language.Preflight(ctx, cfg, cache, language.OperationGenerate); err != nil {
+		return err
+	}
+
 	if cfg.Sources == nil || cfg.Sources.Googleapis == nil {
 		return fmt.Errorf("no googleapis source configured in %s", configPath)
 	}
@@ -65,7 +74,7 @@ func runGenerateAll(ctx context.Context) error {
 			return err
 		}
 
-		if err := generateLibrary(ctx, cfg, googleapisDir, preparedLib, oneLibraryPer, false, false); err != nil {
+		if err := generateLibrary(ctx, cfg, googleapisDir, preparedLib, oneLibraryPer, false, false, false); err != nil {
 			fmt.Printf("  ✗ %s: %v\n", lib.Name, err)
 			return err
 		}
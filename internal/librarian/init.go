@@ -35,7 +35,8 @@ func initCommand() *cli.Command {
 		UsageText: "librarian init <language> [--all]",
 		Description: `Initialize librarian in current directory.
 Creates librarian.yaml with default settings for the specified language.
-Supported languages: go, python, rust
+Supported languages: dart, go, java, nodejs, python, rust, and any language
+with a librarian-<language> backend on the PATH (see doc/language-protocol.md).
 
 Example:
   librarian init go
@@ -92,6 +93,12 @@ func runInit(ctx context.Context, lang, version string) (err error) {
 	return nil
 }
 
+// repoDir returns the root directory of the repository, which is the
+// directory containing librarian.yaml.
+func repoDir() (string, error) {
+	return filepath.Abs(filepath.Dir(configPath))
+}
+
 func cacheDir() (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
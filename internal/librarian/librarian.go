@@ -43,6 +43,8 @@ func Run(ctx context.Context, args []string) error {
 			generateCommand(),
 			releaseCommand(),
 			fmtCommand(),
+			doctorCommand(),
+			overridesCommand(),
 			versionCommand(),
 		},
 	}
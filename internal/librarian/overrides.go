@@ -0,0 +1,193 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/julieqiu/librarianx/internal/config"
+	sidekickapi "github.com/julieqiu/librarianx/internal/sidekick/api"
+	sidekickconfig "github.com/julieqiu/librarianx/internal/sidekick/config"
+	"github.com/julieqiu/librarianx/internal/sidekick/parser"
+	"github.com/urfave/cli/v3"
+)
+
+// overridesCommand groups the commands for documentation and pagination
+// overrides.
+func overridesCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "overrides",
+		Usage:     "manage documentation and pagination overrides",
+		UsageText: "librarian overrides [command]",
+		Commands: []*cli.Command{
+			{
+				Name:      "check",
+				Usage:     "list overrides that no longer apply",
+				UsageText: "librarian overrides check",
+				Description: `Check the documentation and pagination overrides of all libraries against
+the googleapis commit in librarian.yaml. This includes the global
+documentation overrides embedded in librarian.
+
+An override is dead when its element no longer exists, its match text is not
+found, or no library generates its API. Dead overrides usually mean the
+problem was fixed upstream, and the override should be removed.
+
+Example:
+  librarian overrides check`,
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return runOverridesCheck(ctx, os.Stdout)
+				},
+			},
+		},
+	}
+}
+
+func runOverridesCheck(ctx context.Context, w io.Writer) error {
+	cfg, err := config.Read(configPath)
+	if err != nil {
+		return err
+	}
+	if cfg.Sources == nil || cfg.Sources.Googleapis == nil || cfg.Sources.Googleapis.Commit == "" {
+		return fmt.Errorf("no googleapis commit configured in %s", configPath)
+	}
+	if cfg.Default == nil || cfg.Default.Generate == nil || cfg.Default.Generate.OneLibraryPer == "" {
+		return fmt.Errorf("one_library_per must be set in librarian.yaml under default.generate.one_library_per")
+	}
+	googleapisDir, err := googleapisDir(cfg.Sources.Googleapis.Commit)
+	if err != nil {
+		return err
+	}
+	discovered, err := config.DiscoverLibraries(googleapisDir, cfg.Language, cfg.Default.Generate.OneLibraryPer)
+	if err != nil {
+		return fmt.Errorf("failed to discover libraries: %w", err)
+	}
+	var libraries []*config.Library
+	for _, lib := range discovered {
+		library, err := config.FindLibraryByName(cfg, lib.Name, googleapisDir)
+		if err != nil {
+			return err
+		}
+		libraries = append(libraries, library)
+	}
+	global, err := config.ReadDocumentationOverrides()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Checking overrides for %d libraries...\n", len(libraries))
+	dead := checkOverrides(libraries, global, googleapisDir)
+	for _, err := range dead {
+		fmt.Fprintf(w, "  ✗ %v\n", err)
+	}
+	if len(dead) > 0 {
+		return fmt.Errorf("found %d dead override(s)", len(dead))
+	}
+	fmt.Fprintln(w, "All overrides apply.")
+	return nil
+}
+
+// checkOverrides returns an error for each documentation or pagination
+// override that does not apply to the libraries.
+//
+// The overrides limited to some languages are checked too, as they are dead
+// in those languages. Only the APIs with overrides are parsed.
+func checkOverrides(libraries []*config.Library, global []config.DocumentationOverride, googleapisDir string) []error {
+	var errs []error
+	usedGlobal := make([]bool, len(global))
+	for _, library := range libraries {
+		documentation := library.DocumentationOverrides
+		pagination := library.PaginationOverrides
+		if library.Rust != nil {
+			for _, o := range library.Rust.DocumentationOverrides {
+				documentation = append(documentation, config.DocumentationOverride{ID: o.ID, Match: o.Match, Replace: o.Replace})
+			}
+			for _, o := range library.Rust.PaginationOverrides {
+				pagination = append(pagination, config.PaginationOverride{ID: o.ID, ItemField: o.ItemField})
+			}
+		}
+		usedDocumentation := make([]bool, len(documentation))
+		usedPagination := make([]bool, len(pagination))
+		for _, channel := range slices.Sorted(maps.Keys(library.APIServiceConfigs)) {
+			prefix := config.APIElementPrefix(channel)
+			var docOverrides []sidekickconfig.DocumentationOverride
+			for i, o := range global {
+				if strings.HasPrefix(o.ID, prefix) {
+					usedGlobal[i] = true
+					docOverrides = append(docOverrides, sidekickconfig.DocumentationOverride{ID: o.ID, Match: o.Match, Replace: o.Replace})
+				}
+			}
+			for i, o := range documentation {
+				if strings.HasPrefix(o.ID, prefix) {
+					usedDocumentation[i] = true
+					docOverrides = append(docOverrides, sidekickconfig.DocumentationOverride{ID: o.ID, Match: o.Match, Replace: o.Replace})
+				}
+			}
+			var pageOverrides []sidekickconfig.PaginationOverride
+			for i, o := range pagination {
+				if strings.HasPrefix(o.ID, prefix) {
+					usedPagination[i] = true
+					pageOverrides = append(pageOverrides, sidekickconfig.PaginationOverride{ID: o.ID, ItemField: o.ItemField})
+				}
+			}
+			if len(docOverrides) == 0 && len(pageOverrides) == 0 {
+				continue
+			}
+			for _, err := range checkChannelOverrides(channel, library.APIServiceConfigs[channel], googleapisDir, docOverrides, pageOverrides) {
+				errs = append(errs, fmt.Errorf("%s: %w", library.Name, err))
+			}
+		}
+		for i, o := range documentation {
+			if !usedDocumentation[i] {
+				errs = append(errs, fmt.Errorf("%s: documentation override for %s is not in any API of the library", library.Name, o.ID))
+			}
+		}
+		for i, o := range pagination {
+			if !usedPagination[i] {
+				errs = append(errs, fmt.Errorf("%s: pagination override for %s is not in any API of the library", library.Name, o.ID))
+			}
+		}
+	}
+	for i, o := range global {
+		if !usedGlobal[i] {
+			errs = append(errs, fmt.Errorf("global documentation override for %s is not in any library", o.ID))
+		}
+	}
+	return errs
+}
+
+func checkChannelOverrides(channel, serviceConfig, googleapisDir string, documentation []sidekickconfig.DocumentationOverride, pagination []sidekickconfig.PaginationOverride) []error {
+	// Parse the model without overrides, as parsing fails on dead overrides.
+	model, err := parser.CreateModel(&sidekickconfig.Config{
+		General: sidekickconfig.GeneralConfig{
+			SpecificationFormat: "protobuf",
+			SpecificationSource: channel,
+			ServiceConfig:       serviceConfig,
+		},
+		Source: map[string]string{
+			"googleapis-root": googleapisDir,
+		},
+	})
+	if err != nil {
+		return []error{fmt.Errorf("cannot parse %s to check its overrides: %w", channel, err)}
+	}
+	errs := sidekickapi.CheckDocumentationOverrides(model, documentation)
+	return append(errs, parser.CheckPaginationOverrides(model, pagination)...)
+}
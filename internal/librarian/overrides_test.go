@@ -0,0 +1,76 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/config"
+)
+
+func TestCheckOverrides(t *testing.T) {
+	googleapisDir, err := filepath.Abs("../sidekick/testdata/googleapis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	channel := "google/cloud/secretmanager/v1"
+	library := &config.Library{
+		Name: "google-cloud-secretmanager-v1",
+		APIServiceConfigs: map[string]string{
+			channel: filepath.Join(googleapisDir, channel, "secretmanager_v1.yaml"),
+		},
+		DocumentationOverrides: []config.DocumentationOverride{
+			{ID: ".google.cloud.secretmanager.v1.Secret", Match: "A [Secret]", Replace: "A secret"},
+			{ID: ".google.cloud.secretmanager.v1.Replication", Match: "not in the comment", Replace: "unused", Languages: []string{"go"}},
+		},
+		PaginationOverrides: []config.PaginationOverride{
+			{ID: ".google.cloud.secretmanager.v1.SecretManagerService.ListSecretVersions", ItemField: "versions"},
+		},
+		Rust: &config.RustCrate{
+			DocumentationOverrides: []config.RustDocumentationOverride{
+				{ID: ".google.cloud.secretmanager.v1.Secret.name", Match: "The resource name", Replace: "The name"},
+				{ID: ".google.cloud.secretmanager.v1.Secret.name", Match: "not in the comment", Replace: "unused"},
+				{ID: ".google.cloud.secretmanager.v1.Secret.removed", Match: "text", Replace: "unused"},
+				{ID: ".google.cloud.other.v1.Secret", Match: "text", Replace: "unused"},
+			},
+			PaginationOverrides: []config.RustPaginationOverride{
+				{ID: ".google.cloud.secretmanager.v1.SecretManagerService.ListSecrets", ItemField: "secrets"},
+				{ID: ".google.cloud.secretmanager.v1.SecretManagerService.GetSecret", ItemField: "secrets"},
+			},
+		},
+	}
+	global := []config.DocumentationOverride{
+		{ID: ".google.cloud.secretmanager.v1.Secret", Match: "not in the comment", Replace: "unused"},
+		{ID: ".google.cloud.unknown.v1.Secret", Match: "text", Replace: "unused"},
+	}
+	var got []string
+	for _, err := range checkOverrides([]*config.Library{library}, global, googleapisDir) {
+		got = append(got, err.Error())
+	}
+	want := []string{
+		"google-cloud-secretmanager-v1: comment override for .google.cloud.secretmanager.v1.Secret did not match \"not in the comment\"",
+		"google-cloud-secretmanager-v1: comment override for .google.cloud.secretmanager.v1.Replication did not match \"not in the comment\"",
+		"google-cloud-secretmanager-v1: comment override for .google.cloud.secretmanager.v1.Secret.name did not match \"not in the comment\"",
+		"google-cloud-secretmanager-v1: cannot find element .google.cloud.secretmanager.v1.Secret.removed to apply comment overrides, no field removed in message .google.cloud.secretmanager.v1.Secret",
+		"google-cloud-secretmanager-v1: method .google.cloud.secretmanager.v1.SecretManagerService.GetSecret is not paginated, cannot apply pagination override",
+		"google-cloud-secretmanager-v1: documentation override for .google.cloud.other.v1.Secret is not in any API of the library",
+		"global documentation override for .google.cloud.unknown.v1.Secret is not in any library",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+}
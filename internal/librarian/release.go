@@ -16,6 +16,7 @@ package librarian
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -29,39 +30,54 @@ func releaseCommand() *cli.Command {
 	return &cli.Command{
 		Name:      "release",
 		Usage:     "bump versions for release",
-		UsageText: "librarian release [--execute]",
-		Description: `Bump versions for all Cargo.toml files and update librarian.yaml.
-
-By default, this is a dry run that only updates Cargo.toml and librarian.yaml files.
-Use --execute to also create and push git tags.`,
+		UsageText: "librarian release [--execute [--publish]]",
+		Description: `Bump versions for all package manifests (Cargo.toml for Rust,
+pubspec.yaml for Dart) and update librarian.yaml.
+
+By default, this is a dry run that only updates the manifests and librarian.yaml files.
+Use --execute to also create and push git tags, and --publish together with
+--execute to then publish the released libraries to the package registry of
+the repository's language.`,
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
 				Name:  "execute",
 				Usage: "create and push git tags (default is dry run)",
 			},
+			&cli.BoolFlag{
+				Name:  "publish",
+				Usage: "publish the released libraries after tagging (requires --execute)",
+			},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
-			return runRelease(ctx, cmd.Bool("execute"))
+			return runRelease(ctx, cmd.Bool("execute"), cmd.Bool("publish"))
 		},
 	}
 }
 
-func runRelease(ctx context.Context, execute bool) error {
+func runRelease(ctx context.Context, execute, publish bool) error {
+	if publish && !execute {
+		return fmt.Errorf("--publish requires --execute")
+	}
+
 	cfg, err := config.Read(configPath)
 	if err != nil {
 		return err
 	}
 
-	if cfg.Language != "rust" {
-		return fmt.Errorf("release command only supports rust language, got: %s", cfg.Language)
+	cache, err := cacheDir()
+	if err != nil {
+		return err
+	}
+	if err := language.Preflight(ctx, cfg, cache, language.OperationRelease); err != nil {
+		return err
 	}
 
-	// Always bump versions (updates Cargo.toml and librarian.yaml)
+	// Always bump versions (updates package manifests and librarian.yaml)
 	fmt.Println("Bumping versions...")
 	if err := language.Release(ctx, cfg, configPath); err != nil {
 		return err
 	}
-	fmt.Println("✓ Updated Cargo.toml files and librarian.yaml")
+	fmt.Println("✓ Updated package manifests and librarian.yaml")
 
 	if !execute {
 		fmt.Println("\nDry run complete. Run with --execute to create and push tags.")
@@ -74,10 +90,29 @@ func runRelease(ctx context.Context, execute bool) error {
 		return err
 	}
 
+	if publish {
+		fmt.Println("\nPublishing...")
+		if err := publishLibraries(ctx, cfg); err != nil {
+			return err
+		}
+	}
+
 	fmt.Println("✓ Release complete!")
 	return nil
 }
 
+// publishLibraries uploads the released libraries to the package registry.
+// Languages without a publish step, such as Go whose modules are indexed
+// from their tags, are skipped.
+func publishLibraries(ctx context.Context, cfg *config.Config) error {
+	err := language.Publish(ctx, cfg, false)
+	if errors.Is(err, language.ErrNotSupported) {
+		fmt.Printf("  ⊘ publishing is not supported for %s, skipping\n", cfg.Language)
+		return nil
+	}
+	return err
+}
+
 func createAndPushTags(cfg *config.Config) error {
 	if cfg.Default == nil || cfg.Default.Release == nil {
 		return fmt.Errorf("default.release configuration is required")
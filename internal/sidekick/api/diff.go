@@ -0,0 +1,336 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ChangeKind describes how an element changed between two versions of an API.
+type ChangeKind string
+
+const (
+	// Added means the element only exists in the new version.
+	Added ChangeKind = "added"
+	// Removed means the element only exists in the old version.
+	Removed ChangeKind = "removed"
+	// Changed means the element exists in both versions, with differences.
+	Changed ChangeKind = "changed"
+)
+
+// Change is a difference in the public surface of an API.
+type Change struct {
+	// Kind is how the element changed.
+	Kind ChangeKind `json:"kind"`
+	// Element is the type of element, e.g. `service`, `method`, or `field`.
+	Element string `json:"element"`
+	// ID is the fully qualified ID of the element.
+	ID string `json:"id"`
+	// Breaking is true if code using the old version may not work with the
+	// new version.
+	Breaking bool `json:"breaking"`
+	// Description explains the change, it is empty for added and removed
+	// elements.
+	Description string `json:"description,omitempty"`
+}
+
+// Diff compares two versions of an API and returns the changes in its public
+// surface.
+//
+// Removing an element, or changing the type, cardinality, oneof membership,
+// HTTP bindings, long-running operation, or pagination information of an
+// element are breaking changes. Adding elements is not.
+//
+// The changes are sorted by ID.
+func Diff(before, after *API) []*Change {
+	d := &differ{before: before.State, after: after.State}
+	d.services(before.Services, after.Services)
+	d.messages(allMessages(before.Messages), allMessages(after.Messages))
+	d.enums(allEnums(before), allEnums(after))
+	slices.SortStableFunc(d.changes, func(a, b *Change) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return d.changes
+}
+
+type differ struct {
+	before  *APIState
+	after   *APIState
+	changes []*Change
+}
+
+func (d *differ) add(kind ChangeKind, element, id string, breaking bool, description string) {
+	d.changes = append(d.changes, &Change{
+		Kind:        kind,
+		Element:     element,
+		ID:          id,
+		Breaking:    breaking,
+		Description: description,
+	})
+}
+
+func (d *differ) changed(element, id, description string) {
+	d.add(Changed, element, id, true, description)
+}
+
+// compareByID calls `onRemoved`, `onAdded`, or `onBoth` for each element of
+// `before` and `after`, matching the elements by ID.
+func compareByID[T any](before, after []T, id func(T) string, onRemoved, onAdded func(T), onBoth func(T, T)) {
+	byID := map[string]T{}
+	for _, o := range before {
+		byID[id(o)] = o
+	}
+	found := map[string]bool{}
+	for _, n := range after {
+		o, ok := byID[id(n)]
+		if !ok {
+			onAdded(n)
+			continue
+		}
+		found[id(n)] = true
+		onBoth(o, n)
+	}
+	for _, o := range before {
+		if !found[id(o)] {
+			onRemoved(o)
+		}
+	}
+}
+
+func (d *differ) services(before, after []*Service) {
+	compareByID(before, after, func(s *Service) string { return s.ID },
+		func(s *Service) { d.add(Removed, "service", s.ID, true, "") },
+		func(s *Service) { d.add(Added, "service", s.ID, false, "") },
+		func(o, n *Service) { d.methods(o.Methods, n.Methods) })
+}
+
+func (d *differ) methods(before, after []*Method) {
+	compareByID(before, after, func(m *Method) string { return m.ID },
+		func(m *Method) { d.add(Removed, "method", m.ID, true, "") },
+		func(m *Method) { d.add(Added, "method", m.ID, false, "") },
+		d.method)
+}
+
+func (d *differ) method(before, after *Method) {
+	if before.InputTypeID != after.InputTypeID {
+		d.changed("method", after.ID, fmt.Sprintf("request type changed from %s to %s", before.InputTypeID, after.InputTypeID))
+	}
+	if before.OutputTypeID != after.OutputTypeID || before.ReturnsEmpty != after.ReturnsEmpty {
+		d.changed("method", after.ID, fmt.Sprintf("response type changed from %s to %s", before.OutputTypeID, after.OutputTypeID))
+	}
+	if before.ClientSideStreaming != after.ClientSideStreaming || before.ServerSideStreaming != after.ServerSideStreaming {
+		d.changed("method", after.ID, fmt.Sprintf("streaming changed from %s to %s", streaming(before), streaming(after)))
+	}
+	if o, n := bindings(before), bindings(after); o != n {
+		d.changed("method", after.ID, fmt.Sprintf("HTTP bindings changed from [%s] to [%s]", o, n))
+	}
+	if o, n := operationInfo(before), operationInfo(after); o != n {
+		d.changed("method", after.ID, fmt.Sprintf("long-running operation changed from %s to %s", o, n))
+	}
+	if o, n := pagination(before), pagination(after); o != n {
+		d.changed("method", after.ID, fmt.Sprintf("pagination changed from %s to %s", o, n))
+	}
+}
+
+func streaming(m *Method) string {
+	switch {
+	case m.ClientSideStreaming && m.ServerSideStreaming:
+		return "bidirectional"
+	case m.ClientSideStreaming:
+		return "client-side"
+	case m.ServerSideStreaming:
+		return "server-side"
+	default:
+		return "none"
+	}
+}
+
+func bindings(m *Method) string {
+	if m.PathInfo == nil {
+		return ""
+	}
+	var result []string
+	for _, b := range m.PathInfo.Bindings {
+		path := b.PathTemplate.FlatPath()
+		if b.PathTemplate.Verb != nil {
+			path += ":" + *b.PathTemplate.Verb
+		}
+		result = append(result, fmt.Sprintf("%s /%s", b.Verb, path))
+	}
+	if m.PathInfo.BodyFieldPath != "" {
+		result = append(result, "body: "+m.PathInfo.BodyFieldPath)
+	}
+	return strings.Join(result, ", ")
+}
+
+func operationInfo(m *Method) string {
+	switch {
+	case m.OperationInfo != nil:
+		return fmt.Sprintf("(response: %s, metadata: %s)", m.OperationInfo.ResponseTypeID, m.OperationInfo.MetadataTypeID)
+	case m.DiscoveryLro != nil:
+		return fmt.Sprintf("(polling parameters: %s)", strings.Join(m.DiscoveryLro.PollingPathParameters, ", "))
+	default:
+		return "none"
+	}
+}
+
+func pagination(m *Method) string {
+	if m.Pagination == nil {
+		return "none"
+	}
+	return m.Pagination.Name
+}
+
+func (d *differ) messages(before, after []*Message) {
+	compareByID(before, after, func(m *Message) string { return m.ID },
+		func(m *Message) { d.add(Removed, "message", m.ID, true, "") },
+		func(m *Message) { d.add(Added, "message", m.ID, false, "") },
+		d.message)
+}
+
+func (d *differ) message(before, after *Message) {
+	oldGroups, newGroups := oneOfGroups(before), oneOfGroups(after)
+	compareByID(before.Fields, after.Fields, func(f *Field) string { return f.ID },
+		func(f *Field) { d.add(Removed, "field", f.ID, true, "") },
+		func(f *Field) { d.add(Added, "field", f.ID, false, "") },
+		func(o, n *Field) {
+			d.field(o, n)
+			if og, ng := oldGroups[o.ID], newGroups[n.ID]; og != ng {
+				d.changed("field", n.ID, fmt.Sprintf("oneof membership changed from %s to %s", og, ng))
+			}
+		})
+}
+
+func (d *differ) field(before, after *Field) {
+	if o, n := fieldType(d.before, before), fieldType(d.after, after); o != n {
+		d.changed("field", after.ID, fmt.Sprintf("type changed from %s to %s", o, n))
+	}
+	if o, n := cardinality(before), cardinality(after); o != n {
+		d.changed("field", after.ID, fmt.Sprintf("cardinality changed from %s to %s", o, n))
+	}
+	if before.JSONName != after.JSONName {
+		d.changed("field", after.ID, fmt.Sprintf("JSON name changed from %s to %s", before.JSONName, after.JSONName))
+	}
+}
+
+// fieldType returns a description of the field type. Map fields are described
+// by their key and value types, as the ID of the synthetic map entry message
+// does not change when they do.
+func fieldType(state *APIState, f *Field) string {
+	if f.Map && state != nil {
+		if entry, ok := state.MessageByID[f.TypezID]; ok && len(entry.Fields) == 2 {
+			return fmt.Sprintf("map<%s, %s>", fieldType(state, entry.Fields[0]), fieldType(state, entry.Fields[1]))
+		}
+	}
+	switch f.Typez {
+	case MESSAGE_TYPE, ENUM_TYPE, GROUP_TYPE:
+		return f.TypezID
+	}
+	if name, ok := scalarNames[f.Typez]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown type %d", f.Typez)
+}
+
+// scalarNames maps the scalar types to their names in the protobuf language.
+// The protobuf parser leaves `TypezID` empty for these types.
+var scalarNames = map[Typez]string{
+	DOUBLE_TYPE:   "double",
+	FLOAT_TYPE:    "float",
+	INT64_TYPE:    "int64",
+	UINT64_TYPE:   "uint64",
+	INT32_TYPE:    "int32",
+	FIXED64_TYPE:  "fixed64",
+	FIXED32_TYPE:  "fixed32",
+	BOOL_TYPE:     "bool",
+	STRING_TYPE:   "string",
+	BYTES_TYPE:    "bytes",
+	UINT32_TYPE:   "uint32",
+	SFIXED32_TYPE: "sfixed32",
+	SFIXED64_TYPE: "sfixed64",
+	SINT32_TYPE:   "sint32",
+	SINT64_TYPE:   "sint64",
+}
+
+func cardinality(f *Field) string {
+	switch {
+	case f.Map:
+		return "map"
+	case f.Repeated:
+		return "repeated"
+	case f.Optional:
+		return "optional"
+	default:
+		return "singular"
+	}
+}
+
+// oneOfGroups returns the name of the oneof containing each field, or `none`.
+func oneOfGroups(m *Message) map[string]string {
+	groups := map[string]string{}
+	for _, f := range m.Fields {
+		groups[f.ID] = "none"
+	}
+	for _, o := range m.OneOfs {
+		for _, f := range o.Fields {
+			groups[f.ID] = o.Name
+		}
+	}
+	return groups
+}
+
+func (d *differ) enums(before, after []*Enum) {
+	compareByID(before, after, func(e *Enum) string { return e.ID },
+		func(e *Enum) { d.add(Removed, "enum", e.ID, true, "") },
+		func(e *Enum) { d.add(Added, "enum", e.ID, false, "") },
+		d.enum)
+}
+
+func (d *differ) enum(before, after *Enum) {
+	compareByID(before.Values, after.Values, func(v *EnumValue) string { return v.ID },
+		func(v *EnumValue) { d.add(Removed, "enum value", v.ID, true, "") },
+		func(v *EnumValue) { d.add(Added, "enum value", v.ID, false, "") },
+		func(o, n *EnumValue) {
+			if o.Number != n.Number {
+				d.changed("enum value", n.ID, fmt.Sprintf("number changed from %d to %d", o.Number, n.Number))
+			}
+		})
+}
+
+// allMessages returns the messages and their nested messages, skipping the
+// synthetic messages used to represent maps.
+func allMessages(messages []*Message) []*Message {
+	var result []*Message
+	for _, m := range messages {
+		if m.IsMap {
+			continue
+		}
+		result = append(result, m)
+		result = append(result, allMessages(m.Messages)...)
+	}
+	return result
+}
+
+// allEnums returns the top-level enums and the enums nested in messages.
+func allEnums(model *API) []*Enum {
+	result := slices.Clone(model.Enums)
+	for _, m := range allMessages(model.Messages) {
+		result = append(result, m.Enums...)
+	}
+	return result
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func diffTestModel() *API {
+	parent := &Field{Name: "parent", ID: ".test.Request.parent", Typez: STRING_TYPE, JSONName: "parent"}
+	pageToken := &Field{Name: "page_token", ID: ".test.Request.page_token", Typez: STRING_TYPE, JSONName: "pageToken"}
+	choiceA := &Field{Name: "a", ID: ".test.Request.a", Typez: STRING_TYPE, JSONName: "a", IsOneOf: true}
+	request := &Message{
+		Name:    "Request",
+		ID:      ".test.Request",
+		Package: "test",
+		Fields:  []*Field{parent, pageToken, choiceA},
+		OneOfs:  []*OneOf{{Name: "choice", ID: ".test.Request.choice", Fields: []*Field{choiceA}}},
+	}
+	response := &Message{Name: "Response", ID: ".test.Response", Package: "test"}
+	state := &Enum{
+		Name:    "State",
+		ID:      ".test.State",
+		Package: "test",
+		Values: []*EnumValue{
+			{Name: "UNSPECIFIED", ID: ".test.State.UNSPECIFIED", Number: 0},
+			{Name: "ACTIVE", ID: ".test.State.ACTIVE", Number: 1},
+		},
+	}
+	list := &Method{
+		Name:         "List",
+		ID:           ".test.Service.List",
+		InputTypeID:  ".test.Request",
+		OutputTypeID: ".test.Response",
+		PathInfo: &PathInfo{
+			Bindings: []*PathBinding{{
+				Verb:         "GET",
+				PathTemplate: NewPathTemplate().WithLiteral("v1").WithVariableNamed("parent"),
+			}},
+		},
+		Pagination: pageToken,
+	}
+	get := &Method{Name: "Get", ID: ".test.Service.Get", InputTypeID: ".test.Request", OutputTypeID: ".test.Response"}
+	service := &Service{Name: "Service", ID: ".test.Service", Package: "test", Methods: []*Method{list, get}}
+	return NewTestAPI([]*Message{request, response}, []*Enum{state}, []*Service{service})
+}
+
+func TestDiffNoChanges(t *testing.T) {
+	if got := Diff(diffTestModel(), diffTestModel()); len(got) != 0 {
+		t.Errorf("expected no changes, got=%v", got)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	before := diffTestModel()
+	after := diffTestModel()
+
+	// Non-breaking changes.
+	after.Services = append(after.Services, &Service{Name: "Other", ID: ".test.Other"})
+	after.Messages = append(after.Messages, &Message{Name: "Extra", ID: ".test.Extra"})
+	after.Enums[0].Values = append(after.Enums[0].Values, &EnumValue{Name: "DELETED", ID: ".test.State.DELETED", Number: 2})
+	request := after.Messages[0]
+	request.Fields = append(request.Fields, &Field{Name: "filter", ID: ".test.Request.filter", Typez: STRING_TYPE, JSONName: "filter"})
+
+	// Breaking changes.
+	after.Services[0].Methods = after.Services[0].Methods[:1] // Remove `Get`
+	list := after.Services[0].Methods[0]
+	list.PathInfo.Bindings[0].Verb = "POST"
+	list.Pagination = nil
+	list.OperationInfo = &OperationInfo{ResponseTypeID: ".test.Response", MetadataTypeID: ".google.protobuf.Empty"}
+	request.Fields[0].Typez = INT32_TYPE
+	request.Fields[1].Repeated = true
+	request.OneOfs = nil
+	after.Enums[0].Values[1].Number = 7
+
+	want := []*Change{
+		{Kind: Added, Element: "message", ID: ".test.Extra"},
+		{Kind: Added, Element: "service", ID: ".test.Other"},
+		{Kind: Changed, Element: "field", ID: ".test.Request.a", Breaking: true, Description: "oneof membership changed from choice to none"},
+		{Kind: Added, Element: "field", ID: ".test.Request.filter"},
+		{Kind: Changed, Element: "field", ID: ".test.Request.page_token", Breaking: true, Description: "cardinality changed from singular to repeated"},
+		{Kind: Changed, Element: "field", ID: ".test.Request.parent", Breaking: true, Description: "type changed from string to int32"},
+		{Kind: Removed, Element: "method", ID: ".test.Service.Get", Breaking: true},
+		{Kind: Changed, Element: "method", ID: ".test.Service.List", Breaking: true, Description: "HTTP bindings changed from [GET /v1/{parent}] to [POST /v1/{parent}]"},
+		{Kind: Changed, Element: "method", ID: ".test.Service.List", Breaking: true, Description: "long-running operation changed from none to (response: .test.Response, metadata: .google.protobuf.Empty)"},
+		{Kind: Changed, Element: "method", ID: ".test.Service.List", Breaking: true, Description: "pagination changed from page_token to none"},
+		{Kind: Changed, Element: "enum value", ID: ".test.State.ACTIVE", Breaking: true, Description: "number changed from 1 to 7"},
+		{Kind: Added, Element: "enum value", ID: ".test.State.DELETED"},
+	}
+	got := Diff(before, after)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestDiffRemovedElements(t *testing.T) {
+	before := diffTestModel()
+	after := NewTestAPI([]*Message{}, []*Enum{}, []*Service{})
+	got := Diff(before, after)
+	want := []*Change{
+		{Kind: Removed, Element: "message", ID: ".test.Request", Breaking: true},
+		{Kind: Removed, Element: "message", ID: ".test.Response", Breaking: true},
+		{Kind: Removed, Element: "service", ID: ".test.Service", Breaking: true},
+		{Kind: Removed, Element: "enum", ID: ".test.State", Breaking: true},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+}
@@ -15,6 +15,7 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -23,100 +24,100 @@ import (
 )
 
 // PatchDocumentation overrides the documentation of the API model with the provided configuration.
+//
+// It returns an error for each override that no longer applies, because the
+// element does not exist or the `Match` text is not found. Otherwise, changes
+// in the service specification silently disable the override.
 func PatchDocumentation(model *API, config *config.Config) error {
+	var errs []error
 	for _, override := range config.CommentOverrides {
-		id := override.ID
-		if msg, ok := model.State.MessageByID[id]; ok {
-			if err := patchElementDocs(&msg.Documentation, &override); err != nil {
-				return err
-			}
-			continue
-		}
-		if enu, ok := model.State.EnumByID[id]; ok {
-			if err := patchElementDocs(&enu.Documentation, &override); err != nil {
-				return err
-			}
-			continue
-		}
-		if svc, ok := model.State.ServiceByID[id]; ok {
-			if err := patchElementDocs(&svc.Documentation, &override); err != nil {
-				return err
-			}
-			continue
+		documentation, err := findDocumentation(model, override.ID)
+		if err == nil {
+			err = patchElementDocs(documentation, &override)
 		}
-		idx := strings.LastIndex(id, ".")
-		if idx == -1 {
-			return fmt.Errorf("cannot find element %s to apply comment overrides", id)
-		}
-		parentId := id[0:idx]
-		childId := id[idx+1:]
-		if msg, ok := model.State.MessageByID[parentId]; ok {
-			if err := patchFieldDocs(msg, childId, &override); err != nil {
-				return err
-			}
-			continue
-		}
-		if enu, ok := model.State.EnumByID[parentId]; ok {
-			if err := patchEnumValueDocs(enu, childId, &override); err != nil {
-				return err
-			}
-			continue
-		}
-		if svc, ok := model.State.ServiceByID[parentId]; ok {
-			if err := patchMethodDocs(svc, childId, &override); err != nil {
-				return err
-			}
-			continue
+		if err != nil {
+			errs = append(errs, err)
 		}
-		return fmt.Errorf("cannot find element %s to apply comment overrides, only searched for messages, enums and services", id)
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
-func patchFieldDocs(msg *Message, fieldName string, override *config.DocumentationOverride) error {
-	for _, field := range msg.Fields {
-		if field.Name != fieldName {
+// CheckDocumentationOverrides returns an error for each override that no
+// longer applies to the model. It does not change the model.
+func CheckDocumentationOverrides(model *API, overrides []config.DocumentationOverride) []error {
+	// Several overrides may apply to the same element, each one sees the
+	// changes made by the previous ones.
+	patched := map[*string]string{}
+	var errs []error
+	for _, override := range overrides {
+		documentation, err := findDocumentation(model, override.ID)
+		if err != nil {
+			errs = append(errs, err)
 			continue
 		}
-		if err := patchElementDocs(&field.Documentation, override); err != nil {
-			return err
+		text, ok := patched[documentation]
+		if !ok {
+			text = *documentation
 		}
-		return nil
-	}
-	return fmt.Errorf("cannot find field %s in message %s to apply comment override", fieldName, msg.ID)
-}
-
-func patchEnumValueDocs(enu *Enum, name string, override *config.DocumentationOverride) error {
-	for _, v := range enu.Values {
-		if v.Name != name {
+		if !strings.Contains(text, override.Match) {
+			errs = append(errs, fmt.Errorf("comment override for %s did not match %q", override.ID, override.Match))
 			continue
 		}
-		if err := patchElementDocs(&v.Documentation, override); err != nil {
-			return err
-		}
-		return nil
+		patched[documentation] = strings.ReplaceAll(text, override.Match, override.Replace)
 	}
-	return fmt.Errorf("cannot find field %s in message %s to apply comment override", name, enu.ID)
+	return errs
 }
 
-func patchMethodDocs(svc *Service, name string, override *config.DocumentationOverride) error {
-	for _, m := range svc.Methods {
-		if m.Name != name {
-			continue
+// findDocumentation returns the documentation field of the element with the
+// given ID.
+func findDocumentation(model *API, id string) (*string, error) {
+	if msg, ok := model.State.MessageByID[id]; ok {
+		return &msg.Documentation, nil
+	}
+	if enu, ok := model.State.EnumByID[id]; ok {
+		return &enu.Documentation, nil
+	}
+	if svc, ok := model.State.ServiceByID[id]; ok {
+		return &svc.Documentation, nil
+	}
+	idx := strings.LastIndex(id, ".")
+	if idx == -1 {
+		return nil, fmt.Errorf("cannot find element %s to apply comment overrides", id)
+	}
+	parentId := id[0:idx]
+	childId := id[idx+1:]
+	if msg, ok := model.State.MessageByID[parentId]; ok {
+		for _, field := range msg.Fields {
+			if field.Name == childId {
+				return &field.Documentation, nil
+			}
+		}
+		return nil, fmt.Errorf("cannot find element %s to apply comment overrides, no field %s in message %s", id, childId, parentId)
+	}
+	if enu, ok := model.State.EnumByID[parentId]; ok {
+		for _, v := range enu.Values {
+			if v.Name == childId {
+				return &v.Documentation, nil
+			}
 		}
-		if err := patchElementDocs(&m.Documentation, override); err != nil {
-			return err
+		return nil, fmt.Errorf("cannot find element %s to apply comment overrides, no value %s in enum %s", id, childId, parentId)
+	}
+	if svc, ok := model.State.ServiceByID[parentId]; ok {
+		for _, m := range svc.Methods {
+			if m.Name == childId {
+				return &m.Documentation, nil
+			}
 		}
-		return nil
+		return nil, fmt.Errorf("cannot find element %s to apply comment overrides, no method %s in service %s", id, childId, parentId)
 	}
-	return fmt.Errorf("cannot find field %s in message %s to apply comment override", name, svc.ID)
+	return nil, fmt.Errorf("cannot find element %s to apply comment overrides, only searched for messages, enums and services", id)
 }
 
 func patchElementDocs(documentation *string, override *config.DocumentationOverride) error {
 	new := strings.ReplaceAll(*documentation, override.Match, override.Replace)
 	if *documentation == new {
 		slog.Error("comment override mismatch", "id", override.ID, "want", override.Match, "text", *documentation)
-		return fmt.Errorf("comment override for %s did not match", override.ID)
+		return fmt.Errorf("comment override for %s did not match %q", override.ID, override.Match)
 	}
 	*documentation = new
 	return nil
@@ -15,6 +15,7 @@
 package api
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -330,3 +331,66 @@ func TestPatchCommentsMethod(t *testing.T) {
 		t.Errorf("mismatch in enums (-want, +got)\n:%s", diff)
 	}
 }
+
+func TestPatchCommentsReportsAllStale(t *testing.T) {
+	m0 := &Message{
+		Name:          "Message0",
+		Package:       "test",
+		ID:            ".test.Message0",
+		Documentation: Input,
+		Fields:        []*Field{{Name: "field", ID: ".test.Message0.field", Documentation: "Field."}},
+	}
+	model := NewTestAPI([]*Message{m0}, []*Enum{}, []*Service{})
+	cfg := config.Config{
+		CommentOverrides: []config.DocumentationOverride{
+			{ID: ".test.Message0.renamed", Match: "Field.", Replace: "A field."},
+			{ID: ".test.Message0", Match: Match, Replace: Replace},
+			{ID: ".test.Message0.field", Match: "fixed upstream", Replace: "A field."},
+		},
+	}
+	err := PatchDocumentation(model, &cfg)
+	if err == nil {
+		t.Fatal("expected an error with stale overrides")
+	}
+	for _, want := range []string{".test.Message0.renamed", ".test.Message0.field"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error, got=%v", want, err)
+		}
+	}
+	// The overrides that still apply are not skipped.
+	if diff := cmp.Diff(Want, m0.Documentation); diff != "" {
+		t.Errorf("mismatch in documentation (-want, +got)\n:%s", diff)
+	}
+}
+
+func TestCheckDocumentationOverrides(t *testing.T) {
+	m0 := &Message{
+		Name:          "Message0",
+		Package:       "test",
+		ID:            ".test.Message0",
+		Documentation: "Frist line. Second line.",
+	}
+	model := NewTestAPI([]*Message{m0}, []*Enum{}, []*Service{})
+	overrides := []config.DocumentationOverride{
+		{ID: ".test.Message0", Match: "Frist", Replace: "First"},
+		// Applies after the previous override.
+		{ID: ".test.Message0", Match: "First line.", Replace: "The first line."},
+		{ID: ".test.Message0", Match: "Third line.", Replace: "Another line."},
+		{ID: ".test.Missing", Match: "a", Replace: "b"},
+	}
+	got := CheckDocumentationOverrides(model, overrides)
+	var gotText []string
+	for _, err := range got {
+		gotText = append(gotText, err.Error())
+	}
+	want := []string{
+		"comment override for .test.Message0 did not match \"Third line.\"",
+		"cannot find element .test.Missing to apply comment overrides, only searched for messages, enums and services",
+	}
+	if diff := cmp.Diff(want, gotText); diff != "" {
+		t.Errorf("mismatch (-want, +got)\n:%s", diff)
+	}
+	if m0.Documentation != "Frist line. Second line." {
+		t.Errorf("the check should not change the model, got=%q", m0.Documentation)
+	}
+}
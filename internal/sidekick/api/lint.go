@@ -0,0 +1,230 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/julieqiu/librarianx/internal/sidekick/config"
+)
+
+// The IDs for the lint rules.
+const (
+	// LintServiceDocumentation reports services without documentation, see
+	// [AIP-192](https://google.aip.dev/192).
+	LintServiceDocumentation = "service-documentation"
+	// LintMethodDocumentation reports methods without documentation, see
+	// [AIP-192](https://google.aip.dev/192).
+	LintMethodDocumentation = "method-documentation"
+	// LintFieldDocumentation reports fields without documentation, see
+	// [AIP-192](https://google.aip.dev/192).
+	LintFieldDocumentation = "field-documentation"
+	// LintListPagination reports List methods that sidekick does not treat
+	// as paginated, see [AIP-158](https://google.aip.dev/158).
+	LintListPagination = "list-pagination"
+	// LintOperationInfo reports methods returning a long-running operation
+	// without a valid `google.longrunning.operation_info` annotation, see
+	// [AIP-151](https://google.aip.dev/151).
+	LintOperationInfo = "lro-operation-info"
+	// LintRoutingField reports routing annotations that reference missing or
+	// non-string fields, see [AIP-4222](https://google.aip.dev/client-libraries/4222).
+	LintRoutingField = "routing-field"
+	// LintHTTPBinding reports methods without HTTP bindings, see
+	// [AIP-127](https://google.aip.dev/127).
+	LintHTTPBinding = "http-binding"
+)
+
+const longRunningOperationID = ".google.longrunning.Operation"
+
+// LintFinding is a violation of a lint rule.
+type LintFinding struct {
+	// The rule ID, e.g. `field-documentation`.
+	Rule string
+	// The ID of the element violating the rule.
+	ID string
+	// A description of the problem.
+	Message string
+}
+
+func (f *LintFinding) String() string {
+	return fmt.Sprintf("%s [%s]: %s", f.ID, f.Rule, f.Message)
+}
+
+// Lint checks the model for AIP violations.
+//
+// `Validate` verifies the model can be used by the codecs. In contrast, lint
+// findings do not prevent code generation, but they often result in less
+// idiomatic code, e.g. a List method without pagination helpers.
+//
+// The findings suppressed in `cfg` are not returned. The findings are sorted
+// by element ID and then rule.
+func Lint(model *API, cfg *config.Lint) []*LintFinding {
+	l := &linter{model: model, cfg: cfg}
+	for _, s := range model.Services {
+		l.service(s)
+	}
+	for _, m := range allMessages(model.Messages) {
+		l.message(m)
+	}
+	slices.SortStableFunc(l.findings, func(a, b *LintFinding) int {
+		return cmp.Or(cmp.Compare(a.ID, b.ID), cmp.Compare(a.Rule, b.Rule))
+	})
+	return l.findings
+}
+
+type linter struct {
+	model    *API
+	cfg      *config.Lint
+	findings []*LintFinding
+}
+
+func (l *linter) report(rule, id, format string, args ...any) {
+	if l.cfg != nil && l.cfg.Suppressed(rule, id) {
+		return
+	}
+	l.findings = append(l.findings, &LintFinding{Rule: rule, ID: id, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *linter) service(s *Service) {
+	if s.Documentation == "" {
+		l.report(LintServiceDocumentation, s.ID, "the service has no documentation")
+	}
+	for _, m := range s.Methods {
+		l.method(m)
+	}
+}
+
+func (l *linter) method(m *Method) {
+	if m.Documentation == "" {
+		l.report(LintMethodDocumentation, m.ID, "the method has no documentation")
+	}
+	if m.PathInfo == nil || len(m.PathInfo.Bindings) == 0 {
+		l.report(LintHTTPBinding, m.ID, "the method has no HTTP bindings")
+	}
+	l.pagination(m)
+	l.operationInfo(m)
+	l.routing(m)
+}
+
+// pagination reports List methods that `updateMethodPagination` in the
+// parser did not mark as paginated.
+func (l *linter) pagination(m *Method) {
+	if m.Pagination != nil || m.ClientSideStreaming || m.ServerSideStreaming {
+		return
+	}
+	request := l.model.State.MessageByID[m.InputTypeID]
+	response := l.model.State.MessageByID[m.OutputTypeID]
+	if request == nil || response == nil {
+		return
+	}
+	token := findLintField(request, "pageToken")
+	if !strings.HasPrefix(m.Name, "List") && token == nil {
+		return
+	}
+	var missing []string
+	if size := findLintField(request, "pageSize"); size == nil || (size.Typez != INT32_TYPE && size.Typez != UINT32_TYPE) {
+		if findLintField(request, "maxResults") == nil {
+			missing = append(missing, "an int32 `page_size` request field")
+		}
+	}
+	if token == nil || token.Typez != STRING_TYPE {
+		missing = append(missing, "a string `page_token` request field")
+	}
+	if next := findLintField(response, "nextPageToken"); next == nil || next.Typez != STRING_TYPE {
+		missing = append(missing, "a string `next_page_token` response field")
+	}
+	if !slices.ContainsFunc(response.Fields, func(f *Field) bool { return f.Map || (f.Repeated && f.Typez == MESSAGE_TYPE) }) {
+		missing = append(missing, "a repeated or map response field with the items")
+	}
+	if len(missing) == 0 {
+		l.report(LintListPagination, m.ID, "the method is not paginated, the items field in %s is ambiguous, consider a pagination override", response.ID)
+		return
+	}
+	l.report(LintListPagination, m.ID, "the method is not paginated, missing %s", strings.Join(missing, ", "))
+}
+
+func findLintField(m *Message, jsonName string) *Field {
+	idx := slices.IndexFunc(m.Fields, func(f *Field) bool { return f.JSONName == jsonName })
+	if idx == -1 {
+		return nil
+	}
+	return m.Fields[idx]
+}
+
+func (l *linter) operationInfo(m *Method) {
+	if m.OperationInfo == nil {
+		if m.OutputTypeID == longRunningOperationID {
+			l.report(LintOperationInfo, m.ID, "the method returns %s without an operation_info annotation", longRunningOperationID)
+		}
+		return
+	}
+	for _, id := range []string{m.OperationInfo.ResponseTypeID, m.OperationInfo.MetadataTypeID} {
+		if id == "" {
+			l.report(LintOperationInfo, m.ID, "the operation_info annotation is missing the response or metadata type")
+			continue
+		}
+		if _, ok := l.model.State.MessageByID[id]; !ok {
+			l.report(LintOperationInfo, m.ID, "the operation_info annotation references an unknown type %s", id)
+		}
+	}
+}
+
+func (l *linter) routing(m *Method) {
+	for _, info := range m.Routing {
+		for _, variant := range info.Variants {
+			if err := l.routingField(m, variant.FieldPath); err != nil {
+				l.report(LintRoutingField, m.ID, "invalid routing field %q: %v", variant.FieldName(), err)
+			}
+		}
+	}
+}
+
+func (l *linter) routingField(m *Method, fieldPath []string) error {
+	message := l.model.State.MessageByID[m.InputTypeID]
+	if message == nil {
+		return fmt.Errorf("unknown request type %s", m.InputTypeID)
+	}
+	for i, name := range fieldPath {
+		idx := slices.IndexFunc(message.Fields, func(f *Field) bool { return f.Name == name })
+		if idx == -1 {
+			return fmt.Errorf("no field %s in %s", name, message.ID)
+		}
+		field := message.Fields[idx]
+		if i == len(fieldPath)-1 {
+			if field.Typez != STRING_TYPE || field.Repeated || field.Map {
+				return fmt.Errorf("%s is not a singular string field", field.ID)
+			}
+			return nil
+		}
+		if field.Typez != MESSAGE_TYPE || field.Repeated || field.Map {
+			return fmt.Errorf("%s is not a singular message field", field.ID)
+		}
+		if message = l.model.State.MessageByID[field.TypezID]; message == nil {
+			return fmt.Errorf("unknown message type %s", field.TypezID)
+		}
+	}
+	return fmt.Errorf("empty field path")
+}
+
+func (l *linter) message(m *Message) {
+	for _, f := range m.Fields {
+		if f.Documentation == "" {
+			l.report(LintFieldDocumentation, f.ID, "the field has no documentation")
+		}
+	}
+}
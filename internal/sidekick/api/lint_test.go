@@ -0,0 +1,188 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/sidekick/config"
+)
+
+func newLintTestModel() *API {
+	documented := func(f *Field) *Field {
+		f.Documentation = "Documentation for " + f.Name
+		return f
+	}
+	listRequest := &Message{
+		Name:    "ListSecretsRequest",
+		ID:      ".test.ListSecretsRequest",
+		Package: "test",
+		Fields: []*Field{
+			documented(&Field{Name: "parent", JSONName: "parent", ID: ".test.ListSecretsRequest.parent", Typez: STRING_TYPE}),
+			documented(&Field{Name: "page_token", JSONName: "pageToken", ID: ".test.ListSecretsRequest.page_token", Typez: STRING_TYPE}),
+			documented(&Field{Name: "nested", JSONName: "nested", ID: ".test.ListSecretsRequest.nested", Typez: MESSAGE_TYPE, TypezID: ".test.Secret"}),
+		},
+	}
+	listResponse := &Message{
+		Name:    "ListSecretsResponse",
+		ID:      ".test.ListSecretsResponse",
+		Package: "test",
+		Fields: []*Field{
+			documented(&Field{Name: "secrets", JSONName: "secrets", ID: ".test.ListSecretsResponse.secrets", Typez: MESSAGE_TYPE, TypezID: ".test.Secret", Repeated: true}),
+			documented(&Field{Name: "next_page_token", JSONName: "nextPageToken", ID: ".test.ListSecretsResponse.next_page_token", Typez: STRING_TYPE}),
+		},
+	}
+	secret := &Message{
+		Name:    "Secret",
+		ID:      ".test.Secret",
+		Package: "test",
+		Fields: []*Field{
+			documented(&Field{Name: "name", JSONName: "name", ID: ".test.Secret.name", Typez: STRING_TYPE}),
+			{Name: "labels", JSONName: "labels", ID: ".test.Secret.labels", Typez: STRING_TYPE},
+		},
+	}
+	binding := &PathInfo{Bindings: []*PathBinding{{Verb: "GET", PathTemplate: NewPathTemplate().WithLiteral("v1")}}}
+	service := &Service{
+		Name:          "SecretService",
+		ID:            ".test.SecretService",
+		Package:       "test",
+		Documentation: "A service.",
+		Methods: []*Method{
+			{
+				Name:          "ListSecrets",
+				ID:            ".test.SecretService.ListSecrets",
+				Documentation: "Lists secrets.",
+				InputTypeID:   listRequest.ID,
+				OutputTypeID:  listResponse.ID,
+				PathInfo:      binding,
+				Routing: []*RoutingInfo{{
+					Name: "parent",
+					Variants: []*RoutingInfoVariant{
+						{FieldPath: []string{"parent"}},
+						{FieldPath: []string{"missing"}},
+						{FieldPath: []string{"nested", "name"}},
+						{FieldPath: []string{"nested"}},
+					},
+				}},
+			},
+			{
+				Name:         "CreateSecret",
+				ID:           ".test.SecretService.CreateSecret",
+				InputTypeID:  secret.ID,
+				OutputTypeID: ".google.longrunning.Operation",
+			},
+			{
+				Name:          "UpdateSecret",
+				ID:            ".test.SecretService.UpdateSecret",
+				Documentation: "Updates a secret.",
+				InputTypeID:   secret.ID,
+				OutputTypeID:  ".google.longrunning.Operation",
+				PathInfo:      binding,
+				OperationInfo: &OperationInfo{ResponseTypeID: secret.ID, MetadataTypeID: ".test.Missing"},
+			},
+		},
+	}
+	return NewTestAPI([]*Message{listRequest, listResponse, secret}, []*Enum{}, []*Service{service})
+}
+
+func TestLint(t *testing.T) {
+	model := newLintTestModel()
+	got := Lint(model, nil)
+	want := []*LintFinding{
+		{Rule: LintFieldDocumentation, ID: ".test.Secret.labels", Message: "the field has no documentation"},
+		{Rule: LintHTTPBinding, ID: ".test.SecretService.CreateSecret", Message: "the method has no HTTP bindings"},
+		{Rule: LintOperationInfo, ID: ".test.SecretService.CreateSecret", Message: "the method returns .google.longrunning.Operation without an operation_info annotation"},
+		{Rule: LintMethodDocumentation, ID: ".test.SecretService.CreateSecret", Message: "the method has no documentation"},
+		{Rule: LintListPagination, ID: ".test.SecretService.ListSecrets", Message: "the method is not paginated, missing an int32 `page_size` request field"},
+		{Rule: LintRoutingField, ID: ".test.SecretService.ListSecrets", Message: `invalid routing field "missing": no field missing in .test.ListSecretsRequest`},
+		{Rule: LintRoutingField, ID: ".test.SecretService.ListSecrets", Message: `invalid routing field "nested": .test.ListSecretsRequest.nested is not a singular string field`},
+		{Rule: LintOperationInfo, ID: ".test.SecretService.UpdateSecret", Message: "the operation_info annotation references an unknown type .test.Missing"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestLintSuppressions(t *testing.T) {
+	model := newLintTestModel()
+	cfg := &config.Lint{
+		Suppressions: []config.LintSuppression{
+			{Rule: LintRoutingField},
+			{Rule: LintOperationInfo},
+			{Rule: LintFieldDocumentation, ID: ".test.Secret.labels"},
+			{Rule: LintMethodDocumentation, ID: ".test.SecretService.CreateSecret"},
+			{Rule: LintHTTPBinding, ID: ".test.SecretService.ListSecrets"},
+		},
+	}
+	got := Lint(model, cfg)
+	want := []*LintFinding{
+		{Rule: LintHTTPBinding, ID: ".test.SecretService.CreateSecret", Message: "the method has no HTTP bindings"},
+		{Rule: LintListPagination, ID: ".test.SecretService.ListSecrets", Message: "the method is not paginated, missing an int32 `page_size` request field"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestLintPagination(t *testing.T) {
+	request := &Message{
+		Name:    "ListRequest",
+		ID:      ".test.ListRequest",
+		Package: "test",
+		Fields: []*Field{
+			{Name: "page_size", JSONName: "pageSize", ID: ".test.ListRequest.page_size", Typez: INT32_TYPE},
+			{Name: "page_token", JSONName: "pageToken", ID: ".test.ListRequest.page_token", Typez: STRING_TYPE},
+		},
+	}
+	for _, test := range []struct {
+		name     string
+		response []*Field
+		want     string
+	}{
+		{
+			name: "missingEverything",
+			want: "the method is not paginated, missing a string `next_page_token` response field, a repeated or map response field with the items",
+		},
+		{
+			name: "ambiguous",
+			response: []*Field{
+				{Name: "next_page_token", JSONName: "nextPageToken", Typez: STRING_TYPE},
+				{Name: "a", JSONName: "a", Typez: MESSAGE_TYPE, TypezID: ".test.A", Repeated: true},
+				{Name: "b", JSONName: "b", Typez: MESSAGE_TYPE, TypezID: ".test.B", Repeated: true},
+			},
+			want: "the method is not paginated, the items field in .test.ListResponse is ambiguous, consider a pagination override",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			response := &Message{Name: "ListResponse", ID: ".test.ListResponse", Package: "test", Fields: test.response}
+			method := &Method{
+				Name:          "List",
+				ID:            ".test.Service.List",
+				Documentation: "Lists things.",
+				InputTypeID:   request.ID,
+				OutputTypeID:  response.ID,
+				PathInfo:      &PathInfo{Bindings: []*PathBinding{{Verb: "GET"}}},
+			}
+			service := &Service{Name: "Service", ID: ".test.Service", Package: "test", Documentation: "A service.", Methods: []*Method{method}}
+			model := NewTestAPI([]*Message{request, response}, []*Enum{}, []*Service{service})
+			cfg := &config.Lint{Suppressions: []config.LintSuppression{{Rule: LintFieldDocumentation}}}
+			want := []*LintFinding{{Rule: LintListPagination, ID: method.ID, Message: test.want}}
+			if diff := cmp.Diff(want, Lint(model, cfg)); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -252,6 +252,15 @@ type Method struct {
 	OperationInfo *OperationInfo
 	// DiscoveryLro has a value if this is a discovery-style long-running operation.
 	DiscoveryLro *DiscoveryLro
+	// MediaUpload has a value if the method accepts media uploads.
+	//
+	// Only discovery docs describe media uploads.
+	MediaUpload *MediaUpload
+	// SupportsMediaDownload is true if the method can return the media
+	// instead of the response message.
+	//
+	// Discovery docs use `alt=media` to request the media.
+	SupportsMediaDownload bool
 	// Routing contains the routing annotations, if any.
 	Routing []*RoutingInfo
 	// AutoPopulated contains the auto-populated (request_id) field, if any, as defined in
@@ -396,6 +405,31 @@ type PathBinding struct {
 	Codec any
 }
 
+// MediaUpload describes how a method accepts media uploads.
+type MediaUpload struct {
+	// Accept lists the MIME type ranges accepted for the media, e.g. `image/*`.
+	Accept []string
+	// MaxSize is the maximum size of the media in bytes. Zero means there is no
+	// limit.
+	MaxSize int64
+	// Simple is set if the media can be sent in a single request, using
+	// `uploadType=media` or `uploadType=multipart`.
+	Simple *UploadProtocol
+	// Resumable is set if the media can be sent using a resumable upload
+	// session, using `uploadType=resumable`.
+	Resumable *UploadProtocol
+}
+
+// UploadProtocol describes one of the protocols to upload media.
+type UploadProtocol struct {
+	// Multipart is true if the protocol accepts the request body and the media
+	// in a single `multipart/related` request.
+	Multipart bool
+	// The HTTP binding used to upload the media. The binding uses the same
+	// verb and query parameters as the method, but a different path.
+	Binding *PathBinding
+}
+
 // OperationInfo contains normalized long running operation info.
 type OperationInfo struct {
 	// The metadata type. If there is no metadata, this is set to
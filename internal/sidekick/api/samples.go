@@ -0,0 +1,227 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"slices"
+	"strings"
+)
+
+// The maximum depth of nested messages in a sample request. Required fields
+// rarely form cycles, but this guarantees the samples are finite.
+const maxSampleDepth = 4
+
+// SampleField is a field set in a code sample, and its value.
+type SampleField struct {
+	// The field. For repeated fields the sample contains a single element.
+	Field *Field
+	// The value of string and bytes fields, e.g. `projects/my-project`, or
+	// the literal value of other scalar fields, e.g. `42` or `true`.
+	Value string
+	// The value of enum fields.
+	EnumValue *EnumValue
+	// The fields set in message fields, may be empty.
+	Fields []*SampleField
+}
+
+// SampleRequest returns the fields set in the request of a code sample for the
+// method.
+//
+// The sample sets the required fields first, in declaration order, and then
+// any other fields used in the request path. The same rules apply to the
+// message fields, recursively. The values are derived from the field types,
+// names, and from the resource patterns in the path, for example,
+// `projects/my-project/secrets/my-secret` for a field bound to
+// `{name=projects/*/secrets/*}`. Map fields are never set, and output only
+// fields are only set if used in the path.
+func SampleRequest(model *API, method *Method) []*SampleField {
+	request := model.State.MessageByID[method.InputTypeID]
+	if request == nil {
+		return nil
+	}
+	s := &sampler{model: model, patterns: map[string][]string{}}
+	if method.PathInfo != nil && len(method.PathInfo.Bindings) != 0 && method.PathInfo.Bindings[0].PathTemplate != nil {
+		for _, segment := range method.PathInfo.Bindings[0].PathTemplate.Segments {
+			if segment.Variable != nil {
+				s.patterns[strings.Join(segment.Variable.FieldPath, ".")] = segment.Variable.Segments
+			}
+		}
+	}
+	return s.message(request, "", 0)
+}
+
+type sampler struct {
+	model *API
+	// The resource patterns for the fields in the request path, indexed by
+	// their field path, e.g. `secret.name`.
+	patterns map[string][]string
+}
+
+func (s *sampler) message(message *Message, prefix string, depth int) []*SampleField {
+	var required, path []*SampleField
+	for _, field := range message.Fields {
+		if field.Map {
+			continue
+		}
+		fieldPath := prefix + field.Name
+		// Resource names are often output only, but the path still needs
+		// them, e.g., `secret.name` in `UpdateSecret`.
+		inPath := s.inPath(fieldPath)
+		if slices.Contains(field.Behavior, FIELD_BEHAVIOR_OUTPUT_ONLY) && !inPath {
+			continue
+		}
+		isRequired := slices.Contains(field.Behavior, FIELD_BEHAVIOR_REQUIRED)
+		if !isRequired && !inPath {
+			continue
+		}
+		sample := s.field(field, fieldPath, depth)
+		if sample == nil {
+			continue
+		}
+		if isRequired {
+			required = append(required, sample)
+		} else {
+			path = append(path, sample)
+		}
+	}
+	return append(required, path...)
+}
+
+// inPath returns true if the field, or one of its fields, is used in the
+// request path.
+func (s *sampler) inPath(fieldPath string) bool {
+	for p := range s.patterns {
+		if p == fieldPath || strings.HasPrefix(p, fieldPath+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *sampler) field(field *Field, fieldPath string, depth int) *SampleField {
+	sample := &SampleField{Field: field}
+	switch field.Typez {
+	case STRING_TYPE:
+		if pattern, ok := s.patterns[fieldPath]; ok {
+			sample.Value = sampleResourceName(field, pattern)
+		} else {
+			sample.Value = sampleString(field)
+		}
+	case BYTES_TYPE:
+		sample.Value = "example"
+	case BOOL_TYPE:
+		sample.Value = "true"
+	case FLOAT_TYPE, DOUBLE_TYPE:
+		sample.Value = "1.5"
+	case INT32_TYPE, INT64_TYPE, UINT32_TYPE, UINT64_TYPE,
+		SINT32_TYPE, SINT64_TYPE, FIXED32_TYPE, FIXED64_TYPE,
+		SFIXED32_TYPE, SFIXED64_TYPE:
+		sample.Value = "42"
+	case ENUM_TYPE:
+		enum := s.model.State.EnumByID[field.TypezID]
+		if enum == nil || len(enum.Values) == 0 {
+			return nil
+		}
+		// Prefer a value other than the default, which is usually
+		// `*_UNSPECIFIED`.
+		sample.EnumValue = enum.Values[0]
+		if idx := slices.IndexFunc(enum.Values, func(v *EnumValue) bool { return v.Number != 0 }); idx != -1 {
+			sample.EnumValue = enum.Values[idx]
+		}
+	case MESSAGE_TYPE:
+		message := s.model.State.MessageByID[field.TypezID]
+		if message == nil {
+			return nil
+		}
+		if depth < maxSampleDepth {
+			sample.Fields = s.message(message, fieldPath+".", depth+1)
+		}
+	default:
+		return nil
+	}
+	return sample
+}
+
+// sampleResourceName returns a resource name matching the path segments, e.g.
+// `projects/my-project/secrets/my-secret` for `projects/*/secrets/*`.
+func sampleResourceName(field *Field, segments []string) string {
+	var parts []string
+	previous := field.Name
+	for _, segment := range segments {
+		switch segment {
+		case SingleSegmentWildcard:
+			parts = append(parts, "my-"+sampleIdentifier(singular(previous)))
+		case MultiSegmentWildcard:
+			parts = append(parts, "my-"+sampleIdentifier(singular(previous))+"/my-path")
+		default:
+			parts = append(parts, segment)
+			previous = segment
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// sampleString returns a realistic value for a string field not used in the
+// request path, based on the field name.
+func sampleString(field *Field) string {
+	name := field.Name
+	switch {
+	case name == "parent":
+		return "projects/my-project"
+	case strings.Contains(name, "email"):
+		return "user@example.com"
+	case strings.HasSuffix(name, "uri"), strings.HasSuffix(name, "url"):
+		return "https://example.com"
+	case strings.HasSuffix(name, "_id"):
+		return "my-" + sampleIdentifier(strings.TrimSuffix(name, "_id"))
+	default:
+		return "my-" + sampleIdentifier(name)
+	}
+}
+
+// sampleIdentifier converts names like `displayName` or `display_name` to
+// `display-name`.
+func sampleIdentifier(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case r == '_':
+			b.WriteRune('-')
+		case r >= 'A' && r <= 'Z':
+			if i != 0 {
+				b.WriteRune('-')
+			}
+			b.WriteRune(r - 'A' + 'a')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// singular returns the singular of common collection names, e.g. `project`
+// for `projects` and `policy` for `policies`.
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss"):
+		return strings.TrimSuffix(name, "s")
+	default:
+		return name
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSampleRequest(t *testing.T) {
+	state := &Enum{
+		Name:    "State",
+		ID:      ".test.State",
+		Package: "test",
+		Values: []*EnumValue{
+			{Name: "STATE_UNSPECIFIED", Number: 0},
+			{Name: "ENABLED", Number: 1},
+		},
+	}
+	secret := &Message{
+		Name:    "Secret",
+		ID:      ".test.Secret",
+		Package: "test",
+		Fields: []*Field{
+			{Name: "name", Typez: STRING_TYPE, Behavior: []FieldBehavior{FIELD_BEHAVIOR_OUTPUT_ONLY}},
+			{Name: "create_time", Typez: STRING_TYPE, Behavior: []FieldBehavior{FIELD_BEHAVIOR_OUTPUT_ONLY}},
+			{Name: "labels", Typez: MESSAGE_TYPE, TypezID: ".test.Secret.LabelsEntry", Map: true, Behavior: []FieldBehavior{FIELD_BEHAVIOR_REQUIRED}},
+			{Name: "state", Typez: ENUM_TYPE, TypezID: ".test.State", Behavior: []FieldBehavior{FIELD_BEHAVIOR_REQUIRED}},
+			{Name: "owner_email", Typez: STRING_TYPE, Behavior: []FieldBehavior{FIELD_BEHAVIOR_REQUIRED}},
+		},
+	}
+	create := &Message{
+		Name:    "CreateSecretRequest",
+		ID:      ".test.CreateSecretRequest",
+		Package: "test",
+		Fields: []*Field{
+			{Name: "parent", Typez: STRING_TYPE, Behavior: []FieldBehavior{FIELD_BEHAVIOR_REQUIRED}},
+			{Name: "secret_id", Typez: STRING_TYPE, Behavior: []FieldBehavior{FIELD_BEHAVIOR_REQUIRED}},
+			{Name: "secret", Typez: MESSAGE_TYPE, TypezID: ".test.Secret", Behavior: []FieldBehavior{FIELD_BEHAVIOR_REQUIRED}},
+			{Name: "validate_only", Typez: BOOL_TYPE},
+		},
+	}
+	update := &Message{
+		Name:    "UpdateSecretRequest",
+		ID:      ".test.UpdateSecretRequest",
+		Package: "test",
+		Fields: []*Field{
+			{Name: "etag", Typez: BYTES_TYPE},
+			{Name: "secret", Typez: MESSAGE_TYPE, TypezID: ".test.Secret"},
+			{Name: "page_size", Typez: INT32_TYPE, Behavior: []FieldBehavior{FIELD_BEHAVIOR_REQUIRED}},
+		},
+	}
+	createMethod := &Method{
+		Name:        "CreateSecret",
+		ID:          ".test.Service.CreateSecret",
+		InputTypeID: ".test.CreateSecretRequest",
+		PathInfo: &PathInfo{
+			Bindings: []*PathBinding{{
+				Verb: "POST",
+				PathTemplate: NewPathTemplate().
+					WithLiteral("v1").
+					WithVariable(NewPathVariable("parent").WithLiteral("projects").WithMatch()).
+					WithLiteral("secrets"),
+			}},
+		},
+	}
+	updateMethod := &Method{
+		Name:        "UpdateSecret",
+		ID:          ".test.Service.UpdateSecret",
+		InputTypeID: ".test.UpdateSecretRequest",
+		PathInfo: &PathInfo{
+			Bindings: []*PathBinding{{
+				Verb: "PATCH",
+				PathTemplate: NewPathTemplate().
+					WithLiteral("v1").
+					WithVariable(NewPathVariable("secret", "name").
+						WithLiteral("projects").WithMatch().
+						WithLiteral("secrets").WithMatch().
+						WithLiteral("versions").WithMatchRecursive()),
+			}},
+		},
+	}
+	service := &Service{
+		Name:    "Service",
+		ID:      ".test.Service",
+		Package: "test",
+		Methods: []*Method{createMethod, updateMethod},
+	}
+	model := NewTestAPI([]*Message{secret, create, update}, []*Enum{state}, []*Service{service})
+
+	for _, test := range []struct {
+		method *Method
+		want   []string
+	}{
+		{
+			method: createMethod,
+			want: []string{
+				"parent=projects/my-project",
+				"secret_id=my-secret",
+				"secret",
+				"secret.state=ENABLED",
+				"secret.owner_email=user@example.com",
+			},
+		},
+		{
+			method: updateMethod,
+			want: []string{
+				"page_size=42",
+				"secret",
+				"secret.state=ENABLED",
+				"secret.owner_email=user@example.com",
+				"secret.name=projects/my-project/secrets/my-secret/versions/my-version/my-path",
+			},
+		},
+	} {
+		t.Run(test.method.Name, func(t *testing.T) {
+			got := flattenSample("", SampleRequest(model, test.method))
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSampleRequestUnknownInput(t *testing.T) {
+	model := NewTestAPI([]*Message{}, []*Enum{}, []*Service{})
+	if got := SampleRequest(model, &Method{InputTypeID: ".test.Missing"}); got != nil {
+		t.Errorf("SampleRequest() = %v, want nil", got)
+	}
+}
+
+func TestSampleString(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		want string
+	}{
+		{"parent", "projects/my-project"},
+		{"secret_id", "my-secret"},
+		{"service_account_email", "user@example.com"},
+		{"callback_uri", "https://example.com"},
+		{"displayName", "my-display-name"},
+		{"filter", "my-filter"},
+	} {
+		if got := sampleString(&Field{Name: test.name}); got != test.want {
+			t.Errorf("sampleString(%q) = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestSingular(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		want string
+	}{
+		{"projects", "project"},
+		{"policies", "policy"},
+		{"addresses", "address"},
+		{"access", "access"},
+		{"locations", "location"},
+	} {
+		if got := singular(test.name); got != test.want {
+			t.Errorf("singular(%q) = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func flattenSample(prefix string, fields []*SampleField) []string {
+	var result []string
+	for _, f := range fields {
+		name := prefix + f.Field.Name
+		switch {
+		case f.EnumValue != nil:
+			result = append(result, name+"="+f.EnumValue.Name)
+		case f.Field.Typez == MESSAGE_TYPE:
+			result = append(result, name)
+			result = append(result, flattenSample(name+".", f.Fields)...)
+		default:
+			result = append(result, name+"="+f.Value)
+		}
+	}
+	return result
+}
@@ -59,6 +59,7 @@ type Config struct {
 	Codec               map[string]string       `toml:"codec,omitempty"`
 	CommentOverrides    []DocumentationOverride `toml:"documentation-overrides,omitempty"`
 	PaginationOverrides []PaginationOverride    `toml:"pagination-overrides,omitempty"`
+	Lint                *Lint                   `toml:"lint,omitempty"`
 	Release             *Release                `toml:"release,omitempty"`
 }
 
@@ -133,6 +134,7 @@ func mergeConfigs(rootConfig, local *Config) *Config {
 		CommentOverrides:    local.CommentOverrides,
 		PaginationOverrides: local.PaginationOverrides,
 		Discovery:           local.Discovery,
+		Lint:                mergeLint(rootConfig.Lint, local.Lint),
 		// Release does not accept local overrides
 		Release: rootConfig.Release,
 	}
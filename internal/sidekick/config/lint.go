@@ -0,0 +1,64 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// Lint configures the checks for AIP violations in the model.
+//
+// The checks only run if the `[lint]` section is present, either in the
+// top-level configuration file or in the `.sidekick.toml` file for a library.
+type Lint struct {
+	// If true, any finding stops the generation. Otherwise the findings are
+	// logged as warnings.
+	Fail bool `toml:"fail,omitempty"`
+	// Suppress some findings, typically for a single library.
+	Suppressions []LintSuppression `toml:"suppressions,omitempty"`
+}
+
+// LintSuppression suppresses the findings for a lint rule.
+type LintSuppression struct {
+	// The rule, e.g. `field-documentation`.
+	Rule string `toml:"rule"`
+	// The ID of the element, e.g. `.google.cloud.foo.v1.Secret.name`. If empty,
+	// the rule is suppressed for all elements.
+	ID string `toml:"id,omitempty"`
+	// Why the finding is suppressed. Only used for documentation.
+	Reason string `toml:"reason,omitempty"`
+}
+
+// Suppressed returns true if the finding for `rule` in the element `id` is
+// suppressed.
+func (l *Lint) Suppressed(rule, id string) bool {
+	for _, s := range l.Suppressions {
+		if s.Rule == rule && (s.ID == "" || s.ID == id) {
+			return true
+		}
+	}
+	return false
+}
+
+func mergeLint(root, local *Lint) *Lint {
+	if root == nil && local == nil {
+		return nil
+	}
+	merged := &Lint{}
+	for _, l := range []*Lint{root, local} {
+		if l == nil {
+			continue
+		}
+		merged.Fail = merged.Fail || l.Fail
+		merged.Suppressions = append(merged.Suppressions, l.Suppressions...)
+	}
+	return merged
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMergeLocalForLint(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		root  *Lint
+		local *Lint
+		want  *Lint
+	}{
+		{"neither", nil, nil, nil},
+		{"onlyRoot", &Lint{Fail: true}, nil, &Lint{Fail: true}},
+		{
+			"onlyLocal",
+			nil,
+			&Lint{Suppressions: []LintSuppression{{Rule: "http-binding"}}},
+			&Lint{Suppressions: []LintSuppression{{Rule: "http-binding"}}},
+		},
+		{
+			"both",
+			&Lint{Fail: true, Suppressions: []LintSuppression{{Rule: "field-documentation"}}},
+			&Lint{Suppressions: []LintSuppression{{Rule: "http-binding", ID: ".test.Service.Stream", Reason: "gRPC only"}}},
+			&Lint{Fail: true, Suppressions: []LintSuppression{
+				{Rule: "field-documentation"},
+				{Rule: "http-binding", ID: ".test.Service.Stream", Reason: "gRPC only"},
+			}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			root := &Config{Lint: test.root}
+			local := &Config{Lint: test.local}
+			got, err := mergeTestConfigs(t, root, local)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got.Lint); diff != "" {
+				t.Errorf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLintSuppressed(t *testing.T) {
+	lint := &Lint{Suppressions: []LintSuppression{
+		{Rule: "field-documentation"},
+		{Rule: "http-binding", ID: ".test.Service.Stream"},
+	}}
+	for _, test := range []struct {
+		rule string
+		id   string
+		want bool
+	}{
+		{"field-documentation", ".test.Message.field", true},
+		{"http-binding", ".test.Service.Stream", true},
+		{"http-binding", ".test.Service.Get", false},
+		{"method-documentation", ".test.Service.Stream", false},
+	} {
+		if got := lint.Suppressed(test.rule, test.id); got != test.want {
+			t.Errorf("Suppressed(%q, %q) = %v, want = %v", test.rule, test.id, got, test.want)
+		}
+	}
+}
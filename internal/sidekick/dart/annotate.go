@@ -62,6 +62,9 @@ type modelAnnotations struct {
 	// ["export 'package:google_cloud_gax/gax.dart' show Any", "export 'package:google_cloud_gax/gax.dart' show Status"]
 	Exports     []string
 	ProtoPrefix string
+	// If true, generate a runnable code sample for each method, and an index
+	// of these samples.
+	GenerateSamples bool
 }
 
 // HasServices returns true if the model has services.
@@ -87,6 +90,9 @@ type serviceAnnotations struct {
 	FieldName   string
 	StructName  string
 	DefaultHost string
+	// True if any method uploads or downloads media. These methods use the
+	// `http.Client` directly, as `ServiceClient` only handles JSON payloads.
+	HasMediaMethods bool
 }
 
 type messageAnnotation struct {
@@ -130,6 +136,31 @@ type methodAnnotation struct {
 	QueryLines          []string
 	IsLROGetOperation   bool
 	ServerSideStreaming bool // Whether the server supports streaming via server-sent events (SSE).
+	// Set if the method accepts media uploads.
+	MediaUpload *mediaUploadAnnotation
+	// Whether the method can download media, using `alt=media`.
+	SupportsMediaDownload bool
+}
+
+type mediaUploadAnnotation struct {
+	// The path format for the upload URL.
+	PathFmt string
+	// The value for the `uploadType` query parameter, one of `multipart`,
+	// `media`, or `resumable`.
+	UploadType string
+	// The maximum size of the media in bytes, zero if there is no limit.
+	MaxSize int64
+}
+
+// Multipart returns true if the request body is sent along with the media.
+func (m *mediaUploadAnnotation) Multipart() bool {
+	return m.UploadType == "multipart"
+}
+
+// Resumable returns true if the media is sent using a resumable upload
+// session.
+func (m *mediaUploadAnnotation) Resumable() bool {
+	return m.UploadType == "resumable"
 }
 
 // HasBody returns true if the method has a body.
@@ -240,6 +271,7 @@ func (annotate *annotateModel) annotateModel(options map[string]string) error {
 		exports                    = []string{}
 		protobufPrefix             string
 		pkgName                    string
+		generateSamples            bool
 	)
 
 	for key, definition := range options {
@@ -290,6 +322,16 @@ func (annotate *annotateModel) annotateModel(options map[string]string) error {
 				)
 			}
 			doNotPublish = value
+		case key == "generate-samples":
+			value, err := strconv.ParseBool(definition)
+			if err != nil {
+				return fmt.Errorf(
+					"cannot convert `generate-samples` value %q to boolean: %w",
+					definition,
+					err,
+				)
+			}
+			generateSamples = value
 		case key == "readme-after-title-text":
 			// Markdown that will be inserted into the README.md after the title section.
 			readMeAfterTitleText = definition
@@ -414,6 +456,7 @@ func (annotate *annotateModel) annotateModel(options map[string]string) error {
 		ApiKeyEnvironmentVariables: apiKeyEnvironmentVariables,
 		Exports:                    exports,
 		ProtoPrefix:                protobufPrefix,
+		GenerateSamples:            generateSamples,
 	}
 
 	model.Codec = ann
@@ -497,16 +540,26 @@ func (annotate *annotateModel) annotateService(s *api.Service) {
 		return shouldGenerateMethod(m)
 	})
 
+	hasMediaMethods := false
 	for _, m := range methods {
 		annotate.annotateMethod(m)
+		codec := m.Codec.(*methodAnnotation)
+		if codec.MediaUpload != nil || codec.SupportsMediaDownload {
+			hasMediaMethods = true
+		}
+	}
+	if hasMediaMethods {
+		annotate.imports[convertImport] = true
+		annotate.imports[typedDataImport] = true
 	}
 	ann := &serviceAnnotations{
-		Name:        s.Name,
-		DocLines:    formatDocComments(s.Documentation, annotate.state),
-		Methods:     methods,
-		FieldName:   strcase.ToLowerCamel(s.Name),
-		StructName:  s.Name,
-		DefaultHost: s.DefaultHost,
+		Name:            s.Name,
+		DocLines:        formatDocComments(s.Documentation, annotate.state),
+		Methods:         methods,
+		FieldName:       strcase.ToLowerCamel(s.Name),
+		StructName:      s.Name,
+		DefaultHost:     s.DefaultHost,
+		HasMediaMethods: hasMediaMethods,
 	}
 	s.Codec = ann
 }
@@ -627,21 +680,53 @@ func (annotate *annotateModel) annotateMethod(method *api.Method) {
 	}
 
 	annotation := &methodAnnotation{
-		Parent:              method,
-		Name:                strcase.ToLowerCamel(method.Name),
-		RequestMethod:       strings.ToLower(method.PathInfo.Bindings[0].Verb),
-		RequestType:         annotate.resolveTypeName(state.MessageByID[method.InputTypeID], true),
-		ResponseType:        annotate.resolveTypeName(state.MessageByID[method.OutputTypeID], true),
-		DocLines:            formatDocComments(method.Documentation, state),
-		ReturnsValue:        !method.ReturnsEmpty,
-		BodyMessageName:     bodyMessageName,
-		QueryLines:          queryLines,
-		IsLROGetOperation:   isGetOperation,
-		ServerSideStreaming: method.ServerSideStreaming,
+		Parent:                method,
+		Name:                  strcase.ToLowerCamel(method.Name),
+		RequestMethod:         strings.ToLower(method.PathInfo.Bindings[0].Verb),
+		RequestType:           annotate.resolveTypeName(state.MessageByID[method.InputTypeID], true),
+		ResponseType:          annotate.resolveTypeName(state.MessageByID[method.OutputTypeID], true),
+		DocLines:              formatDocComments(method.Documentation, state),
+		ReturnsValue:          !method.ReturnsEmpty,
+		BodyMessageName:       bodyMessageName,
+		QueryLines:            queryLines,
+		IsLROGetOperation:     isGetOperation,
+		ServerSideStreaming:   method.ServerSideStreaming,
+		MediaUpload:           annotateMediaUpload(method),
+		SupportsMediaDownload: method.SupportsMediaDownload,
 	}
 	method.Codec = annotation
 }
 
+// annotateMediaUpload returns the annotations for methods uploading media.
+//
+// The generated code prefers to send the media in a single request. Methods
+// that only support resumable uploads open an upload session and send the
+// media in a single request to the session.
+func annotateMediaUpload(method *api.Method) *mediaUploadAnnotation {
+	if method.MediaUpload == nil {
+		return nil
+	}
+	if simple := method.MediaUpload.Simple; simple != nil {
+		uploadType := "media"
+		if simple.Multipart && method.PathInfo.BodyFieldPath != "" {
+			uploadType = "multipart"
+		}
+		return &mediaUploadAnnotation{
+			PathFmt:    pathTemplateFmt(simple.Binding.PathTemplate),
+			UploadType: uploadType,
+			MaxSize:    method.MediaUpload.MaxSize,
+		}
+	}
+	if resumable := method.MediaUpload.Resumable; resumable != nil {
+		return &mediaUploadAnnotation{
+			PathFmt:    pathTemplateFmt(resumable.Binding.PathTemplate),
+			UploadType: "resumable",
+			MaxSize:    method.MediaUpload.MaxSize,
+		}
+	}
+	return nil
+}
+
 func (annotate *annotateModel) annotateOperationInfo(operationInfo *api.OperationInfo) {
 	response := annotate.state.MessageByID[operationInfo.ResponseTypeID]
 	metadata := annotate.state.MessageByID[operationInfo.MetadataTypeID]
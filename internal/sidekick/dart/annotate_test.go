@@ -267,6 +267,91 @@ func TestAnnotateMethod(t *testing.T) {
 	}
 }
 
+func TestAnnotateMethodMedia(t *testing.T) {
+	method := sample.MethodListSecretVersions()
+	method.MediaUpload = &api.MediaUpload{
+		MaxSize: 1024,
+		Simple: &api.UploadProtocol{
+			Multipart: true,
+			Binding: &api.PathBinding{
+				Verb: "POST",
+				PathTemplate: api.NewPathTemplate().
+					WithLiteral("upload").
+					WithLiteral("v1").
+					WithVariableNamed("parent"),
+			},
+		},
+	}
+	method.SupportsMediaDownload = true
+	service := &api.Service{
+		Name:          sample.ServiceName,
+		Documentation: sample.APIDescription,
+		DefaultHost:   sample.DefaultHost,
+		Methods:       []*api.Method{method},
+		Package:       sample.Package,
+	}
+	model := api.NewTestAPI(
+		[]*api.Message{sample.ListSecretVersionsRequest(), sample.ListSecretVersionsResponse(),
+			sample.Secret(), sample.SecretVersion(), sample.Replication(), sample.Automatic(),
+			sample.CustomerManagedEncryption()},
+		[]*api.Enum{sample.EnumState()},
+		[]*api.Service{service},
+	)
+	api.Validate(model)
+	annotate := newAnnotateModel(model)
+	if err := annotate.annotateModel(requiredConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	codec := method.Codec.(*methodAnnotation)
+	want := &mediaUploadAnnotation{
+		PathFmt:    "/upload/v1/${request.parent}",
+		UploadType: "multipart",
+		MaxSize:    1024,
+	}
+	if diff := cmp.Diff(want, codec.MediaUpload); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+	if !codec.SupportsMediaDownload {
+		t.Errorf("expected SupportsMediaDownload in %v", codec)
+	}
+	if !service.Codec.(*serviceAnnotations).HasMediaMethods {
+		t.Errorf("expected HasMediaMethods in %v", service.Codec)
+	}
+	for _, imp := range []string{convertImport, typedDataImport} {
+		if !annotate.imports[imp] {
+			t.Errorf("missing import %q in %v", imp, annotate.imports)
+		}
+	}
+}
+
+func TestAnnotateMediaUploadResumableOnly(t *testing.T) {
+	method := sample.MethodListSecretVersions()
+	method.MediaUpload = &api.MediaUpload{
+		Resumable: &api.UploadProtocol{
+			Binding: &api.PathBinding{
+				Verb: "POST",
+				PathTemplate: api.NewPathTemplate().
+					WithLiteral("resumable").
+					WithLiteral("upload").
+					WithLiteral("v1").
+					WithVariableNamed("parent"),
+			},
+		},
+	}
+	got := annotateMediaUpload(method)
+	want := &mediaUploadAnnotation{
+		PathFmt:    "/resumable/upload/v1/${request.parent}",
+		UploadType: "resumable",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+	if !got.Resumable() || got.Multipart() {
+		t.Errorf("expected a resumable, non-multipart upload in %v", got)
+	}
+}
+
 func TestCalculatePubPackages(t *testing.T) {
 	for _, test := range []struct {
 		imports map[string]bool
@@ -27,6 +27,7 @@ import (
 )
 
 const (
+	convertImport       = "dart:convert"
 	typedDataImport     = "dart:typed_data"
 	httpImport          = "package:http/http.dart as http"
 	serviceClientImport = "package:google_cloud_rpc/service_client.dart"
@@ -165,8 +166,11 @@ func enumValueName(e *api.EnumValue) string {
 }
 
 func httpPathFmt(pathInfo *api.PathInfo) string {
+	return pathTemplateFmt(pathInfo.Bindings[0].PathTemplate)
+}
+
+func pathTemplateFmt(t *api.PathTemplate) string {
 	var builder strings.Builder
-	t := pathInfo.Bindings[0].PathTemplate
 	for _, segment := range t.Segments {
 		switch {
 		case segment.Literal != nil:
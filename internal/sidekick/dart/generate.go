@@ -35,6 +35,9 @@ func Generate(model *api.API, outdir string, config *config.Config) error {
 
 	provider := templatesProvider()
 	err := language.GenerateFromModel(outdir, model, provider, generatedFiles(model))
+	if err == nil && model.Codec.(*modelAnnotations).GenerateSamples && len(model.Services) > 0 {
+		err = language.GenerateSnippets(outdir, "example/snippet_index.json", "dart", provider, annotate.samples())
+	}
 	if err == nil {
 		// Check if we're configured to skip formatting.
 		skipFormat := config.Codec["skip-format"]
@@ -45,6 +48,12 @@ func Generate(model *api.API, outdir string, config *config.Config) error {
 	return err
 }
 
+// Annotate runs the Dart annotations on the model, without generating any
+// code. The annotations are stored in the `Codec` field of each element.
+func Annotate(model *api.API, config *config.Config) error {
+	return newAnnotateModel(model).annotateModel(config.Codec)
+}
+
 func templatesProvider() language.TemplateProvider {
 	return func(name string) (string, error) {
 		name = filepath.ToSlash(name)
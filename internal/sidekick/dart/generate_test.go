@@ -18,7 +18,6 @@ import (
 	"io/fs"
 	"maps"
 	"os"
-	"os/exec"
 	"path"
 	"path/filepath"
 	"strings"
@@ -34,7 +33,6 @@ var (
 )
 
 func TestFromProtobuf(t *testing.T) {
-	requireProtoc(t)
 	outDir := t.TempDir()
 
 	cfg := &config.Config{
@@ -80,6 +78,62 @@ func TestFromProtobuf(t *testing.T) {
 	}
 }
 
+func TestSamplesFromProtobuf(t *testing.T) {
+	outDir := t.TempDir()
+
+	cfg := &config.Config{
+		General: config.GeneralConfig{
+			SpecificationFormat: "protobuf",
+			ServiceConfig:       "google/cloud/secretmanager/v1/secretmanager_v1.yaml",
+			SpecificationSource: "google/cloud/secretmanager/v1",
+		},
+		Source: map[string]string{
+			"googleapis-root": path.Join(testdataDir, "googleapis"),
+		},
+		Codec: map[string]string{
+			"api-keys-environment-variables": "GOOGLE_API_KEY",
+			"issue-tracker-url":              "http://www.example.com/issues",
+			"copyright-year":                 "2025",
+			"skip-format":                    "true",
+			"generate-samples":               "true",
+			"package:google_cloud_rpc":       "^1.2.3",
+			"package:http":                   "^4.5.6",
+			"package:google_cloud_location":  "^7.8.9",
+			"package:google_cloud_protobuf":  "^0.1.2",
+			"proto:google.protobuf":          "package:google_cloud_protobuf/protobuf.dart",
+			"proto:google.cloud.location":    "package:google_cloud_location/location.dart",
+		},
+	}
+	model, err := parser.CreateModel(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Generate(model, outDir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	contents, err := os.ReadFile(path.Join(outDir, "example", "secret_manager_service_create_secret.dart"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(contents)
+	for _, want := range []string{
+		"// [START secretmanager_v1_generated_SecretManagerService_CreateSecret_async]",
+		"import 'package:google_cloud_secretmanager_v1/secretmanager.dart';",
+		"import 'package:http/http.dart' as http;",
+		"final client = SecretManagerService(client: http.Client());",
+		"parent: 'projects/my-project',",
+		"secretId: 'my-secret',",
+		"final response = await client.createSecret(request);",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in sample:\n%s", want, got)
+		}
+	}
+	if _, err := os.Stat(path.Join(outDir, "example", "snippet_index.json")); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestGeneratedFiles(t *testing.T) {
 	model := api.NewTestAPI([]*api.Message{}, []*api.Enum{}, []*api.Service{})
 	annotate := newAnnotateModel(model)
@@ -122,10 +176,3 @@ func TestTemplatesAvailable(t *testing.T) {
 		t.Errorf("no dart templates found")
 	}
 }
-
-func requireProtoc(t *testing.T) {
-	t.Helper()
-	if _, err := exec.LookPath("protoc"); err != nil {
-		t.Skip("skipping test because protoc is not installed")
-	}
-}
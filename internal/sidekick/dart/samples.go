@@ -0,0 +1,156 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dart
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+	"github.com/julieqiu/librarianx/internal/sidekick/language"
+)
+
+// sampleAnnotation is the input to the template for a method sample.
+type sampleAnnotation struct {
+	CopyrightYear string
+	BoilerPlate   []string
+	RegionTag     string
+	Imports       []string
+	ServiceName   string
+	MethodName    string
+	// The lines initializing the request, starting with its constructor.
+	Request             []string
+	ReturnsValue        bool
+	ServerSideStreaming bool
+}
+
+// samples returns the code samples for all the generated methods in the
+// model. The model must be annotated.
+func (annotate *annotateModel) samples() []language.Snippet {
+	ann := annotate.model.Codec.(*modelAnnotations)
+	var snippets []language.Snippet
+	for _, service := range annotate.model.Services {
+		svcAnn := service.Codec.(*serviceAnnotations)
+		for _, method := range svcAnn.Methods {
+			methodAnn := method.Codec.(*methodAnnotation)
+			// `getOperation` is generic, there is no simple sample for it.
+			if methodAnn.IsLROGetOperation {
+				continue
+			}
+			// Collect the imports used by the request, resolving its type
+			// names adds them to `annotate.imports`.
+			saved := annotate.imports
+			annotate.imports = map[string]bool{}
+			fields := api.SampleRequest(annotate.model, method)
+			request := annotate.sampleMessage(annotate.state.MessageByID[method.InputTypeID], fields, "  ")
+			request[0] = "  final request = " + strings.TrimSpace(request[0])
+			request[len(request)-1] += ";"
+			imports := annotate.imports
+			annotate.imports = saved
+			imports[httpImport] = true
+			imports[fmt.Sprintf("package:%s/%s.dart", ann.PackageName, ann.MainFileName)] = true
+			if usesBytes(fields) {
+				imports[convertImport] = true
+				imports[typedDataImport] = true
+			}
+			tag := language.RegionTag(method)
+			snippets = append(snippets, language.Snippet{
+				Method:       method,
+				TemplatePath: "templates/example/sample.mustache",
+				OutputPath:   path.Join("example", fmt.Sprintf("%s_%s.dart", strcase.ToSnake(service.Name), strcase.ToSnake(method.Name))),
+				RegionTag:    tag,
+				Data: &sampleAnnotation{
+					CopyrightYear:       ann.CopyrightYear,
+					BoilerPlate:         ann.BoilerPlate,
+					RegionTag:           tag,
+					Imports:             calculateImports(imports),
+					ServiceName:         svcAnn.Name,
+					MethodName:          methodAnn.Name,
+					Request:             request,
+					ReturnsValue:        methodAnn.ReturnsValue,
+					ServerSideStreaming: methodAnn.ServerSideStreaming,
+				},
+			})
+		}
+	}
+	return snippets
+}
+
+// sampleMessage returns the lines to construct a message with the given
+// fields, e.g. `Secret(name: 'my-name')`.
+func (annotate *annotateModel) sampleMessage(message *api.Message, fields []*api.SampleField, indent string) []string {
+	name := annotate.resolveTypeName(message, false)
+	var args []string
+	for _, f := range fields {
+		value := annotate.sampleValue(f, indent+"  ")
+		if f.Field.Repeated {
+			value = "[" + value + "]"
+		}
+		args = append(args, fmt.Sprintf("%s  %s: %s,", indent, fieldName(f.Field), value))
+	}
+	// The sample never sets map fields, but the constructor requires them.
+	for _, field := range message.Fields {
+		if field.Map && slices.Contains(field.Behavior, api.FIELD_BEHAVIOR_REQUIRED) {
+			args = append(args, fmt.Sprintf("%s  %s: {},", indent, fieldName(field)))
+		}
+	}
+	if len(args) == 0 {
+		return []string{indent + name + "()"}
+	}
+	lines := []string{indent + name + "("}
+	for _, arg := range args {
+		lines = append(lines, strings.Split(arg, "\n")...)
+	}
+	return append(lines, indent+")")
+}
+
+// sampleValue returns the Dart expression for the value of a field. Message
+// values may span multiple lines.
+func (annotate *annotateModel) sampleValue(f *api.SampleField, indent string) string {
+	switch f.Field.Typez {
+	case api.STRING_TYPE:
+		return dartString(f.Value)
+	case api.BYTES_TYPE:
+		return fmt.Sprintf("Uint8List.fromList(utf8.encode(%s))", dartString(f.Value))
+	case api.ENUM_TYPE:
+		enum := annotate.state.EnumByID[f.Field.TypezID]
+		annotate.updateUsedPackages(enum.Package)
+		name := enumName(enum)
+		if prefix, ok := annotate.packagePrefixes[enum.Package]; ok {
+			name = prefix + "." + name
+		}
+		return name + "." + enumValueName(f.EnumValue)
+	case api.MESSAGE_TYPE:
+		lines := annotate.sampleMessage(annotate.state.MessageByID[f.Field.TypezID], f.Fields, indent)
+		return strings.TrimSpace(strings.Join(lines, "\n"))
+	default:
+		return f.Value
+	}
+}
+
+func usesBytes(fields []*api.SampleField) bool {
+	return slices.ContainsFunc(fields, func(f *api.SampleField) bool {
+		return f.Field.Typez == api.BYTES_TYPE || usesBytes(f.Fields)
+	})
+}
+
+// dartString returns a single-quoted Dart string literal.
+func dartString(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `'`, `\'`, `$`, `\$`)
+	return "'" + r.Replace(s) + "'"
+}
@@ -0,0 +1,831 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golang
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+	"github.com/julieqiu/librarianx/internal/sidekick/language"
+	"github.com/julieqiu/librarianx/internal/sidekick/license"
+)
+
+// runtimeNames are the identifiers defined by the generated `transport.go`
+// file. Generated types must not use them.
+var runtimeNames = []string{
+	"APIError", "Int64", "Iterator", "LongRunningOperation", "Option",
+	"Uint64", "WithAPIKey", "WithCredentials", "WithEndpoint", "WithHTTPClient",
+}
+
+type modelAnnotations struct {
+	PackageName   string
+	ImportPath    string
+	CopyrightYear string
+	BoilerPlate   []string
+	DocLines      []string
+	// All the messages and enums with a generated type, including nested and
+	// external types. Well-known types map to Go types and are not included.
+	Messages []*api.Message
+	Enums    []*api.Enum
+	// Only services with at least one generated method.
+	Services []*api.Service
+	// The imports needed by `types.go`.
+	TypesImports []string
+	// If true, the generated types use the `Int64` and `Uint64` helpers to
+	// encode repeated 64-bit integers as JSON strings.
+	HasInt64 bool
+	// Enable the runtime support for pagination, long-running operations,
+	// and auto-populated request IDs.
+	HasPagination    bool
+	HasLROs          bool
+	HasAutoPopulated bool
+}
+
+// HasTypesImports returns true if `types.go` needs any imports.
+func (m *modelAnnotations) HasTypesImports() bool {
+	return len(m.TypesImports) != 0
+}
+
+// HasServices returns true if the package has any clients.
+func (m *modelAnnotations) HasServices() bool {
+	return len(m.Services) != 0
+}
+
+type serviceAnnotations struct {
+	// The name of the client, e.g. `SecretManagerClient`.
+	Name        string
+	DocLines    []string
+	DefaultHost string
+	// Only the generated methods.
+	Methods []*api.Method
+	// Set if any method returns a long-running operation. The client polls
+	// the operations using the `GetOperation` mixin.
+	Poll *pollAnnotation
+}
+
+type pollAnnotation struct {
+	SendName    string
+	RequestType string
+	NameField   string
+}
+
+type methodAnnotation struct {
+	// The name of the client method, e.g. `CreateSecret`.
+	Name string
+	// The name of the helper that sends the request, e.g.
+	// `sendCreateSecret`.
+	SendName     string
+	DocLines     []string
+	RequestType  string
+	ResponseType string
+	// The request and response types without the pointer, e.g. `Secret`.
+	RequestElement  string
+	ResponseElement string
+	// The HTTP method, e.g. `http.MethodPost`.
+	Verb string
+	// A Go expression computing the request path.
+	Path string
+	// The path variables, which must be set in the request.
+	PathVariables []*pathVariable
+	// Go statements adding the query parameters to `query`.
+	QueryLines []string
+	// A Go expression for the request body, `nil` if there is none.
+	Body string
+	// Go statements adding the routing parameters to `params`.
+	RoutingLines []string
+	// The request ID fields, set to a new UUID4 if empty.
+	AutoPopulated []*autoPopulatedField
+	ReturnsEmpty  bool
+	// Set if the method returns a page of items, iterated by an `Iterator`.
+	Pagination *paginationAnnotation
+	// Set if the method starts a long-running operation.
+	LRO *lroAnnotation
+	// The name of the helper that resumes a long-running operation, e.g.
+	// `CreateInstanceOperation`.
+	ResumeName string
+}
+
+// IsPlain returns true if the method returns the response directly.
+func (m *methodAnnotation) IsPlain() bool {
+	return m.Pagination == nil && m.LRO == nil
+}
+
+type pathVariable struct {
+	// A Go expression for the value, e.g. `req.GetSecret().GetName()`.
+	Accessor string
+	// The field path, e.g. `secret.name`.
+	FieldPath string
+	// If true, the field is a string and the template checks it is not empty.
+	IsString bool
+}
+
+type autoPopulatedField struct {
+	Name     string
+	Optional bool
+}
+
+type paginationAnnotation struct {
+	ItemType       string
+	PageTokenField string
+	NextTokenField string
+	ItemsField     string
+}
+
+type lroAnnotation struct {
+	ResponseType string
+	MetadataType string
+}
+
+type messageAnnotation struct {
+	Name     string
+	DocLines []string
+}
+
+type fieldAnnotation struct {
+	// The name of the Go struct containing the field.
+	MessageName string
+	Name        string
+	Type        string
+	Tag         string
+	DocLines    []string
+}
+
+type enumAnnotation struct {
+	Name     string
+	DocLines []string
+}
+
+type enumValueAnnotation struct {
+	// The name of the Go type for the enum.
+	Type     string
+	Name     string
+	Value    string
+	DocLines []string
+}
+
+type annotator struct {
+	model *api.API
+	state *api.APIState
+	// The Go names for the generated messages and enums, by ID.
+	names map[string]string
+	// All the identifiers used at the package level.
+	used    map[string]bool
+	imports map[string]bool
+	int64s  bool
+}
+
+// annotateModel creates the structs used as input for the mustache templates.
+func annotateModel(model *api.API, codec *codec) *modelAnnotations {
+	a := &annotator{
+		model:   model,
+		state:   model.State,
+		names:   map[string]string{},
+		used:    map[string]bool{},
+		imports: map[string]bool{},
+	}
+	for _, name := range runtimeNames {
+		a.used[name] = true
+	}
+	var services []*api.Service
+	for _, s := range model.Services {
+		if slices.ContainsFunc(s.Methods, shouldGenerateMethod) {
+			services = append(services, s)
+			a.used[clientName(s)] = true
+		}
+	}
+	messages, enums := a.collectTypes(services)
+	for _, m := range messages {
+		a.names[m.ID] = a.uniqueName(messageName(m), m.Package)
+	}
+	for _, e := range enums {
+		a.names[e.ID] = a.uniqueName(enumName(e), e.Package)
+	}
+	for _, e := range enums {
+		a.annotateEnum(e)
+	}
+	for _, m := range messages {
+		a.annotateMessage(m)
+	}
+
+	ann := &modelAnnotations{
+		PackageName:   packageName(model, codec.packageNameOverride),
+		ImportPath:    codec.importPath,
+		CopyrightYear: codec.generationYear,
+		BoilerPlate: append(license.LicenseHeaderBulk(),
+			"",
+			" Code generated by sidekick. DO NOT EDIT."),
+		DocLines: packageDocLines(model),
+		Messages: messages,
+		Enums:    enums,
+		Services: services,
+		HasInt64: a.int64s,
+	}
+	if a.int64s {
+		a.imports["encoding/json"] = true
+		a.imports["strconv"] = true
+	}
+	for imp := range a.imports {
+		ann.TypesImports = append(ann.TypesImports, imp)
+	}
+	sort.Strings(ann.TypesImports)
+	for _, s := range services {
+		a.annotateService(s)
+		for _, m := range s.Codec.(*serviceAnnotations).Methods {
+			mAnn := m.Codec.(*methodAnnotation)
+			ann.HasPagination = ann.HasPagination || mAnn.Pagination != nil
+			ann.HasLROs = ann.HasLROs || mAnn.LRO != nil
+			ann.HasAutoPopulated = ann.HasAutoPopulated || len(mAnn.AutoPopulated) != 0
+		}
+	}
+	model.Codec = ann
+	return ann
+}
+
+// packageDocLines returns the package documentation, which follows the
+// `Package <name> ...` sentence in the `doc.go` file.
+func packageDocLines(model *api.API) []string {
+	lines := formatDocComments(model.Description)
+	if len(lines) == 0 {
+		return nil
+	}
+	return append([]string{"//"}, lines...)
+}
+
+// collectTypes returns the messages and enums that need a Go type: all the
+// types in the package, and any types they, or the services, depend on.
+func (a *annotator) collectTypes(services []*api.Service) ([]*api.Message, []*api.Enum) {
+	var messages []*api.Message
+	var enums []*api.Enum
+	seen := map[string]bool{}
+	var visit func(m *api.Message)
+	visit = func(m *api.Message) {
+		if seen[m.ID] || m.IsMap || isWellKnownType(m.ID) {
+			return
+		}
+		seen[m.ID] = true
+		messages = append(messages, m)
+		for _, e := range m.Enums {
+			if !seen[e.ID] && !isWellKnownType(e.ID) {
+				seen[e.ID] = true
+				enums = append(enums, e)
+			}
+		}
+		for _, child := range m.Messages {
+			visit(child)
+		}
+	}
+	var ids []string
+	for _, e := range a.model.Enums {
+		seen[e.ID] = true
+		enums = append(enums, e)
+		ids = append(ids, e.ID)
+	}
+	for _, m := range a.model.Messages {
+		visit(m)
+	}
+	for _, m := range messages {
+		ids = append(ids, m.ID)
+	}
+	for _, s := range services {
+		ids = append(ids, s.ID)
+	}
+	deps, err := api.FindDependencies(a.model, ids)
+	if err != nil {
+		slog.Error("cannot compute the dependencies", "error", err)
+	}
+	var external []string
+	for id := range deps {
+		if !seen[id] {
+			external = append(external, id)
+		}
+	}
+	sort.Strings(external)
+	for _, id := range external {
+		if m, ok := a.state.MessageByID[id]; ok {
+			visit(m)
+			continue
+		}
+		if e, ok := a.state.EnumByID[id]; ok && !isWellKnownType(id) && !seen[id] {
+			seen[id] = true
+			enums = append(enums, e)
+		}
+	}
+	return messages, enums
+}
+
+// uniqueName returns `name`, or a variation if it is already used.
+func (a *annotator) uniqueName(name, pkg string) string {
+	candidate := name
+	if a.used[candidate] {
+		candidate = toExported(packageName(&api.API{PackageName: pkg}, "")) + name
+	}
+	for i := 2; a.used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	a.used[candidate] = true
+	return candidate
+}
+
+func (a *annotator) annotateEnum(e *api.Enum) {
+	name := a.names[e.ID]
+	docs := formatDocComments(e.Documentation)
+	if e.Deprecated {
+		docs = appendDocParagraph(docs, "// Deprecated: Do not use.")
+	}
+	e.Codec = &enumAnnotation{Name: name, DocLines: docs}
+	// As in `protoc-gen-go`, values of nested enums are prefixed by the
+	// message name.
+	prefix := name
+	if e.Parent != nil {
+		prefix = a.typeName(e.Parent.ID)
+	}
+	for _, ev := range e.Values {
+		valueName := prefix + "_" + valueIdentifier(ev.Name)
+		if a.used[valueName] {
+			valueName = name + "_" + valueIdentifier(ev.Name)
+		}
+		for i := 2; a.used[valueName]; i++ {
+			valueName = fmt.Sprintf("%s_%s%d", name, valueIdentifier(ev.Name), i)
+		}
+		a.used[valueName] = true
+		docs := formatDocComments(ev.Documentation)
+		if ev.Deprecated {
+			docs = appendDocParagraph(docs, "// Deprecated: Do not use.")
+		}
+		ev.Codec = &enumValueAnnotation{
+			Type:     name,
+			Name:     valueName,
+			Value:    strconv.Quote(ev.Name),
+			DocLines: docs,
+		}
+	}
+}
+
+func (a *annotator) annotateMessage(m *api.Message) {
+	docs := formatDocComments(m.Documentation)
+	if m.Deprecated {
+		docs = appendDocParagraph(docs, "// Deprecated: Do not use.")
+	}
+	m.Codec = &messageAnnotation{Name: a.names[m.ID], DocLines: docs}
+	// Discovery docs and OpenAPI specs may use names, such as `fooBar` and
+	// `foo_bar`, that map to the same Go identifier.
+	names := map[string]bool{}
+	for _, f := range m.Fields {
+		name := fieldName(f)
+		for names[name] {
+			name += "_"
+		}
+		names[name] = true
+		docs := formatDocComments(f.Documentation)
+		if f.IsOneOf && f.Group != nil {
+			docs = appendDocParagraph(docs, fmt.Sprintf("// At most one of the fields in the `%s` group may be set.", f.Group.Name))
+		}
+		if f.Deprecated {
+			docs = appendDocParagraph(docs, "// Deprecated: Do not use.")
+		}
+		f.Codec = &fieldAnnotation{
+			MessageName: a.names[m.ID],
+			Name:        name,
+			Type:        a.fieldType(f),
+			Tag:         a.fieldTag(f),
+			DocLines:    docs,
+		}
+	}
+}
+
+// goName returns the name of the Go struct field for a field.
+func (a *annotator) goName(f *api.Field) string {
+	if ann, ok := f.Codec.(*fieldAnnotation); ok {
+		return ann.Name
+	}
+	return fieldName(f)
+}
+
+// fieldType returns the Go type for a field.
+func (a *annotator) fieldType(f *api.Field) string {
+	if f.Map {
+		entry, ok := a.state.MessageByID[f.TypezID]
+		if !ok || len(entry.Fields) != 2 {
+			slog.Error("unable to lookup map entry", "id", f.TypezID)
+			return "map[string]any"
+		}
+		key := scalarType(entry.Fields[0].Typez)
+		if key == "bool" || key == "" {
+			// encoding/json only supports strings and integers as keys.
+			key = "string"
+		}
+		return fmt.Sprintf("map[%s]%s", key, a.elementType(entry.Fields[1]))
+	}
+	if f.Repeated {
+		return "[]" + a.elementType(f)
+	}
+	switch f.Typez {
+	case api.MESSAGE_TYPE:
+		if wkt, ok := wellKnownTypes[f.TypezID]; ok {
+			a.useImport(wkt.Import)
+			return wkt.Singular
+		}
+		return "*" + a.typeName(f.TypezID)
+	case api.ENUM_TYPE:
+		if f.Optional {
+			return "*" + a.typeName(f.TypezID)
+		}
+		return a.typeName(f.TypezID)
+	default:
+		if f.Optional {
+			return "*" + scalarType(f.Typez)
+		}
+		return scalarType(f.Typez)
+	}
+}
+
+// elementType returns the Go type for the elements of repeated fields, and for
+// the values of map fields.
+func (a *annotator) elementType(f *api.Field) string {
+	switch f.Typez {
+	case api.MESSAGE_TYPE:
+		if wkt, ok := wellKnownTypes[f.TypezID]; ok {
+			a.useImport(wkt.Import)
+			if wkt.Element == "Int64" || wkt.Element == "Uint64" {
+				a.int64s = true
+			}
+			return wkt.Element
+		}
+		return "*" + a.typeName(f.TypezID)
+	case api.ENUM_TYPE:
+		return a.typeName(f.TypezID)
+	default:
+		if is64Bit(f.Typez) {
+			// The `,string` option in the JSON tags does not apply to the
+			// elements of slices or maps.
+			a.int64s = true
+			return strcase.ToCamel(scalarType(f.Typez))
+		}
+		return scalarType(f.Typez)
+	}
+}
+
+// fieldTag returns the struct tag for a field.
+func (a *annotator) fieldTag(f *api.Field) string {
+	name := f.JSONName
+	if name == "" {
+		name = strcase.ToLowerCamel(f.Name)
+	}
+	options := ",omitempty"
+	if !f.Map && !f.Repeated {
+		if is64Bit(f.Typez) {
+			options += ",string"
+		}
+		if wkt, ok := wellKnownTypes[f.TypezID]; ok && f.Typez == api.MESSAGE_TYPE && wkt.String {
+			options += ",string"
+		}
+	}
+	return fmt.Sprintf("`json:\"%s%s\"`", name, options)
+}
+
+// typeName returns the Go name of a message or enum.
+func (a *annotator) typeName(id string) string {
+	if wkt, ok := wellKnownTypes[id]; ok {
+		a.useImport(wkt.Import)
+		return wkt.Element
+	}
+	if name, ok := a.names[id]; ok {
+		return name
+	}
+	if m, ok := a.state.MessageByID[id]; ok {
+		return messageName(m)
+	}
+	if e, ok := a.state.EnumByID[id]; ok {
+		return enumName(e)
+	}
+	slog.Error("unable to lookup type", "id", id)
+	return "any"
+}
+
+func (a *annotator) useImport(imp string) {
+	if imp != "" {
+		a.imports[imp] = true
+	}
+}
+
+func (a *annotator) annotateService(s *api.Service) {
+	methods := language.FilterSlice(s.Methods, shouldGenerateMethod)
+	poll := a.poll(methods)
+	ann := &serviceAnnotations{
+		Name:        clientName(s),
+		DocLines:    formatDocComments(s.Documentation),
+		DefaultHost: s.DefaultHost,
+		Methods:     methods,
+	}
+	names := map[string]bool{}
+	for _, m := range methods {
+		names[toExported(m.Name)] = true
+	}
+	for _, m := range methods {
+		a.annotateMethod(m, poll, names)
+		if m.Codec.(*methodAnnotation).LRO != nil {
+			ann.Poll = poll
+		}
+	}
+	s.Codec = ann
+}
+
+func (a *annotator) annotateMethod(m *api.Method, poll *pollAnnotation, names map[string]bool) {
+	request := a.state.MessageByID[m.InputTypeID]
+	binding := m.PathInfo.Bindings[0]
+	docs := formatDocComments(m.Documentation)
+	if m.Deprecated {
+		docs = appendDocParagraph(docs, "// Deprecated: Do not use.")
+	}
+	ann := &methodAnnotation{
+		Name:            toExported(m.Name),
+		SendName:        "send" + toExported(m.Name),
+		DocLines:        docs,
+		RequestType:     a.pointerTo(m.InputTypeID),
+		ResponseType:    a.pointerTo(m.OutputTypeID),
+		RequestElement:  a.typeName(m.InputTypeID),
+		ResponseElement: a.typeName(m.OutputTypeID),
+		Verb:            httpVerb(binding.Verb),
+		ReturnsEmpty:    m.ReturnsEmpty,
+		Body:            a.body(m, request),
+	}
+	ann.Path, ann.PathVariables = a.path(binding.PathTemplate, request)
+	ann.QueryLines = a.queryLines(m, binding)
+	ann.RoutingLines = a.routingLines(m, request, ann.PathVariables)
+	for _, f := range m.AutoPopulated {
+		ann.AutoPopulated = append(ann.AutoPopulated, &autoPopulatedField{Name: a.goName(f), Optional: f.Optional})
+	}
+	ann.Pagination = a.pagination(m)
+	if poll != nil {
+		ann.LRO = a.lro(m)
+	}
+	if ann.Pagination != nil {
+		ann.DocLines = appendDocParagraph(ann.DocLines,
+			"// The iterator fetches the pages as needed, starting with the page token in",
+			"// the request, if any.")
+	}
+	if ann.LRO != nil {
+		ann.DocLines = appendDocParagraph(ann.DocLines,
+			"// The method starts a long-running operation. Use `Wait` to wait for its",
+			"// result.")
+		ann.ResumeName = ann.Name + "Operation"
+		if names[ann.ResumeName] {
+			ann.ResumeName = ann.Name + "LongRunningOperation"
+		}
+	}
+	m.Codec = ann
+}
+
+// appendDocParagraph adds a paragraph to the documentation of an element.
+func appendDocParagraph(docs []string, lines ...string) []string {
+	if len(docs) != 0 {
+		docs = append(docs, "//")
+	}
+	return append(docs, lines...)
+}
+
+// pointerTo returns the type used for requests and responses.
+func (a *annotator) pointerTo(id string) string {
+	name := a.typeName(id)
+	if strings.HasPrefix(name, "*") || strings.HasPrefix(name, "[]") || strings.HasPrefix(name, "map[") || name == "any" {
+		return name
+	}
+	return "*" + name
+}
+
+func httpVerb(verb string) string {
+	switch strings.ToUpper(verb) {
+	case "GET":
+		return "http.MethodGet"
+	case "POST":
+		return "http.MethodPost"
+	case "PUT":
+		return "http.MethodPut"
+	case "PATCH":
+		return "http.MethodPatch"
+	case "DELETE":
+		return "http.MethodDelete"
+	default:
+		return strconv.Quote(strings.ToUpper(verb))
+	}
+}
+
+// body returns the Go expression for the request body.
+func (a *annotator) body(m *api.Method, request *api.Message) string {
+	switch m.PathInfo.BodyFieldPath {
+	case "":
+		return "nil"
+	case "*":
+		return "req"
+	}
+	if request != nil {
+		for _, f := range request.Fields {
+			if f.Name == m.PathInfo.BodyFieldPath {
+				return "req." + a.goName(f)
+			}
+		}
+	}
+	slog.Error("unable to find the body field", "method", m.ID, "body", m.PathInfo.BodyFieldPath)
+	return "req"
+}
+
+// path returns the Go expression for the request path, and the variables used
+// in it.
+func (a *annotator) path(template *api.PathTemplate, request *api.Message) (string, []*pathVariable) {
+	var parts []string
+	var variables []*pathVariable
+	literal := ""
+	for _, segment := range template.Segments {
+		switch {
+		case segment.Literal != nil:
+			literal += "/" + *segment.Literal
+		case segment.Variable != nil:
+			parts = append(parts, strconv.Quote(literal+"/"))
+			literal = ""
+			accessor, field := a.accessor(segment.Variable.FieldPath, request)
+			variables = append(variables, &pathVariable{
+				Accessor:  accessor,
+				FieldPath: strings.Join(segment.Variable.FieldPath, "."),
+				IsString:  field != nil && field.Typez == api.STRING_TYPE && !field.Optional,
+			})
+			parts = append(parts, fmt.Sprintf("pathValue(%s)", accessor))
+		}
+	}
+	if template.Verb != nil {
+		literal += ":" + *template.Verb
+	}
+	if literal != "" {
+		parts = append(parts, strconv.Quote(literal))
+	}
+	return strings.Join(parts, " + "), variables
+}
+
+// accessor returns the Go expression for a (possibly nested) request field,
+// using the nil-safe getters, and the field.
+func (a *annotator) accessor(fieldPath []string, request *api.Message) (string, *api.Field) {
+	accessor := "req"
+	message := request
+	var field *api.Field
+	for _, name := range fieldPath {
+		field = nil
+		if message != nil {
+			idx := slices.IndexFunc(message.Fields, func(f *api.Field) bool { return f.Name == name })
+			if idx != -1 {
+				field = message.Fields[idx]
+			}
+		}
+		if field == nil {
+			slog.Error("unable to find the field in the request", "request", request.ID, "path", strings.Join(fieldPath, "."))
+			accessor += ".Get" + toExported(name) + "()"
+			message = nil
+			continue
+		}
+		accessor += ".Get" + a.goName(field) + "()"
+		message = a.state.MessageByID[field.TypezID]
+	}
+	return accessor, field
+}
+
+// queryLines returns the Go statements adding the query parameters.
+func (a *annotator) queryLines(m *api.Method, binding *api.PathBinding) []string {
+	if m.InputType == nil {
+		return nil
+	}
+	var lines []string
+	for _, f := range language.QueryParams(m, binding) {
+		name := f.JSONName
+		if name == "" {
+			name = strcase.ToLowerCamel(f.Name)
+		}
+		if f.Optional && !f.Repeated && !f.Map && f.Typez != api.MESSAGE_TYPE {
+			lines = append(lines, fmt.Sprintf("if req.%s != nil {", a.goName(f)))
+			lines = append(lines, fmt.Sprintf("addQuery(query, %q, *req.%s, true)", name, a.goName(f)))
+			lines = append(lines, "}")
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("addQuery(query, %q, req.%s, false)", name, a.goName(f)))
+	}
+	return lines
+}
+
+// routingLines returns the Go statements computing the
+// `x-goog-request-params` header.
+//
+// With explicit routing annotations (AIP-4222) the first matching variant of
+// each parameter is used. Otherwise, the header contains the path variables.
+func (a *annotator) routingLines(m *api.Method, request *api.Message, variables []*pathVariable) []string {
+	segments := func(s []string) string {
+		if len(s) == 0 {
+			return "nil"
+		}
+		quoted := language.MapSlice(s, strconv.Quote)
+		return "[]string{" + strings.Join(quoted, ", ") + "}"
+	}
+	var lines []string
+	if len(m.Routing) == 0 {
+		for _, v := range variables {
+			lines = append(lines, fmt.Sprintf("if v := formatValue(%s); v != \"\" {", v.Accessor))
+			lines = append(lines, fmt.Sprintf("params = append(params, routingParam(%q, v))", v.FieldPath))
+			lines = append(lines, "}")
+		}
+		return lines
+	}
+	for _, info := range m.Routing {
+		if info.Name == "" {
+			// An empty annotation disables the routing headers.
+			return nil
+		}
+	}
+	for _, info := range m.Routing {
+		for i, variant := range info.Variants {
+			accessor, _ := a.accessor(variant.FieldPath, request)
+			condition := fmt.Sprintf("if v, ok := routingValue(%s, %s, %s, %s); ok {",
+				accessor, segments(variant.Prefix.Segments), segments(variant.Matching.Segments), segments(variant.Suffix.Segments))
+			if i != 0 {
+				condition = "} else " + condition
+			}
+			lines = append(lines, condition)
+			lines = append(lines, fmt.Sprintf("params = append(params, routingParam(%q, v))", info.Name))
+		}
+		if len(info.Variants) != 0 {
+			lines = append(lines, "}")
+		}
+	}
+	return lines
+}
+
+// pagination returns the pagination annotations, if the method returns pages
+// of items that an `Iterator` can traverse.
+func (a *annotator) pagination(m *api.Method) *paginationAnnotation {
+	if m.Pagination == nil || m.Pagination.Typez != api.STRING_TYPE || m.Pagination.Optional {
+		return nil
+	}
+	response := a.state.MessageByID[m.OutputTypeID]
+	if response == nil || response.Pagination == nil {
+		return nil
+	}
+	next, items := response.Pagination.NextPageToken, response.Pagination.PageableItem
+	if next == nil || items == nil || items.Map || next.Typez != api.STRING_TYPE || next.Optional {
+		return nil
+	}
+	return &paginationAnnotation{
+		ItemType:       a.elementType(items),
+		PageTokenField: a.goName(m.Pagination),
+		NextTokenField: a.goName(next),
+		ItemsField:     a.goName(items),
+	}
+}
+
+// lro returns the annotations for long-running operations (AIP-151).
+func (a *annotator) lro(m *api.Method) *lroAnnotation {
+	if m.OperationInfo == nil {
+		return nil
+	}
+	return &lroAnnotation{
+		ResponseType: a.typeName(m.OperationInfo.ResponseTypeID),
+		MetadataType: a.typeName(m.OperationInfo.MetadataTypeID),
+	}
+}
+
+// poll returns the method used to poll long-running operations. The service
+// must include the `GetOperation` mixin, otherwise the methods returning
+// operations are generated as plain methods.
+func (a *annotator) poll(methods []*api.Method) *pollAnnotation {
+	idx := slices.IndexFunc(methods, func(m *api.Method) bool {
+		return m.InputTypeID == ".google.longrunning.GetOperationRequest"
+	})
+	if idx == -1 {
+		return nil
+	}
+	request := a.state.MessageByID[methods[idx].InputTypeID]
+	if request == nil {
+		return nil
+	}
+	field := slices.IndexFunc(request.Fields, func(f *api.Field) bool { return f.Name == "name" })
+	if field == -1 {
+		return nil
+	}
+	return &pollAnnotation{
+		SendName:    "send" + toExported(methods[idx].Name),
+		RequestType: a.typeName(methods[idx].InputTypeID),
+		NameField:   a.goName(request.Fields[field]),
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golang
+
+import (
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+	"github.com/julieqiu/librarianx/internal/sidekick/config"
+	"github.com/julieqiu/librarianx/internal/sidekick/sample"
+)
+
+// newTestModel returns the sample API used in the codec tests.
+func newTestModel(t *testing.T) *api.API {
+	t.Helper()
+	model := sample.ResourceAPI()
+	if err := api.CrossReference(model); err != nil {
+		t.Fatal(err)
+	}
+	return model
+}
+
+func TestAnnotateFields(t *testing.T) {
+	model := newTestModel(t)
+	codec, err := newCodec(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotateModel(model, codec)
+
+	resource := model.State.MessageByID[".test.v1.Resource"]
+	var got []*fieldAnnotation
+	for _, f := range resource.Fields {
+		got = append(got, f.Codec.(*fieldAnnotation))
+	}
+	want := []*fieldAnnotation{
+		{MessageName: "Resource", Name: "Name", Type: "string", Tag: "`json:\"name,omitempty\"`"},
+		{MessageName: "Resource", Name: "State", Type: "State", Tag: "`json:\"state,omitempty\"`"},
+		{MessageName: "Resource", Name: "Size", Type: "*int64", Tag: "`json:\"size,omitempty,string\"`"},
+		{MessageName: "Resource", Name: "Counts", Type: "[]Uint64", Tag: "`json:\"counts,omitempty\"`"},
+		{MessageName: "Resource", Name: "Labels", Type: "map[string]string", Tag: "`json:\"labels,omitempty\"`"},
+		{MessageName: "Resource", Name: "UpdateTime", Type: "*time.Time", Tag: "`json:\"updateTime,omitempty\"`"},
+		{MessageName: "Resource", Name: "Spec", Type: "*Resource_Spec", Tag: "`json:\"spec,omitempty\"`"},
+		{MessageName: "Resource", Name: "From", Type: "string", Tag: "`json:\"from,omitempty\"`"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+
+	ann := model.Codec.(*modelAnnotations)
+	if diff := cmp.Diff([]string{"encoding/json", "strconv", "time"}, ann.TypesImports); diff != "" {
+		t.Errorf("mismatch in imports (-want, +got):\n%s", diff)
+	}
+	var names []string
+	for _, m := range ann.Messages {
+		names = append(names, m.Codec.(*messageAnnotation).Name)
+	}
+	wantNames := []string{
+		"Resource", "Resource_Spec", "CreateResourceRequest", "ListResourcesRequest",
+		"ListResourcesResponse", "OperationMetadata", "GetOperationRequest",
+		"Operation",
+	}
+	if diff := cmp.Diff(wantNames, names); diff != "" {
+		t.Errorf("mismatch in messages (-want, +got):\n%s", diff)
+	}
+}
+
+func TestAnnotateEnumValues(t *testing.T) {
+	model := newTestModel(t)
+	// `Option` is defined by the runtime, the enum and its values must be
+	// renamed using the package name.
+	option := &api.Enum{
+		Name:    "Option",
+		ID:      ".test.v1.Option",
+		Package: "test.v1",
+		Values: []*api.EnumValue{
+			{Name: "OPTION_UNSPECIFIED"},
+			{Name: "with-dashes"},
+		},
+	}
+	model.Enums = append(model.Enums, option)
+	model.State.EnumByID[option.ID] = option
+	codec, err := newCodec(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotateModel(model, codec)
+
+	var got []*enumValueAnnotation
+	for _, e := range model.Enums {
+		for _, ev := range e.Values {
+			got = append(got, ev.Codec.(*enumValueAnnotation))
+		}
+	}
+	want := []*enumValueAnnotation{
+		{Type: "State", Name: "State_STATE_UNSPECIFIED", Value: `"STATE_UNSPECIFIED"`},
+		{Type: "State", Name: "State_ACTIVE", Value: `"ACTIVE"`},
+		{Type: "Resource_Kind", Name: "Resource_KIND_UNSPECIFIED", Value: `"KIND_UNSPECIFIED"`},
+		{Type: "Resource_Kind", Name: "Resource_LARGE", Value: `"LARGE"`},
+		{Type: "TestOption", Name: "TestOption_OPTION_UNSPECIFIED", Value: `"OPTION_UNSPECIFIED"`},
+		{Type: "TestOption", Name: "TestOption_with_dashes", Value: `"with-dashes"`},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestAnnotateMethods(t *testing.T) {
+	model := newTestModel(t)
+	codec, err := newCodec(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotateModel(model, codec)
+
+	service := model.Services[0].Codec.(*serviceAnnotations)
+	if service.Name != "ResourceClient" {
+		t.Errorf("mismatch in service name, want=ResourceClient, got=%s", service.Name)
+	}
+	if len(service.Methods) != 3 {
+		t.Errorf("the streaming method should be skipped, got=%v", service.Methods)
+	}
+	wantPoll := &pollAnnotation{SendName: "sendGetOperation", RequestType: "GetOperationRequest", NameField: "Name"}
+	if diff := cmp.Diff(wantPoll, service.Poll); diff != "" {
+		t.Errorf("mismatch in poll annotations (-want, +got):\n%s", diff)
+	}
+
+	create := model.State.MethodByID[".test.v1.ResourceService.CreateResource"].Codec.(*methodAnnotation)
+	wantCreate := &methodAnnotation{
+		Name:     "CreateResource",
+		SendName: "sendCreateResource",
+		DocLines: []string{
+			"// Creates a resource.",
+			"//",
+			"// The method starts a long-running operation. Use `Wait` to wait for its",
+			"// result.",
+		},
+		RequestType:     "*CreateResourceRequest",
+		ResponseType:    "*Operation",
+		RequestElement:  "CreateResourceRequest",
+		ResponseElement: "Operation",
+		Verb:            "http.MethodPost",
+		Path:            `"/v1/" + pathValue(req.GetParent()) + "/resources"`,
+		PathVariables: []*pathVariable{
+			{Accessor: "req.GetParent()", FieldPath: "parent", IsString: true},
+		},
+		QueryLines: []string{
+			`addQuery(query, "requestId", req.RequestId, false)`,
+			"if req.ValidateOnly != nil {",
+			`addQuery(query, "validateOnly", *req.ValidateOnly, true)`,
+			"}",
+		},
+		Body: "req.Resource",
+		RoutingLines: []string{
+			`if v, ok := routingValue(req.GetParent(), nil, []string{"projects", "*"}, nil); ok {`,
+			`params = append(params, routingParam("project", v))`,
+			"}",
+		},
+		AutoPopulated: []*autoPopulatedField{{Name: "RequestId"}},
+		LRO:           &lroAnnotation{ResponseType: "Resource", MetadataType: "OperationMetadata"},
+		ResumeName:    "CreateResourceOperation",
+	}
+	if diff := cmp.Diff(wantCreate, create); diff != "" {
+		t.Errorf("mismatch in CreateResource (-want, +got):\n%s", diff)
+	}
+
+	list := model.State.MethodByID[".test.v1.ResourceService.ListResources"].Codec.(*methodAnnotation)
+	wantPagination := &paginationAnnotation{
+		ItemType:       "*Resource",
+		PageTokenField: "PageToken",
+		NextTokenField: "NextPageToken",
+		ItemsField:     "Resources",
+	}
+	if diff := cmp.Diff(wantPagination, list.Pagination); diff != "" {
+		t.Errorf("mismatch in pagination (-want, +got):\n%s", diff)
+	}
+	wantRouting := []string{
+		`if v := formatValue(req.GetParent()); v != "" {`,
+		`params = append(params, routingParam("parent", v))`,
+		"}",
+	}
+	if diff := cmp.Diff(wantRouting, list.RoutingLines); diff != "" {
+		t.Errorf("mismatch in implicit routing (-want, +got):\n%s", diff)
+	}
+
+	ann := model.Codec.(*modelAnnotations)
+	if !ann.HasPagination || !ann.HasLROs || !ann.HasAutoPopulated {
+		t.Errorf("expected the runtime support for all features, got=%+v", ann)
+	}
+}
+
+func TestGenerateTestModel(t *testing.T) {
+	unknown := &config.Config{Codec: map[string]string{"not-an-option": "true"}}
+	if err := Generate(newTestModel(t), t.TempDir(), unknown); err == nil {
+		t.Errorf("expected an error with an unknown codec option")
+	}
+
+	discoveryLRO := newTestModel(t)
+	discoveryLRO.Services[0].Methods[0].DiscoveryLro = &api.DiscoveryLro{PollingPathParameters: []string{"project"}}
+	if err := Generate(discoveryLRO, t.TempDir(), &config.Config{}); err == nil {
+		t.Errorf("expected an error with a discovery-style LRO")
+	}
+
+	outDir := t.TempDir()
+	model := newTestModel(t)
+	if err := Generate(model, outDir, &config.Config{}); err != nil {
+		t.Fatal(err)
+	}
+	contents, err := os.ReadFile(path.Join(outDir, "client.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"func (c *ResourceClient) CreateResource(ctx context.Context, req *CreateResourceRequest) (*LongRunningOperation[Resource, OperationMetadata], error) {",
+		"func (c *ResourceClient) CreateResourceOperation(name string) *LongRunningOperation[Resource, OperationMetadata] {",
+		"func (c *ResourceClient) ListResources(ctx context.Context, req *ListResourcesRequest) *Iterator[*Resource] {",
+		"req.RequestId = newRequestID()",
+	} {
+		if !strings.Contains(string(contents), want) {
+			t.Errorf("missing %q in generated client", want)
+		}
+	}
+	buildPackage(t, outDir)
+
+	// Run the client tests from `testdata`.
+	clientTest, err := os.ReadFile("testdata/client_test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path.Join(outDir, "client_test.go"), clientTest, 0666); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("go", "test", "./...")
+	cmd.Dir = outDir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("the tests for the generated client failed: %v\n%s", err, output)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golang
+
+import (
+	"fmt"
+
+	"github.com/julieqiu/librarianx/internal/sidekick/language"
+)
+
+type codec struct {
+	// Overrides the name of the Go package, by default the last component of
+	// the protobuf package that is not a version, e.g. `secretmanager`.
+	packageNameOverride string
+	// The import path of the generated package, used in the package doc.
+	importPath string
+	// The year in the copyright headers.
+	generationYear string
+}
+
+func newCodec(options map[string]string) (*codec, error) {
+	codec := &codec{
+		generationYear: language.GenerationYear(),
+	}
+	for key, definition := range options {
+		switch key {
+		case "package-name-override":
+			codec.packageNameOverride = definition
+		case "import-path":
+			codec.importPath = definition
+		case "copyright-year":
+			codec.generationYear = definition
+		default:
+			return nil, fmt.Errorf("unknown Go codec option %q", key)
+		}
+	}
+	return codec, nil
+}
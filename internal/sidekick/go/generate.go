@@ -0,0 +1,104 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golang
+
+import (
+	"embed"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+	"github.com/julieqiu/librarianx/internal/sidekick/config"
+	"github.com/julieqiu/librarianx/internal/sidekick/language"
+)
+
+//go:embed templates
+var goTemplates embed.FS
+
+// Generate generates a Go package from the model.
+func Generate(model *api.API, outdir string, cfg *config.Config) error {
+	if err := Annotate(model, cfg); err != nil {
+		return err
+	}
+	files := generatedFiles(model)
+	if err := language.GenerateFromModel(outdir, model, templatesProvider(), files); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := formatFile(filepath.Join(outdir, file.OutputPath)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Annotate runs the Go annotations on the model, without generating any code.
+// The annotations are stored in the `Codec` field of each element.
+func Annotate(model *api.API, cfg *config.Config) error {
+	codec, err := newCodec(cfg.Codec)
+	if err != nil {
+		return err
+	}
+	// The clients poll operations with the `GetOperation` mixin, there is no
+	// support for the per-API polling methods of discovery-style LROs.
+	for _, service := range model.Services {
+		for _, method := range service.Methods {
+			if method.DiscoveryLro != nil {
+				return fmt.Errorf("discovery-style long-running operations are not supported by the Go codec, found one in %s", method.ID)
+			}
+		}
+	}
+	annotateModel(model, codec)
+	return nil
+}
+
+func templatesProvider() language.TemplateProvider {
+	return func(name string) (string, error) {
+		contents, err := goTemplates.ReadFile(filepath.ToSlash(name))
+		if err != nil {
+			return "", err
+		}
+		return string(contents), nil
+	}
+}
+
+// generatedFiles returns the files to generate. Packages without services only
+// contain the types.
+func generatedFiles(model *api.API) []language.GeneratedFile {
+	files := language.WalkTemplatesDir(goTemplates, "templates/package")
+	if model.Codec.(*modelAnnotations).HasServices() {
+		return files
+	}
+	return language.FilterSlice(files, func(f language.GeneratedFile) bool {
+		name := filepath.Base(f.OutputPath)
+		return name != "client.go" && name != "transport.go"
+	})
+}
+
+// formatFile runs `gofmt` on a generated file. A failure indicates a bug in
+// the templates or the annotations.
+func formatFile(path string) error {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	formatted, err := format.Source(contents)
+	if err != nil {
+		return fmt.Errorf("cannot format generated file %s: %w", path, err)
+	}
+	return os.WriteFile(path, formatted, 0666)
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golang
+
+import (
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/julieqiu/librarianx/internal/sidekick/config"
+	"github.com/julieqiu/librarianx/internal/sidekick/parser"
+)
+
+var testdataDir, _ = filepath.Abs("../testdata")
+
+func TestGenerate(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		cfg  *config.Config
+	}{
+		{
+			name: "protobuf",
+			cfg: &config.Config{
+				General: config.GeneralConfig{
+					SpecificationFormat: "protobuf",
+					ServiceConfig:       "google/cloud/secretmanager/v1/secretmanager_v1.yaml",
+					SpecificationSource: "google/cloud/secretmanager/v1",
+				},
+				Source: map[string]string{
+					"googleapis-root": path.Join(testdataDir, "googleapis"),
+				},
+				Codec: map[string]string{
+					"copyright-year": "2025",
+					"import-path":    "example.com/secretmanager",
+				},
+			},
+		},
+		{
+			name: "openapi",
+			cfg: &config.Config{
+				General: config.GeneralConfig{
+					SpecificationFormat: "openapi",
+					ServiceConfig:       path.Join(testdataDir, "googleapis/google/cloud/secretmanager/v1/secretmanager_v1.yaml"),
+					SpecificationSource: path.Join(testdataDir, "openapi/secretmanager_openapi_v1.json"),
+				},
+				Codec: map[string]string{
+					"package-name-override": "secretmanager",
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			outDir := t.TempDir()
+			model, err := parser.CreateModel(test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := Generate(model, outDir, test.cfg); err != nil {
+				t.Fatal(err)
+			}
+			for _, expected := range []string{"doc.go", "types.go", "client.go", "transport.go"} {
+				if _, err := os.Stat(path.Join(outDir, expected)); err != nil {
+					t.Errorf("missing %s: %v", expected, err)
+				}
+			}
+			buildPackage(t, outDir)
+		})
+	}
+}
+
+// buildPackage compiles and vets the generated code, in a module with the
+// dependencies of the generated package.
+func buildPackage(t *testing.T, dir string) {
+	t.Helper()
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("skipping the build of the generated code, go is not installed")
+	}
+	if err := os.WriteFile(path.Join(dir, "go.mod"), []byte("module example.com/generated\n\ngo 1.24\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{{"mod", "tidy"}, {"build", "./..."}, {"vet", "./..."}} {
+		cmd := exec.Command("go", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off")
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("go %v failed: %v\n%s", args, err, output)
+		}
+	}
+}
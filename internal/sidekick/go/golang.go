@@ -0,0 +1,226 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package golang implements a native Go code generator.
+//
+// The generated packages contain REST clients and plain Go structs for the
+// messages. They do not depend on protobuf types, which allows generating
+// clients from discovery docs and OpenAPI specs, where there are no protobuf
+// types to reuse. The clients authenticate with `cloud.google.com/go/auth`.
+package golang
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/iancoleman/strcase"
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+)
+
+// wellKnownType describes how a well-known type maps to Go.
+type wellKnownType struct {
+	// The type for singular fields.
+	Singular string
+	// The type for elements of repeated fields and values of map fields.
+	Element string
+	// The Go package needed to use this type, if any.
+	Import string
+	// If true, the JSON encoding of the singular type uses a string.
+	String bool
+}
+
+// wellKnownTypes maps the well-known types to Go types with the same JSON
+// encoding. The generator does not generate structs for these messages.
+var wellKnownTypes = map[string]wellKnownType{
+	".google.protobuf.Any":         {Singular: "json.RawMessage", Element: "json.RawMessage", Import: "encoding/json"},
+	".google.protobuf.BoolValue":   {Singular: "*bool", Element: "bool"},
+	".google.protobuf.BytesValue":  {Singular: "[]byte", Element: "[]byte"},
+	".google.protobuf.DoubleValue": {Singular: "*float64", Element: "float64"},
+	".google.protobuf.Duration":    {Singular: "string", Element: "string"},
+	".google.protobuf.Empty":       {Singular: "*struct{}", Element: "struct{}"},
+	".google.protobuf.FieldMask":   {Singular: "string", Element: "string"},
+	".google.protobuf.FloatValue":  {Singular: "*float32", Element: "float32"},
+	".google.protobuf.Int32Value":  {Singular: "*int32", Element: "int32"},
+	".google.protobuf.Int64Value":  {Singular: "*int64", Element: "Int64", String: true},
+	".google.protobuf.ListValue":   {Singular: "[]any", Element: "[]any"},
+	".google.protobuf.StringValue": {Singular: "*string", Element: "string"},
+	".google.protobuf.Struct":      {Singular: "map[string]any", Element: "map[string]any"},
+	".google.protobuf.Timestamp":   {Singular: "*time.Time", Element: "time.Time", Import: "time"},
+	".google.protobuf.UInt32Value": {Singular: "*uint32", Element: "uint32"},
+	".google.protobuf.UInt64Value": {Singular: "*uint64", Element: "Uint64", String: true},
+	".google.protobuf.Value":       {Singular: "any", Element: "any"},
+	".google.protobuf.NullValue":   {Singular: "any", Element: "any"},
+}
+
+// isWellKnownType returns true if the message or enum ID maps to a Go type.
+func isWellKnownType(id string) bool {
+	_, ok := wellKnownTypes[id]
+	return ok
+}
+
+// scalarType returns the Go type for scalar fields.
+func scalarType(typez api.Typez) string {
+	switch typez {
+	case api.BOOL_TYPE:
+		return "bool"
+	case api.STRING_TYPE:
+		return "string"
+	case api.BYTES_TYPE:
+		return "[]byte"
+	case api.INT32_TYPE, api.SINT32_TYPE, api.SFIXED32_TYPE:
+		return "int32"
+	case api.INT64_TYPE, api.SINT64_TYPE, api.SFIXED64_TYPE:
+		return "int64"
+	case api.UINT32_TYPE, api.FIXED32_TYPE:
+		return "uint32"
+	case api.UINT64_TYPE, api.FIXED64_TYPE:
+		return "uint64"
+	case api.FLOAT_TYPE:
+		return "float32"
+	case api.DOUBLE_TYPE:
+		return "float64"
+	default:
+		return ""
+	}
+}
+
+// is64Bit returns true for the integer types encoded as JSON strings.
+func is64Bit(typez api.Typez) bool {
+	switch typez {
+	case api.INT64_TYPE, api.SINT64_TYPE, api.SFIXED64_TYPE,
+		api.UINT64_TYPE, api.FIXED64_TYPE:
+		return true
+	default:
+		return false
+	}
+}
+
+// messageName returns the Go name for a message. Nested messages use the same
+// `Parent_Child` convention as `protoc-gen-go`.
+func messageName(m *api.Message) string {
+	name := toExported(m.Name)
+	if m.Parent != nil {
+		return messageName(m.Parent) + "_" + name
+	}
+	return name
+}
+
+// enumName returns the Go name for an enum.
+func enumName(e *api.Enum) string {
+	name := toExported(e.Name)
+	if e.Parent != nil {
+		return messageName(e.Parent) + "_" + name
+	}
+	return name
+}
+
+// fieldName returns the Go name for a field.
+func fieldName(f *api.Field) string {
+	return toExported(f.Name)
+}
+
+var nonIdentifier = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// toExported converts a name in any of the conventions used by the
+// specification formats to an exported Go identifier.
+func toExported(name string) string {
+	name = strcase.ToCamel(nonIdentifier.ReplaceAllString(name, "_"))
+	if name == "" || !unicode.IsLetter(rune(name[0])) {
+		name = "X" + name
+	}
+	return name
+}
+
+// valueIdentifier converts an enum value, which may be any string in
+// discovery docs and OpenAPI specs, to a valid suffix for a Go identifier.
+func valueIdentifier(name string) string {
+	name = strings.Trim(nonIdentifier.ReplaceAllString(name, "_"), "_")
+	if name == "" {
+		return "EMPTY"
+	}
+	return name
+}
+
+// commentRefsRegex finds cross-references in the documentation, such as
+// `[Secret][google.cloud.secretmanager.v1.Secret]`, and `[Secret][]`.
+var commentRefsRegex = regexp.MustCompile(`\[([^\]]+)\]\[([\w\.]*)\]`)
+
+// formatDocComments converts the documentation of an element into Go comment
+// lines.
+//
+// Go doc comments have no syntax for cross-references outside the package,
+// the references are replaced by their text.
+func formatDocComments(documentation string) []string {
+	if documentation == "" {
+		return nil
+	}
+	lines := strings.Split(documentation, "\n")
+	for i, line := range lines {
+		line = strings.TrimRightFunc(line, unicode.IsSpace)
+		lines[i] = commentRefsRegex.ReplaceAllString(line, "$1")
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = "//"
+		} else {
+			lines[i] = "// " + line
+		}
+	}
+	return lines
+}
+
+// packageName returns the name of the generated Go package, e.g.
+// `secretmanager` for `google.cloud.secretmanager.v1`.
+func packageName(model *api.API, override string) string {
+	if override != "" {
+		return override
+	}
+	parts := strings.Split(model.PackageName, ".")
+	for i := len(parts) - 1; i >= 0; i-- {
+		if parts[i] != "" && !isVersion(parts[i]) {
+			return strings.ToLower(nonIdentifier.ReplaceAllString(parts[i], ""))
+		}
+	}
+	return strings.ToLower(nonIdentifier.ReplaceAllString(model.Name, ""))
+}
+
+func isVersion(s string) bool {
+	return len(s) > 1 && s[0] == 'v' && unicode.IsDigit(rune(s[1]))
+}
+
+// clientName returns the name of the client for a service, e.g.
+// `SecretManagerClient` for `SecretManagerService`.
+func clientName(s *api.Service) string {
+	name := toExported(s.Name)
+	if trimmed := strings.TrimSuffix(name, "Service"); trimmed != "" {
+		name = trimmed
+	}
+	return name + "Client"
+}
+
+// shouldGenerateMethod returns true if the method can be called with the REST
+// transport.
+func shouldGenerateMethod(m *api.Method) bool {
+	if m.ClientSideStreaming || m.ServerSideStreaming {
+		return false
+	}
+	if m.PathInfo == nil || len(m.PathInfo.Bindings) == 0 {
+		return false
+	}
+	return m.PathInfo.Bindings[0].PathTemplate != nil
+}
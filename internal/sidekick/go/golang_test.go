@@ -0,0 +1,125 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+)
+
+func TestToExported(t *testing.T) {
+	for _, test := range []struct {
+		input string
+		want  string
+	}{
+		{"secret_id", "SecretId"},
+		{"displayName", "DisplayName"},
+		{"kebab-case", "KebabCase"},
+		{"$alt", "Alt"},
+		{"1st", "X1St"},
+		{"", "X"},
+	} {
+		if got := toExported(test.input); got != test.want {
+			t.Errorf("toExported(%q) = %q, want %q", test.input, got, test.want)
+		}
+	}
+}
+
+func TestValueIdentifier(t *testing.T) {
+	for _, test := range []struct {
+		input string
+		want  string
+	}{
+		{"ACTIVE", "ACTIVE"},
+		{"with-dashes", "with_dashes"},
+		{"a.b/c", "a_b_c"},
+		{"-", "EMPTY"},
+	} {
+		if got := valueIdentifier(test.input); got != test.want {
+			t.Errorf("valueIdentifier(%q) = %q, want %q", test.input, got, test.want)
+		}
+	}
+}
+
+func TestFormatDocComments(t *testing.T) {
+	input := `Creates a [Secret][google.cloud.secretmanager.v1.Secret].   
+
+Uses the [parent][] field.
+`
+	want := []string{
+		"// Creates a Secret.",
+		"//",
+		"// Uses the parent field.",
+	}
+	if diff := cmp.Diff(want, formatDocComments(input)); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+	if got := formatDocComments(""); got != nil {
+		t.Errorf("expected no comments for empty documentation, got=%v", got)
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	for _, test := range []struct {
+		packageName string
+		override    string
+		want        string
+	}{
+		{"google.cloud.secretmanager.v1", "", "secretmanager"},
+		{"google.cloud.foo.v1beta2", "", "foo"},
+		{"google.cloud.secretmanager.v1", "sm", "sm"},
+		{"", "", "test"},
+	} {
+		model := &api.API{Name: "Test", PackageName: test.packageName}
+		if got := packageName(model, test.override); got != test.want {
+			t.Errorf("packageName(%q, %q) = %q, want %q", test.packageName, test.override, got, test.want)
+		}
+	}
+}
+
+func TestClientName(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		want string
+	}{
+		{"SecretManagerService", "SecretManagerClient"},
+		{"Pets", "PetsClient"},
+		{"Service", "ServiceClient"},
+	} {
+		if got := clientName(&api.Service{Name: test.name}); got != test.want {
+			t.Errorf("clientName(%q) = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestNewCodec(t *testing.T) {
+	got, err := newCodec(map[string]string{
+		"package-name-override": "sm",
+		"import-path":           "example.com/sm",
+		"copyright-year":        "2024",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &codec{packageNameOverride: "sm", importPath: "example.com/sm", generationYear: "2024"}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(codec{})); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+	if _, err := newCodec(map[string]string{"unknown": "true"}); err == nil {
+		t.Errorf("expected an error with an unknown option")
+	}
+}
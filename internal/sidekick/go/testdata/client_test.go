@@ -0,0 +1,143 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file is copied into the package generated from `sample.ResourceAPI()`,
+// and tests the generated client against a fake service.
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestCreateResource(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/projects/my-project/resources":
+			if got := r.Header.Get("x-goog-request-params"); got != "project=projects%2Fmy-project" {
+				t.Errorf("mismatch in routing header, got=%q", got)
+			}
+			id := r.URL.Query().Get("requestId")
+			if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(id) {
+				t.Errorf("the request ID is not a UUID4, got=%q", id)
+			}
+			body, _ := io.ReadAll(r.Body)
+			var resource Resource
+			if err := json.Unmarshal(body, &resource); err != nil || resource.Name != "r1" {
+				t.Errorf("mismatch in request body, got=%s", body)
+			}
+			w.Write([]byte(`{"name": "projects/my-project/operations/op1"}`))
+		case "/v1/projects/my-project/operations/op1":
+			polls++
+			if polls < 2 {
+				w.Write([]byte(`{"name": "projects/my-project/operations/op1", "metadata": {"createTime": "2025-01-02T03:04:05Z"}}`))
+				return
+			}
+			w.Write([]byte(`{"name": "projects/my-project/operations/op1", "done": true, "response": {"name": "r1", "size": "42", "counts": ["1", 2]}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	ctx := context.Background()
+	op, err := client.CreateResource(ctx, &CreateResourceRequest{
+		Parent:   "projects/my-project",
+		Resource: &Resource{Name: "r1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result, err := op.Poll(ctx); err != nil || result != nil {
+		t.Fatalf("expected a pending operation, got=%v, %v", result, err)
+	}
+	metadata, err := op.Metadata()
+	if err != nil || metadata.GetCreateTime() == nil {
+		t.Errorf("expected metadata, got=%v, %v", metadata, err)
+	}
+	result, err := client.CreateResourceOperation(op.Name()).Wait(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.GetName() != "r1" || *result.GetSize() != 42 || len(result.GetCounts()) != 2 {
+		t.Errorf("mismatch in result, got=%+v", result)
+	}
+}
+
+func TestListResources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("pageToken") {
+		case "":
+			w.Write([]byte(`{"resources": [{"name": "r1"}, {"name": "r2"}], "nextPageToken": "p2"}`))
+		case "p2":
+			w.Write([]byte(`{"resources": [{"name": "r3"}]}`))
+		default:
+			http.Error(w, `{"error": {"code": 400, "message": "bad token", "status": "INVALID_ARGUMENT"}}`, http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	var names []string
+	for resource, err := range client.ListResources(context.Background(), &ListResourcesRequest{Parent: "projects/p"}).All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, resource.GetName())
+	}
+	if len(names) != 3 || names[2] != "r3" {
+		t.Errorf("mismatch in items, got=%v", names)
+	}
+
+	_, err := client.ListResources(context.Background(), &ListResourcesRequest{Parent: "projects/p", PageToken: "bad"}).Next()
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPCode != http.StatusBadRequest || apiErr.Status != "INVALID_ARGUMENT" {
+		t.Errorf("expected an APIError, got=%v", err)
+	}
+}
+
+func TestMissingPathField(t *testing.T) {
+	client, err := NewResourceClient(WithEndpoint("http://localhost:0"), WithAPIKey("test-key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreateResource(context.Background(), &CreateResourceRequest{}); err == nil {
+		t.Errorf("expected an error with a missing path field")
+	}
+}
+
+func TestDefaultCredentials(t *testing.T) {
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", filepath.Join(t.TempDir(), "missing.json"))
+	if _, err := NewResourceClient(); err == nil {
+		t.Errorf("expected an error when the default credentials are missing")
+	}
+}
+
+func newTestClient(t *testing.T, server *httptest.Server) *ResourceClient {
+	t.Helper()
+	client, err := NewResourceClient(WithEndpoint(server.URL), WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
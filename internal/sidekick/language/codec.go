@@ -17,7 +17,9 @@
 package language
 
 import (
+	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/julieqiu/librarianx/internal/sidekick/api"
 )
@@ -37,6 +39,12 @@ type GeneratedFile struct {
 // then return the full contents of the template (or an error).
 type TemplateProvider func(templateName string) (string, error)
 
+// GenerationYear returns the current year, for the copyright headers.
+func GenerationYear() string {
+	year, _, _ := time.Now().Date()
+	return fmt.Sprintf("%04d", year)
+}
+
 // PathParams returns the path parameters for a method.
 func PathParams(m *api.Method, state *api.APIState) []*api.Field {
 	msg, ok := state.MessageByID[m.InputTypeID]
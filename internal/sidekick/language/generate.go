@@ -48,27 +48,7 @@ func (p *mustacheProvider) Get(name string) (string, error) {
 func GenerateFromModel(outdir string, model *api.API, provider TemplateProvider, generatedFiles []GeneratedFile) error {
 	var errs []error
 	for _, gen := range generatedFiles {
-		templateContents, err := provider(gen.TemplatePath)
-		if err != nil {
-			errs = append(errs, err)
-			continue
-		}
-		if outdir == "" {
-			wd, _ := os.Getwd()
-			outdir = wd
-		}
-		destination := filepath.Join(outdir, gen.OutputPath)
-		os.MkdirAll(filepath.Dir(destination), 0777) // Ignore errors
-		nestedProvider := mustacheProvider{
-			impl:    provider,
-			dirname: filepath.Dir(gen.TemplatePath),
-		}
-		s, err := mustache.RenderPartials(templateContents, &nestedProvider, model)
-		if err != nil {
-			errs = append(errs, err)
-			continue
-		}
-		if err := os.WriteFile(destination, []byte(s), 0666); err != nil {
+		if err := renderTemplate(outdir, provider, gen.TemplatePath, gen.OutputPath, model); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -77,3 +57,27 @@ func GenerateFromModel(outdir string, model *api.API, provider TemplateProvider,
 	}
 	return nil
 }
+
+// renderTemplate renders a single template, using `data` as the input, into
+// `outputPath` relative to `outdir`.
+func renderTemplate(outdir string, provider TemplateProvider, templatePath, outputPath string, data any) error {
+	templateContents, err := provider(templatePath)
+	if err != nil {
+		return err
+	}
+	if outdir == "" {
+		wd, _ := os.Getwd()
+		outdir = wd
+	}
+	destination := filepath.Join(outdir, outputPath)
+	os.MkdirAll(filepath.Dir(destination), 0777) // Ignore errors
+	nestedProvider := mustacheProvider{
+		impl:    provider,
+		dirname: filepath.Dir(templatePath),
+	}
+	s, err := mustache.RenderPartials(templateContents, &nestedProvider, data)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(destination, []byte(s), 0666)
+}
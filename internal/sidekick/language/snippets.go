@@ -0,0 +1,115 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package language
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+)
+
+// Snippet is a code sample for a single method.
+type Snippet struct {
+	// The method demonstrated by the sample.
+	Method *api.Method
+	// The template used to render the sample.
+	TemplatePath string
+	// The output file, relative to the output directory.
+	OutputPath string
+	// The region tag wrapping the sample, see [RegionTag].
+	RegionTag string
+	// The input to the mustache template.
+	Data any
+}
+
+// SnippetIndex is the index of the code samples generated for a library.
+//
+// Documentation tooling uses the index to find the sample for each method.
+type SnippetIndex struct {
+	Language string              `json:"language"`
+	Snippets []SnippetIndexEntry `json:"snippets"`
+}
+
+// SnippetIndexEntry describes the code sample for a single method.
+type SnippetIndexEntry struct {
+	// The fully qualified name of the method, e.g.
+	// `google.cloud.secretmanager.v1.SecretManagerService.CreateSecret`.
+	Method string `json:"method"`
+	// The sample file, relative to the output directory.
+	File string `json:"file"`
+	// The region tags in the sample file.
+	RegionTags []string `json:"region_tags"`
+}
+
+// GenerateSnippets renders one code sample per snippet, and writes the index
+// of the samples to `indexPath`. All paths are relative to `outdir`.
+func GenerateSnippets(outdir, indexPath, language string, provider TemplateProvider, snippets []Snippet) error {
+	index := SnippetIndex{Language: language, Snippets: []SnippetIndexEntry{}}
+	var errs []error
+	for _, snippet := range snippets {
+		if err := renderTemplate(outdir, provider, snippet.TemplatePath, snippet.OutputPath, snippet.Data); err != nil {
+			errs = append(errs, fmt.Errorf("generating sample for %s: %w", snippet.Method.ID, err))
+			continue
+		}
+		index.Snippets = append(index.Snippets, SnippetIndexEntry{
+			Method:     strings.TrimPrefix(snippet.Method.ID, "."),
+			File:       filepath.ToSlash(snippet.OutputPath),
+			RegionTags: []string{snippet.RegionTag},
+		})
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("errors generating samples: %w", errors.Join(errs...))
+	}
+	contents, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	destination := filepath.Join(outdir, indexPath)
+	if err := os.MkdirAll(filepath.Dir(destination), 0777); err != nil {
+		return err
+	}
+	return os.WriteFile(destination, append(contents, '\n'), 0666)
+}
+
+// RegionTag returns the region tag for the code sample of a method.
+//
+// The tags follow the format used by the samples in other Google Cloud client
+// libraries, e.g., `secretmanager_v1_generated_SecretManagerService_CreateSecret_async`.
+// The generated clients are asynchronous, thus the `_async` suffix.
+func RegionTag(method *api.Method) string {
+	var pkg, serviceName string
+	switch {
+	case method.Service != nil:
+		pkg = method.Service.Package
+		serviceName = method.Service.Name
+	case method.Model != nil:
+		pkg = method.Model.PackageName
+	}
+	parts := strings.Split(pkg, ".")
+	prefix := parts[len(parts)-1]
+	if len(parts) > 1 && isVersion(prefix) {
+		prefix = parts[len(parts)-2] + "_" + prefix
+	}
+	return fmt.Sprintf("%s_generated_%s_%s_async", prefix, serviceName, method.Name)
+}
+
+func isVersion(s string) bool {
+	return len(s) > 1 && s[0] == 'v' && s[1] >= '0' && s[1] <= '9'
+}
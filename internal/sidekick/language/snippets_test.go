@@ -0,0 +1,135 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package language
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+)
+
+func TestGenerateSnippets(t *testing.T) {
+	service := &api.Service{Name: "SecretManagerService", Package: "google.cloud.secretmanager.v1"}
+	method := &api.Method{
+		Name:    "CreateSecret",
+		ID:      ".google.cloud.secretmanager.v1.SecretManagerService.CreateSecret",
+		Service: service,
+	}
+	provider := func(name string) (string, error) {
+		contents, err := os.ReadFile(name)
+		if err != nil {
+			return "", err
+		}
+		return string(contents), nil
+	}
+	type data struct {
+		Name      string
+		RegionTag string
+	}
+	tag := RegionTag(method)
+	outDir := t.TempDir()
+	snippets := []Snippet{{
+		Method:       method,
+		TemplatePath: "testSnippets/sample.txt.mustache",
+		OutputPath:   "examples/create_secret.txt",
+		RegionTag:    tag,
+		Data:         data{Name: method.Name, RegionTag: tag},
+	}}
+	if err := GenerateSnippets(outDir, "examples/snippet_index.json", "test", provider, snippets); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(outDir, "examples", "create_secret.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `// [START secretmanager_v1_generated_SecretManagerService_CreateSecret_async]
+CreateSecret
+// [END secretmanager_v1_generated_SecretManagerService_CreateSecret_async]
+`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+
+	contents, err := os.ReadFile(filepath.Join(outDir, "examples", "snippet_index.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotIndex SnippetIndex
+	if err := json.Unmarshal(contents, &gotIndex); err != nil {
+		t.Fatal(err)
+	}
+	wantIndex := SnippetIndex{
+		Language: "test",
+		Snippets: []SnippetIndexEntry{{
+			Method:     "google.cloud.secretmanager.v1.SecretManagerService.CreateSecret",
+			File:       "examples/create_secret.txt",
+			RegionTags: []string{"secretmanager_v1_generated_SecretManagerService_CreateSecret_async"},
+		}},
+	}
+	if diff := cmp.Diff(wantIndex, gotIndex); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestGenerateSnippetsMissingTemplate(t *testing.T) {
+	method := &api.Method{Name: "CreateSecret", ID: ".test.Service.CreateSecret"}
+	provider := func(name string) (string, error) {
+		contents, err := os.ReadFile(name)
+		return string(contents), err
+	}
+	outDir := t.TempDir()
+	snippets := []Snippet{{
+		Method:       method,
+		TemplatePath: "testSnippets/missing.txt.mustache",
+		OutputPath:   "examples/create_secret.txt",
+	}}
+	if err := GenerateSnippets(outDir, "examples/snippet_index.json", "test", provider, snippets); err == nil {
+		t.Errorf("expected an error with a missing template")
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "examples", "snippet_index.json")); err == nil {
+		t.Errorf("the index should not be written when a sample fails")
+	}
+}
+
+func TestRegionTag(t *testing.T) {
+	for _, test := range []struct {
+		method *api.Method
+		want   string
+	}{
+		{
+			method: &api.Method{
+				Name:    "GetSecret",
+				Service: &api.Service{Name: "SecretManagerService", Package: "google.cloud.secretmanager.v1"},
+			},
+			want: "secretmanager_v1_generated_SecretManagerService_GetSecret_async",
+		},
+		{
+			method: &api.Method{
+				Name:    "GetOperation",
+				Service: &api.Service{Name: "Operations", Package: "google.longrunning"},
+			},
+			want: "longrunning_generated_Operations_GetOperation_async",
+		},
+	} {
+		if got := RegionTag(test.method); got != test.want {
+			t.Errorf("RegionTag(%s) = %q, want %q", test.method.Name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+	"github.com/julieqiu/librarianx/internal/sidekick/config"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// ParseDescriptorSet reads a binary `FileDescriptorSet`, such as the output
+// of `protoc --descriptor_set_out` or `buf build`, and converts it into the
+// `api.API` model.
+//
+// The set must include the imports of the files to generate, and should
+// include source info, otherwise the model has no documentation.
+func ParseDescriptorSet(cfg *config.Config) (*api.API, error) {
+	contents, err := os.ReadFile(cfg.General.SpecificationSource)
+	if err != nil {
+		return nil, err
+	}
+	descriptors := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(contents, descriptors); err != nil {
+		return nil, fmt.Errorf("cannot parse descriptor set %s: %w", cfg.General.SpecificationSource, err)
+	}
+	request, err := newCodeGeneratorRequestFromSet(descriptors, cfg.Source)
+	if err != nil {
+		return nil, err
+	}
+	serviceConfig, err := loadServiceConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return makeAPIForProtobuf(serviceConfig, request), nil
+}
+
+// newCodeGeneratorRequestFromSet selects the files to generate from
+// descriptors.
+//
+// With the `include-list` source option, those are the files in the list,
+// named as they are in the set. Otherwise they are the files in the
+// directory of the last file in the set: tools emit imports before the files
+// importing them, so the last file always belongs to the API. The
+// `exclude-list` source option removes files from the selection.
+func newCodeGeneratorRequestFromSet(descriptors *descriptorpb.FileDescriptorSet, options map[string]string) (*pluginpb.CodeGeneratorRequest, error) {
+	if len(descriptors.File) == 0 {
+		return nil, fmt.Errorf("the descriptor set has no files")
+	}
+	if _, ok := options["include-list"]; ok {
+		if _, ok := options["exclude-list"]; ok {
+			return nil, fmt.Errorf("cannot use both `exclude-list` and `include-list` in the source options")
+		}
+	}
+
+	var selected func(name string) bool
+	if list, ok := options["include-list"]; ok {
+		include := strings.Split(list, ",")
+		selected = func(name string) bool { return slices.Contains(include, name) }
+	} else {
+		dir := path.Dir(descriptors.File[len(descriptors.File)-1].GetName())
+		selected = func(name string) bool { return path.Dir(name) == dir }
+	}
+	var exclude []string
+	if list, ok := options["exclude-list"]; ok {
+		exclude = strings.Split(list, ",")
+	}
+
+	request := &pluginpb.CodeGeneratorRequest{
+		ProtoFile:       descriptors.File,
+		CompilerVersion: newCompilerVersion(),
+	}
+	for _, file := range descriptors.File {
+		name := file.GetName()
+		if !selected(name) || slices.Contains(exclude, name) {
+			continue
+		}
+		request.FileToGenerate = append(request.FileToGenerate, name)
+		request.SourceFileDescriptors = append(request.SourceFileDescriptors, file)
+	}
+	if len(request.FileToGenerate) == 0 {
+		return nil, fmt.Errorf("no files to generate in the descriptor set")
+	}
+	return request, nil
+}
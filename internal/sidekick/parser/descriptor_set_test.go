@@ -0,0 +1,146 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/sidekick/config"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// writeDescriptorSet compiles the secretmanager protos into a descriptor set
+// and returns its path.
+func writeDescriptorSet(t *testing.T) string {
+	t.Helper()
+	source := path.Join(testdataDir, "googleapis", "google/cloud/secretmanager/v1")
+	options := map[string]string{"googleapis-root": path.Join(testdataDir, "googleapis")}
+	files := []string{path.Join(source, "resources.proto"), path.Join(source, "service.proto")}
+	contents, err := compile(files, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filename := filepath.Join(t.TempDir(), "secretmanager.binpb")
+	if err := os.WriteFile(filename, contents, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestCreateModelDescriptorSet(t *testing.T) {
+	cfg := &config.Config{
+		General: config.GeneralConfig{
+			SpecificationFormat: "descriptor-set",
+			ServiceConfig:       secretManagerYamlFullPath,
+			SpecificationSource: writeDescriptorSet(t),
+		},
+	}
+	model, err := CreateModel(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	service, ok := model.State.ServiceByID[".google.cloud.secretmanager.v1.SecretManagerService"]
+	if !ok {
+		t.Fatalf("missing service (.google.cloud.secretmanager.v1.SecretManagerService) in ServiceByID index")
+	}
+	if service.Documentation == "" {
+		t.Errorf("missing service documentation, the source info was lost")
+	}
+	if _, ok := model.State.MessageByID[".google.cloud.secretmanager.v1.Secret"]; !ok {
+		t.Errorf("missing message (.google.cloud.secretmanager.v1.Secret) in MessageByID index")
+	}
+}
+
+func TestDescriptorSetFilesToGenerate(t *testing.T) {
+	descriptors := &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{
+			{Name: proto.String("google/api/annotations.proto")},
+			{Name: proto.String("google/cloud/secretmanager/v1/resources.proto")},
+			{Name: proto.String("google/cloud/secretmanager/v1/service.proto")},
+		},
+	}
+	for _, test := range []struct {
+		name    string
+		options map[string]string
+		want    []string
+	}{
+		{
+			name: "directory of the last file",
+			want: []string{
+				"google/cloud/secretmanager/v1/resources.proto",
+				"google/cloud/secretmanager/v1/service.proto",
+			},
+		},
+		{
+			name:    "include list",
+			options: map[string]string{"include-list": "google/cloud/secretmanager/v1/service.proto"},
+			want:    []string{"google/cloud/secretmanager/v1/service.proto"},
+		},
+		{
+			name:    "exclude list",
+			options: map[string]string{"exclude-list": "google/cloud/secretmanager/v1/service.proto"},
+			want:    []string{"google/cloud/secretmanager/v1/resources.proto"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := newCodeGeneratorRequestFromSet(descriptors, test.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, request.FileToGenerate); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+			if len(request.SourceFileDescriptors) != len(test.want) {
+				t.Errorf("got %d source file descriptors, want %d", len(request.SourceFileDescriptors), len(test.want))
+			}
+		})
+	}
+}
+
+func TestDescriptorSetErrors(t *testing.T) {
+	descriptors := &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{{Name: proto.String("a/b.proto")}},
+	}
+	for _, test := range []struct {
+		name        string
+		descriptors *descriptorpb.FileDescriptorSet
+		options     map[string]string
+	}{
+		{"empty set", &descriptorpb.FileDescriptorSet{}, nil},
+		{"include and exclude", descriptors, map[string]string{"include-list": "a/b.proto", "exclude-list": "a/c.proto"}},
+		{"nothing selected", descriptors, map[string]string{"include-list": "a/c.proto"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := newCodeGeneratorRequestFromSet(test.descriptors, test.options); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+
+	cfg := &config.Config{
+		General: config.GeneralConfig{
+			SpecificationFormat: "descriptor-set",
+			SpecificationSource: secretManagerYamlFullPath,
+		},
+	}
+	if got, err := CreateModel(cfg); err == nil {
+		t.Errorf("expected an error parsing a file that is not a descriptor set, got=%v", got)
+	}
+}
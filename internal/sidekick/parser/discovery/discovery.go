@@ -209,14 +209,14 @@ func (a *auth) UnmarshalJSON(data []byte) error {
 // https://tools.ietf.org/html/draft-zyp-json-schema-03#section-5.1.
 // We only support the subset of JSON schema needed for Google API generation.
 type schema struct {
-	ID                   string // union types not supported
-	Type                 string // union types not supported
+	ID                   string
+	Type                 string // empty for union types, see `Variants`
 	Format               string
 	Description          string
 	Properties           propertyList
-	ItemSchema           *schema `json:"items"` // array of schemas not supported
-	AdditionalProperties *schema // boolean not supported
-	Ref                  string  `json:"$ref"`
+	ItemSchema           *schema `json:"items"`
+	AdditionalProperties *schema
+	Ref                  string `json:"$ref"`
 	Default              string
 	Pattern              string
 	Deprecated           bool
@@ -225,6 +225,9 @@ type schema struct {
 	EnumDescriptions []string
 	EnumDeprecated   []bool
 	Variant          *variant
+	// The alternatives for union types. These come from `type` arrays,
+	// `anyOf` lists, and tuple-like `items` arrays.
+	Variants []*schema `json:"anyOf"`
 
 	RefSchema *schema `json:"-"` // Schema referred to by $ref
 	Name      string  `json:"-"` // Schema name, if top level
@@ -241,6 +244,100 @@ type variantMapItem struct {
 	Ref       string `json:"$ref"`
 }
 
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// Discovery docs follow JSON Schema draft 3. In this draft `type` may be an
+// array of type names or schemas, `items` may be an array of schemas (a
+// tuple), and `additionalProperties` may be a boolean.
+func (s *schema) UnmarshalJSON(data []byte) error {
+	type plain schema
+	aux := struct {
+		*plain
+		Type                 json.RawMessage `json:"type"`
+		ItemSchema           json.RawMessage `json:"items"`
+		AdditionalProperties json.RawMessage `json:"additionalProperties"`
+	}{plain: (*plain)(s)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if err := s.unmarshalType(aux.Type); err != nil {
+		return err
+	}
+	if err := s.unmarshalItems(aux.ItemSchema); err != nil {
+		return err
+	}
+	return s.unmarshalAdditionalProperties(aux.AdditionalProperties)
+}
+
+func (s *schema) unmarshalType(data json.RawMessage) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, &s.Type); err == nil {
+		return nil
+	}
+	var alternatives []json.RawMessage
+	if err := json.Unmarshal(data, &alternatives); err != nil {
+		return fmt.Errorf("type must be a string or an array: %w", err)
+	}
+	var variants []*schema
+	for _, a := range alternatives {
+		variant := &schema{}
+		if err := json.Unmarshal(a, &variant.Type); err != nil {
+			if err := json.Unmarshal(a, variant); err != nil {
+				return err
+			}
+		}
+		if variant.Type == "null" {
+			// Discovery fields are always optional, `null` adds nothing.
+			continue
+		}
+		variants = append(variants, variant)
+	}
+	if len(variants) == 1 && variants[0].Ref == "" && len(variants[0].Properties) == 0 {
+		s.Type = variants[0].Type
+		return nil
+	}
+	s.Variants = append(s.Variants, variants...)
+	return nil
+}
+
+func (s *schema) unmarshalItems(data json.RawMessage) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if data[0] != '[' {
+		return json.Unmarshal(data, &s.ItemSchema)
+	}
+	var tuple []*schema
+	if err := json.Unmarshal(data, &tuple); err != nil {
+		return err
+	}
+	switch len(tuple) {
+	case 0:
+		return fmt.Errorf("empty items array in schema %q", s.ID)
+	case 1:
+		s.ItemSchema = tuple[0]
+	default:
+		s.ItemSchema = &schema{Variants: tuple}
+	}
+	return nil
+}
+
+func (s *schema) unmarshalAdditionalProperties(data json.RawMessage) error {
+	if len(data) == 0 {
+		return nil
+	}
+	var allowed bool
+	if err := json.Unmarshal(data, &allowed); err == nil {
+		if allowed {
+			s.AdditionalProperties = &schema{Type: "any"}
+		}
+		return nil
+	}
+	return json.Unmarshal(data, &s.AdditionalProperties)
+}
+
 func (s *schema) init(topLevelSchemas map[string]*schema) error {
 	if s == nil {
 		return nil
@@ -272,6 +369,11 @@ func (s *schema) init(topLevelSchemas map[string]*schema) error {
 			return err
 		}
 	}
+	for _, v := range s.Variants {
+		if err := v.init(topLevelSchemas); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -287,6 +389,9 @@ func (s *schema) initKind() (kind, error) {
 	if s.Ref != "" {
 		return ReferenceKind, nil
 	}
+	if len(s.Variants) != 0 {
+		return UnionKind, nil
+	}
 	switch s.Type {
 	case "string", "number", "integer", "boolean", "any":
 		return SimpleKind, nil
@@ -338,6 +443,10 @@ const (
 	// See https://tools.ietf.org/html/draft-zyp-json-schema-03#section-5.28
 	// for more details on the format.
 	ReferenceKind
+
+	// UnionKind is the category for a JSON Schema that accepts values of
+	// several types, e.g. `"type": ["string", "integer"]`.
+	UnionKind
 )
 
 type property struct {
@@ -495,6 +604,26 @@ type parameter struct {
 	Location string
 }
 
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// Without this method the `schema.UnmarshalJSON()` method is promoted, and
+// it would ignore the parameter fields.
+func (p *parameter) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &p.schema); err != nil {
+		return err
+	}
+	var aux struct {
+		Required bool
+		Repeated bool
+		Location string
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	p.Required, p.Repeated, p.Location = aux.Required, aux.Repeated, aux.Location
+	return nil
+}
+
 // sortedKeys returns the keys of m, which must be a map[string]T, in sorted order.
 func sortedKeys[Map ~map[string]V, V any](m Map) []string {
 	keys := slices.Collect(maps.Keys(m))
@@ -27,6 +27,12 @@ func maybeMapField(model *api.API, message *api.Message, input *property) (*api.
 	if input.Schema.AdditionalProperties == nil {
 		return nil, nil
 	}
+	if field := maybeFreeFormObjectField(message, input); field != nil {
+		return field, nil
+	}
+	if field := maybeMapOfUnionField(model, message, input); field != nil {
+		return field, nil
+	}
 
 	if field := maybeMapOfObjectField(model, message, input); field != nil {
 		return field, nil
@@ -37,6 +43,43 @@ func maybeMapField(model *api.API, message *api.Message, input *property) (*api.
 	return maybeMapOfPrimitiveField(model, message, input)
 }
 
+// maybeFreeFormObjectField handles objects with `additionalProperties: true`
+// or `additionalProperties: {"type": "any"}`. These objects accept any
+// properties, which is what `google.protobuf.Struct` represents.
+func maybeFreeFormObjectField(message *api.Message, input *property) *api.Field {
+	values := input.Schema.AdditionalProperties
+	if values.Type != "any" || values.Format != "" {
+		return nil
+	}
+	return &api.Field{
+		Name:          input.Name,
+		JSONName:      input.Name,
+		ID:            fmt.Sprintf("%s.%s", message.ID, input.Name),
+		Documentation: input.Schema.Description,
+		Typez:         api.MESSAGE_TYPE,
+		TypezID:       ".google.protobuf.Struct",
+		Deprecated:    input.Schema.Deprecated,
+		Optional:      true,
+	}
+}
+
+func maybeMapOfUnionField(model *api.API, message *api.Message, input *property) *api.Field {
+	if len(input.Schema.AdditionalProperties.Variants) == 0 {
+		return nil
+	}
+	typezID := insertMapType(model, api.MESSAGE_TYPE, ".google.protobuf.Value")
+	return &api.Field{
+		Name:          input.Name,
+		JSONName:      input.Name,
+		ID:            fmt.Sprintf("%s.%s", message.ID, input.Name),
+		Documentation: input.Schema.Description,
+		Typez:         api.MESSAGE_TYPE,
+		TypezID:       typezID,
+		Deprecated:    input.Schema.Deprecated,
+		Map:           true,
+	}
+}
+
 func maybeMapOfObjectField(model *api.API, message *api.Message, input *property) *api.Field {
 	if input.Schema.AdditionalProperties.Ref == "" {
 		return nil
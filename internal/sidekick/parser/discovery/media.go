@@ -0,0 +1,104 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+)
+
+// The size suffixes used in the `mediaUpload.maxSize` field of discovery docs.
+var mediaSizeUnits = map[string]int64{
+	"KB": 1 << 10,
+	"MB": 1 << 20,
+	"GB": 1 << 30,
+	"TB": 1 << 40,
+}
+
+// makeMediaUpload converts the `mediaUpload` field of a discovery doc method.
+//
+// The upload paths are absolute, and they do not include the service path,
+// e.g. `/upload/storage/v1/b/{bucket}/o`.
+func makeMediaUpload(id string, binding *api.PathBinding, input *mediaUpload) (*api.MediaUpload, error) {
+	maxSize, err := parseMediaSize(input.MaxSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid maxSize for media upload in method %s: %w", id, err)
+	}
+	result := &api.MediaUpload{
+		Accept:  input.Accept,
+		MaxSize: maxSize,
+	}
+	for _, name := range slices.Sorted(maps.Keys(input.Protocols)) {
+		p, err := makeUploadProtocol(binding, input.Protocols[name])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s upload protocol in method %s: %w", name, id, err)
+		}
+		switch name {
+		case "simple":
+			result.Simple = p
+		case "resumable":
+			result.Resumable = p
+		default:
+			return nil, fmt.Errorf("unknown upload protocol %q in method %s", name, id)
+		}
+	}
+	if result.Simple == nil && result.Resumable == nil {
+		return nil, fmt.Errorf("media upload without protocols in method %s", id)
+	}
+	return result, nil
+}
+
+func makeUploadProtocol(binding *api.PathBinding, input protocol) (*api.UploadProtocol, error) {
+	path, err := ParseUriTemplate(strings.TrimPrefix(input.Path, "/"))
+	if err != nil {
+		return nil, err
+	}
+	return &api.UploadProtocol{
+		Multipart: input.Multipart,
+		Binding: &api.PathBinding{
+			Verb:            binding.Verb,
+			PathTemplate:    path,
+			QueryParameters: binding.QueryParameters,
+		},
+	}, nil
+}
+
+// parseMediaSize parses sizes such as `5TB`, `10MB`, or `1024`.
+func parseMediaSize(size string) (int64, error) {
+	if size == "" {
+		return 0, nil
+	}
+	multiplier := int64(1)
+	number := size
+	for suffix, m := range mediaSizeUnits {
+		if n, ok := strings.CutSuffix(size, suffix); ok {
+			number, multiplier = n, m
+			break
+		}
+	}
+	value, err := strconv.ParseInt(number, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if value < 0 {
+		return 0, fmt.Errorf("negative size %q", size)
+	}
+	return value * multiplier, nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+)
+
+func TestMediaMethod(t *testing.T) {
+	model := api.NewTestAPI([]*api.Message{}, []*api.Enum{}, []*api.Service{})
+	model.PackageName = "test"
+	parent := &api.Message{Name: "objects", ID: ".test.objects", Package: "test"}
+	doc := &document{ServicePath: "storage/v1/"}
+	input := &method{
+		Name:       "insert",
+		Path:       "b/{bucket}/o",
+		HTTPMethod: "POST",
+		Parameters: parameterList{
+			{Name: "bucket", Location: "path", Required: true, schema: schema{Type: "string"}},
+			{Name: "name", Location: "query", schema: schema{Type: "string"}},
+		},
+		Request:  &schema{Ref: "Object"},
+		Response: &schema{Ref: "Object"},
+		MediaUpload: &mediaUpload{
+			Accept:  []string{"*/*"},
+			MaxSize: "5TB",
+			Protocols: map[string]protocol{
+				"simple":    {Multipart: true, Path: "/upload/storage/v1/b/{bucket}/o"},
+				"resumable": {Multipart: true, Path: "/resumable/upload/storage/v1/b/{bucket}/o"},
+			},
+		},
+		SupportsMediaDownload: true,
+	}
+	got, err := makeMethod(model, parent, doc, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	queryParameters := map[string]bool{"name": true}
+	want := &api.MediaUpload{
+		Accept:  []string{"*/*"},
+		MaxSize: 5 << 40,
+		Simple: &api.UploadProtocol{
+			Multipart: true,
+			Binding: &api.PathBinding{
+				Verb: "POST",
+				PathTemplate: api.NewPathTemplate().
+					WithLiteral("upload").
+					WithLiteral("storage").
+					WithLiteral("v1").
+					WithLiteral("b").
+					WithVariableNamed("bucket").
+					WithLiteral("o"),
+				QueryParameters: queryParameters,
+			},
+		},
+		Resumable: &api.UploadProtocol{
+			Multipart: true,
+			Binding: &api.PathBinding{
+				Verb: "POST",
+				PathTemplate: api.NewPathTemplate().
+					WithLiteral("resumable").
+					WithLiteral("upload").
+					WithLiteral("storage").
+					WithLiteral("v1").
+					WithLiteral("b").
+					WithVariableNamed("bucket").
+					WithLiteral("o"),
+				QueryParameters: queryParameters,
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got.MediaUpload); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+	if !got.SupportsMediaDownload {
+		t.Errorf("expected SupportsMediaDownload in %v", got)
+	}
+}
+
+func TestMediaMethodError(t *testing.T) {
+	model := api.NewTestAPI([]*api.Message{}, []*api.Enum{}, []*api.Service{})
+	parent := &api.Message{Name: "objects", ID: ".test.objects", Package: "test"}
+	for _, test := range []struct {
+		name  string
+		input *mediaUpload
+	}{
+		{"noProtocols", &mediaUpload{}},
+		{"badMaxSize", &mediaUpload{MaxSize: "5XB", Protocols: map[string]protocol{"simple": {Path: "/upload/a"}}}},
+		{"badPath", &mediaUpload{Protocols: map[string]protocol{"simple": {Path: "/upload/{+a"}}}},
+		{"unknownProtocol", &mediaUpload{Protocols: map[string]protocol{"chunked": {Path: "/upload/a"}}}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			input := &method{Name: "insert", Path: "a", HTTPMethod: "POST", MediaUpload: test.input}
+			if got, err := makeMethod(model, parent, &document{}, input); err == nil {
+				t.Errorf("expected an error, got=%v", got)
+			}
+		})
+	}
+}
+
+func TestParseMediaSize(t *testing.T) {
+	for _, test := range []struct {
+		input string
+		want  int64
+	}{
+		{"", 0},
+		{"1024", 1024},
+		{"2KB", 2 << 10},
+		{"10MB", 10 << 20},
+		{"5GB", 5 << 30},
+		{"5TB", 5 << 40},
+	} {
+		got, err := parseMediaSize(test.input)
+		if err != nil {
+			t.Errorf("parseMediaSize(%q) failed: %v", test.input, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("parseMediaSize(%q) = %d, want = %d", test.input, got, test.want)
+		}
+	}
+}
+
+func TestParseMediaSizeError(t *testing.T) {
+	for _, input := range []string{"abc", "MB", "-1MB", "1.5GB"} {
+		if got, err := parseMediaSize(input); err == nil {
+			t.Errorf("expected an error parsing %q, got=%d", input, got)
+		}
+	}
+}
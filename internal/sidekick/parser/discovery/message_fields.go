@@ -32,6 +32,9 @@ func makeMessageFields(model *api.API, message *api.Message, schema *schema) err
 }
 
 func makeField(model *api.API, message *api.Message, input *property) (*api.Field, error) {
+	if field, err := maybeUnionField(model, message, input.Name, input.Schema); err != nil || field != nil {
+		return field, err
+	}
 	if input.Schema.Type == "array" {
 		return makeArrayField(model, message, input)
 	}
@@ -45,10 +48,16 @@ func makeField(model *api.API, message *api.Message, input *property) (*api.Fiel
 }
 
 func makeArrayField(model *api.API, message *api.Message, input *property) (*api.Field, error) {
-	field, err := maybeInlineObjectField(model, message, input.Name, input.Schema.ItemSchema)
+	field, err := maybeUnionField(model, message, input.Name, input.Schema.ItemSchema)
 	if err != nil {
 		return nil, err
 	}
+	if field == nil {
+		field, err = maybeInlineObjectField(model, message, input.Name, input.Schema.ItemSchema)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if field != nil {
 		field.Documentation = input.Schema.Description
 		field.Repeated = true
@@ -47,9 +47,6 @@ func makeServiceMethods(model *api.API, service *api.Service, doc *document, res
 
 func makeMethod(model *api.API, parent *api.Message, doc *document, input *method) (*api.Method, error) {
 	id := fmt.Sprintf("%s.%s", parent.ID, input.Name)
-	if input.MediaUpload != nil {
-		return nil, fmt.Errorf("media upload methods are not supported, id=%s", id)
-	}
 	bodyID, err := getMethodType(model, id, "request type", input.Request)
 	if err != nil {
 		return nil, err
@@ -126,6 +123,14 @@ func makeMethod(model *api.API, parent *api.Message, doc *document, input *metho
 		bodyPathField = name
 	}
 
+	var upload *api.MediaUpload
+	if input.MediaUpload != nil {
+		upload, err = makeMediaUpload(id, binding, input.MediaUpload)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	method := &api.Method{
 		ID:            id,
 		Name:          input.Name,
@@ -137,6 +142,8 @@ func makeMethod(model *api.API, parent *api.Message, doc *document, input *metho
 			Bindings:      []*api.PathBinding{binding},
 			BodyFieldPath: bodyPathField,
 		},
+		MediaUpload:           upload,
+		SupportsMediaDownload: input.SupportsMediaDownload,
 	}
 	return method, nil
 }
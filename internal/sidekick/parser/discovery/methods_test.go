@@ -290,7 +290,7 @@ func TestMakeServiceMethodsError(t *testing.T) {
 		ID:   ".test.Service",
 	}
 	if err := makeServiceMethods(model, service, &doc, input); err == nil {
-		t.Errorf("expected error on method with media upload, service=%v", service)
+		t.Errorf("expected error on method with media upload without protocols, service=%v", service)
 	}
 }
 
@@ -304,7 +304,7 @@ func TestMakeMethodError(t *testing.T) {
 		Name  string
 		Input method
 	}{
-		{"mediaUploadMustBeNil", method{MediaUpload: &mediaUpload{}}},
+		{"mediaUploadWithoutProtocols", method{MediaUpload: &mediaUpload{}}},
 		{"requestMustHaveRef", method{Request: &schema{}}},
 		{"responseMustHaveRef", method{Response: &schema{}}},
 		{"badPath", method{Path: "{+var"}},
@@ -0,0 +1,74 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery
+
+import (
+	"fmt"
+
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+)
+
+// maybeUnionField returns a field for schemas that accept several types.
+//
+// Unions where all the alternatives are equivalent use the type of the
+// alternative. Other unions use `google.protobuf.Value`. A oneof would need
+// a different JSON name for each alternative, while discovery docs send all
+// of them under the property name, and `google.protobuf.Value` preserves that
+// encoding.
+func maybeUnionField(model *api.API, message *api.Message, name string, input *schema) (*api.Field, error) {
+	if len(input.Variants) == 0 {
+		return nil, nil
+	}
+	variants := distinctVariants(input.Variants)
+	if len(variants) == 1 {
+		variant := *variants[0]
+		if variant.Description == "" {
+			variant.Description = input.Description
+		}
+		variant.Deprecated = variant.Deprecated || input.Deprecated
+		if field, err := maybeInlineObjectField(model, message, name, &variant); err != nil || field != nil {
+			return field, err
+		}
+		return makeScalarField(model, message, name, &variant)
+	}
+	return &api.Field{
+		Name:          name,
+		JSONName:      name,
+		ID:            fmt.Sprintf("%s.%s", message.ID, name),
+		Documentation: input.Description,
+		Typez:         api.MESSAGE_TYPE,
+		TypezID:       ".google.protobuf.Value",
+		Deprecated:    input.Deprecated,
+		Optional:      true,
+	}, nil
+}
+
+// distinctVariants removes equivalent alternatives from a union, e.g. the
+// items in a tuple with the same type.
+func distinctVariants(variants []*schema) []*schema {
+	var result []*schema
+	seen := map[string]bool{}
+	for _, v := range variants {
+		if len(v.Properties) == 0 && len(v.Variants) == 0 && v.AdditionalProperties == nil && v.ItemSchema == nil && v.Enums == nil {
+			key := fmt.Sprintf("%s/%s/%s", v.Type, v.Format, v.Ref)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		result = append(result, v)
+	}
+	return result
+}
@@ -0,0 +1,212 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+	"github.com/julieqiu/librarianx/internal/sidekick/api/apitest"
+)
+
+const unionDocument = `{
+  "schemas": {
+    "Other": {"id": "Other", "type": "object", "properties": {"name": {"type": "string"}}},
+    "Union": {
+      "id": "Union",
+      "type": "object",
+      "properties": {
+        "nullable": {"type": ["string", "null"], "description": "A nullable string."},
+        "mixed": {"type": ["string", "integer"], "description": "A string or a number."},
+        "withSchema": {"type": ["string", {"$ref": "Other"}]},
+        "anyOf": {"anyOf": [{"type": "boolean"}, {"$ref": "Other"}]},
+        "sameTypes": {"anyOf": [{"$ref": "Other"}, {"$ref": "Other"}]},
+        "tuple": {"type": "array", "items": [{"type": "string"}, {"type": "integer", "format": "int32"}]},
+        "uniformTuple": {"type": "array", "items": [{"type": "string"}, {"type": "string"}]},
+        "singleTuple": {"type": "array", "items": [{"type": "boolean"}]},
+        "freeForm": {"type": "object", "additionalProperties": true, "description": "Anything goes."},
+        "freeFormAny": {"type": "object", "additionalProperties": {"type": "any"}},
+        "noExtra": {"type": "object", "additionalProperties": false, "properties": {"a": {"type": "string"}}},
+        "mapOfUnion": {"type": "object", "additionalProperties": {"type": ["string", "boolean"]}}
+      }
+    }
+  },
+  "resources": {
+    "unions": {
+      "methods": {
+        "get": {
+          "path": "v1/unions/{name}",
+          "httpMethod": "GET",
+          "parameters": {
+            "name": {"type": "string", "location": "path", "required": true},
+            "view": {"type": ["string", "null"], "location": "query"}
+          },
+          "response": {"$ref": "Union"}
+        }
+      }
+    }
+  }
+}`
+
+func TestUnionFields(t *testing.T) {
+	model, err := NewAPI(nil, []byte(unionDocument), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, ok := model.State.MessageByID["..Union"]
+	if !ok {
+		t.Fatalf("missing message ..Union")
+	}
+	want := &api.Message{
+		Name: "Union",
+		ID:   "..Union",
+		Fields: []*api.Field{
+			{
+				Name:     "anyOf",
+				JSONName: "anyOf",
+				ID:       "..Union.anyOf",
+				Typez:    api.MESSAGE_TYPE,
+				TypezID:  ".google.protobuf.Value",
+				Optional: true,
+			},
+			{
+				Name:          "freeForm",
+				JSONName:      "freeForm",
+				ID:            "..Union.freeForm",
+				Documentation: "Anything goes.",
+				Typez:         api.MESSAGE_TYPE,
+				TypezID:       ".google.protobuf.Struct",
+				Optional:      true,
+			},
+			{
+				Name:     "freeFormAny",
+				JSONName: "freeFormAny",
+				ID:       "..Union.freeFormAny",
+				Typez:    api.MESSAGE_TYPE,
+				TypezID:  ".google.protobuf.Struct",
+				Optional: true,
+			},
+			{
+				Name:     "mapOfUnion",
+				JSONName: "mapOfUnion",
+				ID:       "..Union.mapOfUnion",
+				Typez:    api.MESSAGE_TYPE,
+				TypezID:  "$map<string, .google.protobuf.Value>",
+				Map:      true,
+			},
+			{
+				Name:          "mixed",
+				JSONName:      "mixed",
+				ID:            "..Union.mixed",
+				Documentation: "A string or a number.",
+				Typez:         api.MESSAGE_TYPE,
+				TypezID:       ".google.protobuf.Value",
+				Optional:      true,
+			},
+			{
+				Name:     "noExtra",
+				JSONName: "noExtra",
+				ID:       "..Union.noExtra",
+				Typez:    api.MESSAGE_TYPE,
+				TypezID:  "..Union.noExtra",
+				Optional: true,
+			},
+			{
+				Name:          "nullable",
+				JSONName:      "nullable",
+				ID:            "..Union.nullable",
+				Documentation: "A nullable string.",
+				Typez:         api.STRING_TYPE,
+				TypezID:       "string",
+				Optional:      true,
+			},
+			{
+				Name:     "sameTypes",
+				JSONName: "sameTypes",
+				ID:       "..Union.sameTypes",
+				Typez:    api.MESSAGE_TYPE,
+				TypezID:  "..Other",
+				Optional: true,
+			},
+			{
+				Name:     "singleTuple",
+				JSONName: "singleTuple",
+				ID:       "..Union.singleTuple",
+				Typez:    api.BOOL_TYPE,
+				TypezID:  "bool",
+				Repeated: true,
+			},
+			{
+				Name:     "tuple",
+				JSONName: "tuple",
+				ID:       "..Union.tuple",
+				Typez:    api.MESSAGE_TYPE,
+				TypezID:  ".google.protobuf.Value",
+				Repeated: true,
+			},
+			{
+				Name:     "uniformTuple",
+				JSONName: "uniformTuple",
+				ID:       "..Union.uniformTuple",
+				Typez:    api.STRING_TYPE,
+				TypezID:  "string",
+				Repeated: true,
+			},
+			{
+				Name:     "withSchema",
+				JSONName: "withSchema",
+				ID:       "..Union.withSchema",
+				Typez:    api.MESSAGE_TYPE,
+				TypezID:  ".google.protobuf.Value",
+				Optional: true,
+			},
+		},
+	}
+	apitest.CheckMessage(t, got, want)
+}
+
+func TestUnionParameters(t *testing.T) {
+	doc, err := newDiscoDocument([]byte(unionDocument))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := doc.Resources[0].Methods[0].Parameters
+	want := parameterList{
+		{Name: "name", schema: schema{Type: "string"}, Required: true, Location: "path"},
+		{Name: "view", schema: schema{Type: "string"}, Location: "query"},
+	}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(parameter{}), cmp.AllowUnexported(schema{})); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestUnionErrors(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		contents string
+	}{
+		{"bad type array", `{"schemas": {"Bad": {"type": "object", "properties": {"bad": {"type": [123]}}}}}`},
+		{"bad variant", `{"schemas": {"Bad": {"type": "object", "properties": {"bad": {"type": ["string", {"$ref": "notFound"}]}}}}}`},
+		{"empty tuple", `{"schemas": {"Bad": {"type": "object", "properties": {"bad": {"type": "array", "items": []}}}}}`},
+		{"bad additional properties", `{"schemas": {"Bad": {"type": "object", "properties": {"bad": {"type": "object", "additionalProperties": 123}}}}}`},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got, err := NewAPI(nil, []byte(test.contents), nil); err == nil {
+				t.Errorf("expected an error, got=%v", got)
+			}
+		})
+	}
+}
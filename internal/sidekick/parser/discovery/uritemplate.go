@@ -17,6 +17,7 @@ package discovery
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/julieqiu/librarianx/internal/sidekick/api"
@@ -29,69 +30,189 @@ const (
 )
 
 var (
-	identifierRe = regexp.MustCompile("[A-Za-z][A-Za-z0-9_]*")
+	identifierRe = regexp.MustCompile("^[A-Za-z][A-Za-z0-9_]*$")
 )
 
 // ParseUriTemplate parses a [RFC 6570] URI template as an `api.PathTemplate`.
 //
 // In sidekick we need to capture the structure of the URI template for the
 // codec(s) to emit good templates with them.
+//
+// The expressions map to path segments as follows:
+//
+//   - `{var}` and `{var*}` match a single segment, and `{a,b}` produces one
+//     single segment match per variable.
+//   - `{+var}` (reserved expansion) matches one or more segments, as the value
+//     may contain `/` characters. In `{+a,b}` only the last variable matches
+//     more than one segment.
+//   - `{#var}` (fragment expansion) matches the remaining segments. It may
+//     only appear at the end of the path.
+//   - `{/var}` starts a new segment matching a single segment, `{/var*}`
+//     matches one or more segments, and `{/a,b}` starts one segment per
+//     variable.
+//
+// The following are out of scope and do not produce path segments:
+//
+//   - `{?var}` and `{&var}` (form-style query expansions) may only appear at
+//     the end of the template and are skipped. Discovery documents describe
+//     query parameters as method parameters with `"location": "query"`, which
+//     is what the codecs use.
+//   - `{.var}` (label expansion) and `{;var}` (path-style parameter expansion)
+//     expand to part of a segment, e.g. `.json` or `;id=1`, and are rejected.
+//
+// Prefix modifiers (`{var:3}`) are accepted and ignored.
+//
+// [RFC 6570]: https://www.rfc-editor.org/rfc/rfc6570.html
 func ParseUriTemplate(uriTemplate string) (*api.PathTemplate, error) {
 	template := &api.PathTemplate{}
 	var pos int
 	for {
-		var err error
-		var segment *api.PathSegment
-		var width int
-
 		if pos == len(uriTemplate) {
 			return nil, fmt.Errorf("expected a segment, found eof: %s", uriTemplate)
 		}
+		var segments []api.PathSegment
+		var width int
+		var err error
 		if uriTemplate[pos] == beginExpression {
-			segment, width, err = parseExpression(uriTemplate[pos:])
+			segments, width, err = parseExpression(uriTemplate[pos:])
 		} else {
+			var segment *api.PathSegment
 			segment, width, err = parseLiteral(uriTemplate[pos:])
+			if segment != nil {
+				segments = []api.PathSegment{*segment}
+			}
 		}
 		if err != nil {
 			return nil, err
 		}
-		template.Segments = append(template.Segments, *segment)
+		template.Segments = append(template.Segments, segments...)
 		pos += width
+		// Path segment (`{/var}`) and fragment (`{#var}`) expansions start
+		// new segments without a separating slash.
+		for strings.HasPrefix(uriTemplate[pos:], "{/") || strings.HasPrefix(uriTemplate[pos:], "{#") {
+			fragment := strings.HasPrefix(uriTemplate[pos:], "{#")
+			segments, width, err := parseExpression(uriTemplate[pos:])
+			if err != nil {
+				return nil, err
+			}
+			template.Segments = append(template.Segments, segments...)
+			pos += width
+			if fragment && pos != len(uriTemplate) && !strings.HasPrefix(uriTemplate[pos:], "{?") && !strings.HasPrefix(uriTemplate[pos:], "{&") {
+				return nil, fmt.Errorf("fragment expansions must be at the end of the path in URI template %q", uriTemplate)
+			}
+		}
 		if pos == len(uriTemplate) || uriTemplate[pos] != slash {
 			break
 		}
 		pos++ // Skip slash
 	}
+	// Query expansions are only valid at the end of the template.
+	for strings.HasPrefix(uriTemplate[pos:], "{?") || strings.HasPrefix(uriTemplate[pos:], "{&") {
+		_, width, err := parseExpression(uriTemplate[pos:])
+		if err != nil {
+			return nil, err
+		}
+		pos += width
+	}
 	if pos != len(uriTemplate) {
 		return nil, fmt.Errorf("trailing data (%q) cannot be parsed as a URI template", uriTemplate[pos:])
 	}
+	if len(template.Segments) == 0 {
+		return nil, fmt.Errorf("no path segments in URI template %q", uriTemplate)
+	}
 	return template, nil
 }
 
-func parseExpression(input string) (*api.PathSegment, int, error) {
+// parseExpression parses a single `{...}` expression and returns the path
+// segments for it. See [ParseUriTemplate] for how each operator maps to path
+// segments.
+//
+// The format for expressions is defined in:
+//
+//	https://www.rfc-editor.org/rfc/rfc6570.html#section-2.2
+func parseExpression(input string) ([]api.PathSegment, int, error) {
 	if input == "" || input[0] != beginExpression {
 		return nil, 0, fmt.Errorf("missing `{` character in expression %q", input)
 	}
-	tail := input[1:]
-	if strings.IndexAny(tail, "+#") == 0 {
-		return nil, 0, fmt.Errorf("level 2 expressions unsupported input=%q", input)
+	end := strings.IndexByte(input, endExpression)
+	if end == -1 {
+		return nil, 0, fmt.Errorf("missing `}` character at the end of the expression %q", input)
 	}
-	if strings.IndexAny(tail, "./?&") == 0 {
-		return nil, 0, fmt.Errorf("level 3 expressions unsupported input=%q", input)
+	width := end + 1
+	body := input[1:end]
+	var operator byte
+	if body != "" && strings.IndexByte("+#./;?&", body[0]) != -1 {
+		operator = body[0]
+		body = body[1:]
 	}
-	if strings.IndexAny(tail, "=,!@|") == 0 {
+	if body != "" && strings.IndexByte("=,!@|", body[0]) != -1 {
 		return nil, 0, fmt.Errorf("reserved character on expression %q", input)
 	}
-	match := identifierRe.FindStringIndex(tail)
-	if match[0] != 0 {
-		return nil, 0, fmt.Errorf("no identifier found on expression %q", input)
+	variables, err := parseVariableList(body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w in expression %q", err, input)
 	}
-	id := tail[0:match[1]]
-	tail = tail[match[1]:]
-	if tail == "" || tail[0] != endExpression {
-		return nil, 0, fmt.Errorf("missing `}` character at the end of the expression %q", input)
+
+	switch operator {
+	case 0, '+', '#':
+		var segments []api.PathSegment
+		for i, variable := range variables {
+			v := api.NewPathVariable(variable.name)
+			if operator != 0 && i == len(variables)-1 {
+				v = v.WithMatchRecursive()
+			} else {
+				v = v.WithMatch()
+			}
+			segments = append(segments, api.PathSegment{Variable: v})
+		}
+		return segments, width, nil
+	case '/':
+		var segments []api.PathSegment
+		for _, variable := range variables {
+			v := api.NewPathVariable(variable.name)
+			if variable.explode {
+				v = v.WithMatchRecursive()
+			} else {
+				v = v.WithMatch()
+			}
+			segments = append(segments, api.PathSegment{Variable: v})
+		}
+		return segments, width, nil
+	case '?', '&':
+		// Query parameters come from the method parameters.
+		return nil, width, nil
+	default:
+		return nil, 0, fmt.Errorf("%q expansions expand to part of a path segment and are not supported in expression %q", operator, input)
+	}
+}
+
+type uriVariable struct {
+	name    string
+	explode bool
+}
+
+// parseVariableList parses the comma separated variables in an expression,
+// including any `*` (explode) or `:N` (prefix) modifiers.
+func parseVariableList(input string) ([]uriVariable, error) {
+	var variables []uriVariable
+	for _, spec := range strings.Split(input, ",") {
+		var variable uriVariable
+		if name, ok := strings.CutSuffix(spec, "*"); ok {
+			variable.explode = true
+			spec = name
+		} else if name, prefix, ok := strings.Cut(spec, ":"); ok {
+			if n, err := strconv.Atoi(prefix); err != nil || n <= 0 || n >= 10000 {
+				return nil, fmt.Errorf("invalid prefix modifier %q", prefix)
+			}
+			spec = name
+		}
+		if !identifierRe.MatchString(spec) {
+			return nil, fmt.Errorf("invalid variable name %q", spec)
+		}
+		variable.name = spec
+		variables = append(variables, variable)
 	}
-	return &api.PathSegment{Variable: api.NewPathVariable(id).WithMatch()}, match[1] + 2, nil
+	return variables, nil
 }
 
 // parseLiteral() extracts a literal value from `input`.
@@ -119,8 +240,20 @@ func parseLiteral(input string) (*api.PathSegment, int, error) {
 	if literal == "" {
 		return nil, 0, fmt.Errorf("invalid empty literal with input=%q", input)
 	}
-	if tail != "" && tail[0] != slash {
+	if tail != "" && tail[0] != slash && !startsSegmentOrQuery(tail) {
 		return nil, index, fmt.Errorf("found unexpected character %v in literal %q, stopped at position %v", tail[0], input, index)
 	}
 	return &api.PathSegment{Literal: &literal}, width, nil
 }
+
+// startsSegmentOrQuery returns true if `input` starts with an expression that
+// can directly follow a literal, i.e., a path segment, fragment, or query
+// expansion.
+func startsSegmentOrQuery(input string) bool {
+	for _, prefix := range []string{"{/", "{#", "{?", "{&"} {
+		if strings.HasPrefix(input, prefix) {
+			return true
+		}
+	}
+	return false
+}
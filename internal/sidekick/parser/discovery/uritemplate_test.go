@@ -43,6 +43,62 @@ func TestParseUriTemplateSuccess(t *testing.T) {
 			WithVariableNamed("zone").
 			WithVariableNamed("parentName").
 			WithLiteral("reservationSubBlocks")},
+		{"v1/{+parent}/externalAccountKeys", api.NewPathTemplate().
+			WithLiteral("v1").
+			WithVariable(api.NewPathVariable("parent").WithMatchRecursive()).
+			WithLiteral("externalAccountKeys")},
+		{"storage/v1/b/{bucket}/o/{+object}", api.NewPathTemplate().
+			WithLiteral("storage").
+			WithLiteral("v1").
+			WithLiteral("b").
+			WithVariableNamed("bucket").
+			WithLiteral("o").
+			WithVariable(api.NewPathVariable("object").WithMatchRecursive())},
+		{"drive/v3/files{/fileId}", api.NewPathTemplate().
+			WithLiteral("drive").
+			WithLiteral("v3").
+			WithLiteral("files").
+			WithVariableNamed("fileId")},
+		{"v1/files{/parent,fileId}/content", api.NewPathTemplate().
+			WithLiteral("v1").
+			WithLiteral("files").
+			WithVariableNamed("parent").
+			WithVariableNamed("fileId").
+			WithLiteral("content")},
+		{"v1{/path*}", api.NewPathTemplate().
+			WithLiteral("v1").
+			WithVariable(api.NewPathVariable("path").WithMatchRecursive())},
+		{"v1/{name}{/child}", api.NewPathTemplate().
+			WithLiteral("v1").
+			WithVariableNamed("name").
+			WithVariableNamed("child")},
+		{"v1/files{?fields,alt}", api.NewPathTemplate().
+			WithLiteral("v1").
+			WithLiteral("files")},
+		{"v1/{name}{?fields}{&alt}", api.NewPathTemplate().
+			WithLiteral("v1").
+			WithVariableNamed("name")},
+		{"v1/files{#section}", api.NewPathTemplate().
+			WithLiteral("v1").
+			WithLiteral("files").
+			WithVariable(api.NewPathVariable("section").WithMatchRecursive())},
+		{"v1/{name}{#section}{?alt}", api.NewPathTemplate().
+			WithLiteral("v1").
+			WithVariableNamed("name").
+			WithVariable(api.NewPathVariable("section").WithMatchRecursive())},
+		{"v1/{a,b}/c", api.NewPathTemplate().
+			WithLiteral("v1").
+			WithVariableNamed("a").
+			WithVariableNamed("b").
+			WithLiteral("c")},
+		{"v1/{+a,b}", api.NewPathTemplate().
+			WithLiteral("v1").
+			WithVariableNamed("a").
+			WithVariable(api.NewPathVariable("b").WithMatchRecursive())},
+		{"v1/{name:3}/{ids*}", api.NewPathTemplate().
+			WithLiteral("v1").
+			WithVariableNamed("name").
+			WithVariableNamed("ids")},
 	} {
 		got, err := ParseUriTemplate(test.input)
 		if err != nil {
@@ -59,8 +115,13 @@ func TestParseUriTemplateError(t *testing.T) {
 	for _, test := range []struct {
 		input string
 	}{
-		{"v1/{+parent}/externalAccountKeys"},
 		{"a/b/c/"},
+		{"v1/files{#section}/more"},
+		{"v1/files{#section}{/child}"},
+		{"v1/files/{name}{.format}"},
+		{"v1/files{;id}"},
+		{"v1/files{?fields}/more"},
+		{"{?fields}"},
 		{"a/b/c|"},
 		{"a/b/{c}|"},
 		{"a/b/{c}}/d"},
@@ -76,25 +137,46 @@ func TestParseUriTemplateError(t *testing.T) {
 
 func TestParseExpression(t *testing.T) {
 	for _, test := range []struct {
-		input string
-		want  string
+		input     string
+		want      []api.PathSegment
+		wantWidth int
 	}{
-		{"{abc}", "abc"},
-		{"{Abc}", "Abc"},
-		{"{abc012}", "abc012"},
-		{"{abc_012}", "abc_012"},
-		{"{abc_012}/foo/{bar}", "abc_012"},
+		{"{abc}", []api.PathSegment{{Variable: api.NewPathVariable("abc").WithMatch()}}, 5},
+		{"{Abc}", []api.PathSegment{{Variable: api.NewPathVariable("Abc").WithMatch()}}, 5},
+		{"{abc012}", []api.PathSegment{{Variable: api.NewPathVariable("abc012").WithMatch()}}, 8},
+		{"{abc_012}", []api.PathSegment{{Variable: api.NewPathVariable("abc_012").WithMatch()}}, 9},
+		{"{abc_012}/foo/{bar}", []api.PathSegment{{Variable: api.NewPathVariable("abc_012").WithMatch()}}, 9},
+		{"{abc*}", []api.PathSegment{{Variable: api.NewPathVariable("abc").WithMatch()}}, 6},
+		{"{abc:10}", []api.PathSegment{{Variable: api.NewPathVariable("abc").WithMatch()}}, 8},
+		{"{+abc}", []api.PathSegment{{Variable: api.NewPathVariable("abc").WithMatchRecursive()}}, 6},
+		{"{/abc}", []api.PathSegment{{Variable: api.NewPathVariable("abc").WithMatch()}}, 6},
+		{"{/abc*}", []api.PathSegment{{Variable: api.NewPathVariable("abc").WithMatchRecursive()}}, 7},
+		{"{/a,b}", []api.PathSegment{
+			{Variable: api.NewPathVariable("a").WithMatch()},
+			{Variable: api.NewPathVariable("b").WithMatch()},
+		}, 6},
+		{"{a,b}", []api.PathSegment{
+			{Variable: api.NewPathVariable("a").WithMatch()},
+			{Variable: api.NewPathVariable("b").WithMatch()},
+		}, 5},
+		{"{+a,b}", []api.PathSegment{
+			{Variable: api.NewPathVariable("a").WithMatch()},
+			{Variable: api.NewPathVariable("b").WithMatchRecursive()},
+		}, 6},
+		{"{#abc}", []api.PathSegment{{Variable: api.NewPathVariable("abc").WithMatchRecursive()}}, 6},
+		{"{?a,b}", nil, 6},
+		{"{&a}", nil, 4},
 	} {
-		gotSegment, gotWidth, err := parseExpression(test.input)
+		gotSegments, gotWidth, err := parseExpression(test.input)
 		if err != nil {
 			t.Errorf("expected a successful parse with input=%s, err=%v", test.input, err)
 			continue
 		}
-		if diff := cmp.Diff(&api.PathSegment{Variable: api.NewPathVariable(test.want).WithMatch()}, gotSegment); diff != "" {
+		if diff := cmp.Diff(test.want, gotSegments); diff != "" {
 			t.Errorf("mismatch [%s] (-want, +got):\n%s", test.input, diff)
 		}
-		if len(test.want)+2 != gotWidth {
-			t.Errorf("mismatch want=%d, got=%d", len(test.want), gotWidth)
+		if test.wantWidth != gotWidth {
+			t.Errorf("mismatch [%s] want=%d, got=%d", test.input, test.wantWidth, gotWidth)
 		}
 	}
 }
@@ -102,12 +184,12 @@ func TestParseExpression(t *testing.T) {
 func TestParseExpressionError(t *testing.T) {
 	for _, input := range []string{
 		"", "(a)",
-		"{+a}", "{#a}",
-		"{.a}", "{/a}", "{?a}", "{&a}",
+		"{.a}", "{;a}",
 		"{=a}", "{,a}", "{!a}", "{@a}", "{|a}",
-		"{a,b}", "{_abc}", "{0abc}", "{ab"} {
-		if gotSegment, gotWidth, err := parseExpression(input); err == nil {
-			t.Errorf("expected a parsing error with input=%s, gotSegment=%v, gotWidth=%v", input, gotSegment, gotWidth)
+		"{_abc}", "{0abc}", "{ab", "{}", "{+}",
+		"{a:0}", "{a:x}", "{a:10000}", "{a,}"} {
+		if gotSegments, gotWidth, err := parseExpression(input); err == nil {
+			t.Errorf("expected a parsing error with input=%s, gotSegments=%v, gotWidth=%v", input, gotSegments, gotWidth)
 		}
 	}
 }
@@ -19,8 +19,10 @@ package parser
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
+	"github.com/iancoleman/strcase"
 	"github.com/julieqiu/librarianx/internal/sidekick/api"
 	"github.com/julieqiu/librarianx/internal/sidekick/config"
 	"github.com/julieqiu/librarianx/internal/sidekick/parser/httprule"
@@ -47,7 +49,7 @@ func ParseOpenAPI(cfg *config.Config) (*api.API, error) {
 	if err != nil {
 		return nil, err
 	}
-	return makeAPIForOpenAPI(serviceConfig, model)
+	return makeAPIForOpenAPI(serviceConfig, model, cfg.Source)
 }
 
 func createDocModel(contents []byte) (*libopenapi.DocumentModel[v3.Document], error) {
@@ -62,7 +64,7 @@ func createDocModel(contents []byte) (*libopenapi.DocumentModel[v3.Document], er
 	return docModel, nil
 }
 
-func makeAPIForOpenAPI(serviceConfig *serviceconfig.Service, model *libopenapi.DocumentModel[v3.Document]) (*api.API, error) {
+func makeAPIForOpenAPI(serviceConfig *serviceconfig.Service, model *libopenapi.DocumentModel[v3.Document], options map[string]string) (*api.API, error) {
 	result := &api.API{
 		Name:        "",
 		Title:       model.Model.Info.Title,
@@ -99,16 +101,23 @@ func makeAPIForOpenAPI(serviceConfig *serviceconfig.Service, model *libopenapi.D
 		result.PackageName = packageName
 	}
 
-	for name, msg := range model.Model.Components.Schemas.FromOldest() {
+	var schemas *orderedmap.Map[string, *base.SchemaProxy]
+	if model.Model.Components != nil {
+		schemas = model.Model.Components.Schemas
+	}
+	for name, msg := range schemas.FromOldest() {
 		id := fmt.Sprintf(".%s.%s", packageName, name)
 		schema, err := msg.BuildSchema()
 		if err != nil {
 			return nil, err
 		}
-		fields, err := makeMessageFields(result.State, packageName, name, schema)
+		fields, oneOfs, err := makeMessageFields(result.State, packageName, name, schema)
 		if err != nil {
 			return nil, err
 		}
+		for _, field := range fields {
+			field.ID = fmt.Sprintf("%s.%s", id, field.Name)
+		}
 		message := &api.Message{
 			Name:          name,
 			ID:            id,
@@ -116,13 +125,14 @@ func makeAPIForOpenAPI(serviceConfig *serviceconfig.Service, model *libopenapi.D
 			Deprecated:    msg.Schema().Deprecated != nil && *msg.Schema().Deprecated,
 			Documentation: msg.Schema().Description,
 			Fields:        fields,
+			OneOfs:        oneOfs,
 		}
 
 		result.Messages = append(result.Messages, message)
 		result.State.MessageByID[id] = message
 	}
 
-	err := makeServices(result, model, packageName, serviceName)
+	err := makeServices(result, model, packageName, serviceName, options)
 	if err != nil {
 		return nil, err
 	}
@@ -130,30 +140,189 @@ func makeAPIForOpenAPI(serviceConfig *serviceconfig.Service, model *libopenapi.D
 	return result, nil
 }
 
-func makeServices(a *api.API, model *libopenapi.DocumentModel[v3.Document], packageName, serviceName string) error {
+// makeServices creates the services for the operations in the document.
+//
+// By default all operations belong to a single service, named after the
+// service config. Large specifications can be split into multiple services
+// with source options:
+//
+//   - `service:<Name> = <prefix>,<prefix>...` puts the operations whose path
+//     starts with one of the prefixes into the `<Name>` service. The longest
+//     matching prefix wins.
+//   - `service-grouping = tag` puts the remaining operations into a service
+//     named after their first tag. If a schema has the same name, e.g. the
+//     `pet` tag and the `Pet` schema, the service is named `PetService`
+//     instead, because each service has a synthetic message with its ID.
+//
+// Operations matching no rule stay in the default service.
+func makeServices(a *api.API, model *libopenapi.DocumentModel[v3.Document], packageName, serviceName string, options map[string]string) error {
 	// It is hard to imagine an OpenAPI specification without at least some
 	// RPCs, but we can simplify the tests if we support specifications without
 	// paths or without any useful methods in the paths.
 	if model.Model.Paths == nil {
 		return nil
 	}
-	sID := fmt.Sprintf(".%s.%s", packageName, serviceName)
-	service := &api.Service{
-		Name:          serviceName,
-		ID:            sID,
-		Package:       packageName,
-		Documentation: a.Description,
-		DefaultHost:   defaultHost(model),
-	}
-	err := makeMethods(a, service, model, packageName, sID)
+	rules, err := newServiceRules(options)
 	if err != nil {
 		return err
 	}
-	a.Services = append(a.Services, service)
-	a.State.ServiceByID[service.ID] = service
+	tagDocs := map[string]string{}
+	for _, tag := range model.Model.Tags {
+		tagDocs[tag.Name] = tag.Description
+	}
+
+	services := map[string]*api.Service{}
+	lookup := func(name, documentation string, fromTag bool) (*api.Service, error) {
+		if service, ok := services[name]; ok {
+			return service, nil
+		}
+		if documentation == "" {
+			documentation = a.Description
+		}
+		serviceName := name
+		if _, ok := a.State.MessageByID[fmt.Sprintf(".%s.%s", packageName, serviceName)]; ok && fromTag {
+			serviceName += "Service"
+		}
+		id := fmt.Sprintf(".%s.%s", packageName, serviceName)
+		if _, ok := a.State.MessageByID[id]; ok {
+			return nil, fmt.Errorf("the service %s has the same ID as the message %s", serviceName, id)
+		}
+		service := &api.Service{
+			Name:          serviceName,
+			ID:            id,
+			Package:       packageName,
+			Documentation: documentation,
+			DefaultHost:   defaultHost(model),
+		}
+		services[name] = service
+		a.Services = append(a.Services, service)
+		a.State.ServiceByID[service.ID] = service
+		// It is Okay to reuse the ID, sidekick uses different the namespaces
+		// for messages vs. services.
+		parent := &api.Message{
+			Name:               service.Name,
+			ID:                 service.ID,
+			Package:            service.Package,
+			Documentation:      fmt.Sprintf("Synthetic messages for the [%s][%s] service.", service.Name, service.ID[1:]),
+			ServicePlaceholder: true,
+		}
+		a.State.MessageByID[parent.ID] = parent
+		a.Messages = append(a.Messages, parent)
+		return service, nil
+	}
+
+	for pattern, item := range model.Model.Paths.PathItems.FromOldest() {
+		pathTemplate, err := httprule.ParseSegments(pattern)
+		if err != nil {
+			return err
+		}
+		for _, op := range pathOperations(item) {
+			name, documentation, fromTag, grouped := rules.service(pattern, op.Operation, tagDocs)
+			if !grouped {
+				name = serviceName
+			}
+			service, err := lookup(name, documentation, fromTag)
+			if err != nil {
+				return err
+			}
+			methodName := op.Operation.OperationId
+			if rules.enabled() {
+				methodName, err = groupedMethodName(service, op.Operation.OperationId)
+				if err != nil {
+					return err
+				}
+			}
+			if err := makeMethod(a, service, op, methodName, packageName, pattern, pathTemplate); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
+// serviceRules assigns operations to services.
+type serviceRules struct {
+	// byTag is true if operations are grouped by their first tag.
+	byTag bool
+	// prefixes maps path prefixes to service names.
+	prefixes map[string]string
+}
+
+func newServiceRules(options map[string]string) (*serviceRules, error) {
+	rules := &serviceRules{prefixes: map[string]string{}}
+	switch grouping := options["service-grouping"]; grouping {
+	case "":
+	case "tag":
+		rules.byTag = true
+	default:
+		return nil, fmt.Errorf("unknown service-grouping %q, only `tag` is supported", grouping)
+	}
+	for key, value := range options {
+		name, ok := strings.CutPrefix(key, "service:")
+		if !ok {
+			continue
+		}
+		if name == "" {
+			return nil, fmt.Errorf("missing service name in source option %q", key)
+		}
+		for _, prefix := range strings.Split(value, ",") {
+			if other, ok := rules.prefixes[prefix]; ok && other != name {
+				return nil, fmt.Errorf("path prefix %q is assigned to both %s and %s", prefix, other, name)
+			}
+			rules.prefixes[prefix] = name
+		}
+	}
+	return rules, nil
+}
+
+func (r *serviceRules) enabled() bool {
+	return r.byTag || len(r.prefixes) != 0
+}
+
+// service returns the name and documentation of the service for an
+// operation, and whether the name comes from a tag. It returns false if no
+// rule applies.
+func (r *serviceRules) service(pattern string, operation *v3.Operation, tagDocs map[string]string) (string, string, bool, bool) {
+	var match string
+	for prefix := range r.prefixes {
+		if strings.HasPrefix(pattern, prefix) && len(prefix) > len(match) {
+			match = prefix
+		}
+	}
+	if match != "" {
+		return r.prefixes[match], "", false, true
+	}
+	if r.byTag && len(operation.Tags) != 0 {
+		tag := operation.Tags[0]
+		return strcase.ToCamel(tag), tagDocs[tag], true, true
+	}
+	return "", "", false, false
+}
+
+// groupedMethodName returns the name of the method for `operationID` in a
+// service created by the grouping rules.
+//
+// The name is the operation ID in PascalCase, without the service name when
+// the operation ID starts with it, e.g. `pets.list` becomes `List` in the
+// `Pets` service, but `listPets` becomes `ListPets`. The name does not
+// depend on the other operations, unless removing the prefix makes two
+// names collide.
+func groupedMethodName(service *api.Service, operationID string) (string, error) {
+	full := strcase.ToCamel(operationID)
+	taken := func(name string) bool {
+		return slices.ContainsFunc(service.Methods, func(m *api.Method) bool { return m.Name == name })
+	}
+	if name, ok := strings.CutPrefix(full, service.Name); ok && name != "" && !taken(name) {
+		if first := name[0]; first >= 'A' && first <= 'Z' {
+			return name, nil
+		}
+	}
+	if taken(full) {
+		return "", fmt.Errorf("duplicate method name %s in service %s for operation %s", full, service.Name, operationID)
+	}
+	return full, nil
+}
+
 func defaultHost(model *libopenapi.DocumentModel[v3.Document]) string {
 	defaultHost := ""
 	for _, server := range model.Model.Servers {
@@ -168,95 +337,79 @@ func defaultHost(model *libopenapi.DocumentModel[v3.Document]) string {
 	return strings.TrimPrefix(defaultHost, "https://")
 }
 
-func makeMethods(a *api.API, service *api.Service, model *libopenapi.DocumentModel[v3.Document], packageName, serviceID string) error {
-	if model.Model.Paths == nil {
-		// The method has no path, there is nothing to generate.
-		return nil
-	}
-
-	// It is Okay to reuse the ID, sidekick uses different the namespaces
-	// for messages vs. services.
-	parent := &api.Message{
-		Name:               service.Name,
-		ID:                 service.ID,
-		Package:            service.Package,
-		Documentation:      fmt.Sprintf("Synthetic messages for the [%s][%s] service.", service.Name, service.ID[1:]),
-		ServicePlaceholder: true,
-	}
-	a.State.MessageByID[parent.ID] = parent
-	a.Messages = append(a.Messages, parent)
+type namedOperation struct {
+	Verb      string
+	Operation *v3.Operation
+}
 
-	for pattern, item := range model.Model.Paths.PathItems.FromOldest() {
-		pathTemplate, err := httprule.ParseSegments(pattern)
-		if err != nil {
-			return err
+// pathOperations returns the operations defined for a path.
+func pathOperations(item *v3.PathItem) []namedOperation {
+	var operations []namedOperation
+	for _, op := range []namedOperation{
+		{Verb: "GET", Operation: item.Get},
+		{Verb: "PUT", Operation: item.Put},
+		{Verb: "POST", Operation: item.Post},
+		{Verb: "DELETE", Operation: item.Delete},
+		{Verb: "OPTIONS", Operation: item.Options},
+		{Verb: "HEAD", Operation: item.Head},
+		{Verb: "PATCH", Operation: item.Patch},
+		{Verb: "TRACE", Operation: item.Trace},
+	} {
+		if op.Operation != nil {
+			operations = append(operations, op)
 		}
+	}
+	return operations
+}
 
-		type NamedOperation struct {
-			Verb      string
-			Operation *v3.Operation
-		}
-		operations := []NamedOperation{
-			{Verb: "GET", Operation: item.Get},
-			{Verb: "PUT", Operation: item.Put},
-			{Verb: "POST", Operation: item.Post},
-			{Verb: "DELETE", Operation: item.Delete},
-			{Verb: "OPTIONS", Operation: item.Options},
-			{Verb: "HEAD", Operation: item.Head},
-			{Verb: "PATCH", Operation: item.Patch},
-			{Verb: "TRACE", Operation: item.Trace},
-		}
-		for _, op := range operations {
-			if op.Operation == nil {
-				continue
-			}
-			requestMessage, bodyFieldPath, err := makeRequestMessage(a, parent, op.Operation, packageName, pattern)
-			if err != nil {
-				return err
-			}
-			responseMessage, err := makeResponseMessage(a, op.Operation, packageName)
-			if err != nil {
-				return err
-			}
-			queryParameters := makeQueryParameters(op.Operation)
-			pathInfo := &api.PathInfo{
-				Bindings: []*api.PathBinding{
-					{
-						Verb:            op.Verb,
-						PathTemplate:    pathTemplate,
-						QueryParameters: queryParameters,
-					},
-				},
-				BodyFieldPath: bodyFieldPath,
-			}
-			mID := fmt.Sprintf("%s.%s", serviceID, op.Operation.OperationId)
-			m := &api.Method{
-				Name:          op.Operation.OperationId,
-				ID:            mID,
-				Deprecated:    op.Operation.Deprecated != nil && *op.Operation.Deprecated,
-				Documentation: op.Operation.Description,
-				InputTypeID:   requestMessage.ID,
-				OutputTypeID:  responseMessage.ID,
-				PathInfo:      pathInfo,
-			}
-			a.State.MethodByID[m.ID] = m
-			service.Methods = append(service.Methods, m)
-		}
+func makeMethod(a *api.API, service *api.Service, op namedOperation, methodName, packageName, pattern string, pathTemplate *api.PathTemplate) error {
+	parent := a.State.MessageByID[service.ID]
+	requestMessage, bodyFieldPath, err := makeRequestMessage(a, parent, op.Operation, methodName, packageName, pattern)
+	if err != nil {
+		return err
 	}
+	responseMessage, returnsEmpty, err := makeResponseMessage(a, op.Operation, packageName)
+	if err != nil {
+		return err
+	}
+	queryParameters := makeQueryParameters(op.Operation)
+	pathInfo := &api.PathInfo{
+		Bindings: []*api.PathBinding{
+			{
+				Verb:            op.Verb,
+				PathTemplate:    pathTemplate,
+				QueryParameters: queryParameters,
+			},
+		},
+		BodyFieldPath: bodyFieldPath,
+	}
+	mID := fmt.Sprintf("%s.%s", service.ID, methodName)
+	m := &api.Method{
+		Name:          methodName,
+		ID:            mID,
+		Deprecated:    op.Operation.Deprecated != nil && *op.Operation.Deprecated,
+		Documentation: op.Operation.Description,
+		InputTypeID:   requestMessage.ID,
+		OutputTypeID:  responseMessage.ID,
+		ReturnsEmpty:  returnsEmpty,
+		PathInfo:      pathInfo,
+	}
+	a.State.MethodByID[m.ID] = m
+	service.Methods = append(service.Methods, m)
 	return nil
 }
 
 // makeRequestMessage creates (if needed) the request message for `operation`. Returns the message
 // and the body field path (if any) for the request.
-func makeRequestMessage(a *api.API, parent *api.Message, operation *v3.Operation, packageName, template string) (*api.Message, string, error) {
-	messageName := fmt.Sprintf("%sRequest", operation.OperationId)
+func makeRequestMessage(a *api.API, parent *api.Message, operation *v3.Operation, methodName, packageName, template string) (*api.Message, string, error) {
+	messageName := fmt.Sprintf("%sRequest", methodName)
 	id := fmt.Sprintf("%s.%s", parent.ID, messageName)
-	methodID := fmt.Sprintf("%s.%s", parent.ID, operation.OperationId)
+	methodID := fmt.Sprintf("%s.%s", parent.ID, methodName)
 	message := &api.Message{
 		Name:             messageName,
 		ID:               id,
 		Package:          packageName,
-		Documentation:    fmt.Sprintf("Synthetic request message for the [%s()][%s] method.", operation.OperationId, methodID[1:]),
+		Documentation:    fmt.Sprintf("Synthetic request message for the [%s()][%s] method.", methodName, methodID[1:]),
 		SyntheticRequest: true,
 		Parent:           parent,
 	}
@@ -281,6 +434,7 @@ func makeRequestMessage(a *api.API, parent *api.Message, operation *v3.Operation
 		}
 		field := &api.Field{
 			Name:          p.Name,
+			ID:            fmt.Sprintf("%s.%s", id, p.Name),
 			JSONName:      p.Name, // OpenAPI fields are already camelCase
 			Documentation: documentation,
 			Deprecated:    p.Deprecated,
@@ -312,6 +466,7 @@ func makeRequestMessage(a *api.API, parent *api.Message, operation *v3.Operation
 		bodyFieldPath = name
 		field := &api.Field{
 			Name:          name,
+			ID:            fmt.Sprintf("%s.%s", id, name),
 			JSONName:      name,
 			Documentation: "The request body.",
 			Typez:         api.MESSAGE_TYPE,
@@ -342,25 +497,50 @@ func openapiIsAutoPopulated(typez api.Typez, schema *base.Schema, p *v3.Paramete
 	return typez == api.STRING_TYPE && schema.Format == "uuid" && openapiFieldIsOptional(p)
 }
 
-func makeResponseMessage(api *api.API, operation *v3.Operation, packageName string) (*api.Message, error) {
+// makeResponseMessage returns the response message of `operation`, and
+// whether the operation returns nothing. Operations without a response body
+// return `.google.protobuf.Empty`.
+func makeResponseMessage(api *api.API, operation *v3.Operation, packageName string) (*api.Message, bool, error) {
 	if operation.Responses == nil {
-		return nil, fmt.Errorf("missing Responses in specification for operation %s", operation.OperationId)
+		return nil, false, fmt.Errorf("missing Responses in specification for operation %s", operation.OperationId)
+	}
+	// Google's OpenAPI v3 specifications only include the "default"
+	// response. Other specifications list the response for each status code,
+	// the first successful one describes the result.
+	response := operation.Responses.Default
+	if response == nil {
+		response = successResponse(operation.Responses.Codes)
 	}
-	if operation.Responses.Default == nil {
-		// Google's OpenAPI v3 specifications only include the "default"
-		// response. In the future we may want to support more than this.
-		return nil, fmt.Errorf("expected Default response for operation %s", operation.OperationId)
+	if response == nil {
+		return nil, false, fmt.Errorf("expected a default or 2xx response for operation %s", operation.OperationId)
+	}
+	// A missing `Content` indicates the operation returns nothing:
+	//   https://swagger.io/docs/specification/v3_0/describing-responses/#empty-response-body
+	if response.Content == nil || response.Content.Len() == 0 {
+		if message, ok := api.State.MessageByID[".google.protobuf.Empty"]; ok {
+			return message, true, nil
+		}
+		return nil, false, fmt.Errorf("cannot find .google.protobuf.Empty for operation %s", operation.OperationId)
 	}
-	// TODO(#1590) - support a missing `Content` as an indication of `void`.
-	reference, err := findReferenceInContentMap(operation.Responses.Default.Content)
+	reference, err := findReferenceInContentMap(response.Content)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	id := fmt.Sprintf(".%s.%s", packageName, strings.TrimPrefix(reference, "#/components/schemas/"))
 	if message, ok := api.State.MessageByID[id]; ok {
-		return message, nil
+		return message, false, nil
 	}
-	return nil, fmt.Errorf("cannot find response message ref=%s", reference)
+	return nil, false, fmt.Errorf("cannot find response message ref=%s", reference)
+}
+
+// successResponse returns the first response with a 2xx status code, if any.
+func successResponse(codes *orderedmap.Map[string, *v3.Response]) *v3.Response {
+	for code, response := range codes.FromOldest() {
+		if strings.HasPrefix(code, "2") {
+			return response
+		}
+	}
+	return nil
 }
 
 func findReferenceInContentMap(content *orderedmap.Map[string, *v3.MediaType]) (string, error) {
@@ -384,12 +564,32 @@ func makeQueryParameters(operation *v3.Operation) map[string]bool {
 	return queryParameters
 }
 
-func makeMessageFields(state *api.APIState, packageName, messageName string, message *base.Schema) ([]*api.Field, error) {
+// makeMessageFields returns the fields of a message schema, and the groups
+// of fields created for `oneOf` and `anyOf` schemas.
+func makeMessageFields(state *api.APIState, packageName, messageName string, message *base.Schema) ([]*api.Field, []*api.OneOf, error) {
 	var fields []*api.Field
+	var oneOfs []*api.OneOf
+	// A message defined as one of several schemas has a single group of
+	// fields, named after its discriminator.
+	if len(message.OneOf) != 0 || len(message.AnyOf) != 0 {
+		name := "variant"
+		if message.Discriminator != nil && message.Discriminator.PropertyName != "" {
+			name = message.Discriminator.PropertyName
+		}
+		group, err := makeOneOf(packageName, messageName, name, message)
+		if err != nil {
+			return nil, nil, err
+		}
+		fields = append(fields, group.Fields...)
+		oneOfs = append(oneOfs, group)
+	}
+	if message.Properties == nil {
+		return fields, oneOfs, nil
+	}
 	for name, f := range message.Properties.FromOldest() {
 		schema, err := f.BuildSchema()
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		optional := true
 		for _, r := range message.Required {
@@ -398,13 +598,166 @@ func makeMessageFields(state *api.APIState, packageName, messageName string, mes
 				break
 			}
 		}
-		field, err := makeField(state, packageName, messageName, name, optional, schema)
-		if err != nil {
-			return nil, err
+		if variants := nonNullVariants(schema); len(variants) > 1 {
+			group, err := makeOneOf(packageName, messageName, name, schema)
+			if err != nil {
+				return nil, nil, err
+			}
+			fields = append(fields, group.Fields...)
+			oneOfs = append(oneOfs, group)
+			continue
+		} else if len(variants) == 1 {
+			// `anyOf: [{$ref: ...}, {type: "null"}]` is how OpenAPI 3.1
+			// specifications spell a nullable reference.
+			f = variants[0]
+			optional = true
+			if schema, err = f.BuildSchema(); err != nil {
+				return nil, nil, err
+			}
+		}
+		var field *api.Field
+		if reference := f.GetReference(); reference != "" && isObjectSchema(schema) {
+			field = makeReferenceField(packageName, name, reference, schema)
+		} else {
+			field, err = makeField(state, packageName, messageName, name, optional, schema)
+			if err != nil {
+				return nil, nil, err
+			}
 		}
 		fields = append(fields, field)
 	}
-	return fields, nil
+	return fields, oneOfs, nil
+}
+
+// nonNullVariants returns the alternatives of a `oneOf` or `anyOf` schema,
+// skipping the `{type: "null"}` alternatives used to mark nullable values.
+func nonNullVariants(schema *base.Schema) []*base.SchemaProxy {
+	var variants []*base.SchemaProxy
+	for _, proxy := range append(slices.Clone(schema.OneOf), schema.AnyOf...) {
+		if proxy.GetReference() == "" {
+			if s := proxy.Schema(); s != nil && len(s.Type) == 1 && s.Type[0] == "null" {
+				continue
+			}
+		}
+		variants = append(variants, proxy)
+	}
+	return variants
+}
+
+// makeOneOf creates a group of mutually exclusive fields, one for each
+// alternative of a `oneOf` or `anyOf` schema.
+//
+// Fields for referenced schemas are named after the discriminator mapping
+// for the schema, or after the schema itself. Fields for inline scalar
+// schemas are named after their type, e.g. `stringValue`.
+func makeOneOf(packageName, messageName, name string, schema *base.Schema) (*api.OneOf, error) {
+	group := &api.OneOf{
+		Name:          name,
+		ID:            fmt.Sprintf(".%s.%s.%s", packageName, messageName, name),
+		Documentation: schema.Description,
+	}
+	names := map[string]bool{}
+	for _, proxy := range nonNullVariants(schema) {
+		variant, err := proxy.BuildSchema()
+		if err != nil {
+			return nil, fmt.Errorf("cannot build schema for one-of %s.%s: %w", messageName, name, err)
+		}
+		var field *api.Field
+		if reference := proxy.GetReference(); reference != "" {
+			variantName := discriminatorValue(schema.Discriminator, reference)
+			field = makeReferenceField(packageName, strcase.ToLowerCamel(variantName), reference, variant)
+		} else {
+			typ, _ := schemaType(variant)
+			switch typ {
+			case "boolean", "integer", "number", "string":
+				field, err = makeScalarField(messageName, scalarVariantName(typ, variant), variant, false, variant)
+				if err != nil {
+					return nil, err
+				}
+			default:
+				return nil, fmt.Errorf("unsupported inline one-of alternative for %s.%s, only references and scalars are supported", messageName, name)
+			}
+		}
+		if names[field.Name] {
+			return nil, fmt.Errorf("duplicate alternative %s in one-of %s.%s, add a `title` to distinguish the alternatives", field.Name, messageName, name)
+		}
+		names[field.Name] = true
+		field.IsOneOf = true
+		field.Optional = false
+		group.Fields = append(group.Fields, field)
+	}
+	if len(group.Fields) == 0 {
+		return nil, fmt.Errorf("empty one-of for %s.%s", messageName, name)
+	}
+	return group, nil
+}
+
+// scalarVariantName returns the field name for an inline scalar alternative
+// in a one-of. The schema title is used if present, otherwise the name is
+// derived from the type, e.g. `stringValue`.
+func scalarVariantName(typ string, variant *base.Schema) string {
+	if variant.Title != "" {
+		return strcase.ToLowerCamel(variant.Title)
+	}
+	return typ + "Value"
+}
+
+// discriminatorValue returns the discriminator value for the schema
+// referenced by `reference`, or the schema name if the discriminator has no
+// mapping for it.
+func discriminatorValue(discriminator *base.Discriminator, reference string) string {
+	if discriminator != nil && discriminator.Mapping != nil {
+		for value, target := range discriminator.Mapping.FromOldest() {
+			if target == reference {
+				return value
+			}
+		}
+	}
+	return strings.TrimPrefix(reference, "#/components/schemas/")
+}
+
+// makeReferenceField creates a message field for a `$ref` to a component
+// schema. OpenAPI 3.1 allows `$ref` with sibling keywords, so these no
+// longer need an `allOf` wrapper.
+func makeReferenceField(packageName, name, reference string, schema *base.Schema) *api.Field {
+	return &api.Field{
+		Name:          name,
+		JSONName:      name, // OpenAPI field names are always camelCase
+		Documentation: schema.Description,
+		Deprecated:    schema.Deprecated != nil && *schema.Deprecated,
+		Typez:         api.MESSAGE_TYPE,
+		TypezID:       fmt.Sprintf(".%s.%s", packageName, strings.TrimPrefix(reference, "#/components/schemas/")),
+		Optional:      true,
+	}
+}
+
+// isObjectSchema returns true if the schema describes a JSON object with
+// its own properties, i.e., a message.
+func isObjectSchema(schema *base.Schema) bool {
+	typ, _ := schemaType(schema)
+	if typ == "" {
+		return schema.Properties != nil || len(schema.OneOf) != 0 || len(schema.AnyOf) != 0
+	}
+	return typ == "object" && (schema.AdditionalProperties == nil || schema.Properties != nil)
+}
+
+// schemaType returns the type of a schema and whether the schema is
+// nullable. OpenAPI 3.0 marks nullable schemas with `nullable: true`, while
+// OpenAPI 3.1 includes "null" in the list of types, e.g. `type: [string,
+// "null"]`.
+func schemaType(schema *base.Schema) (string, bool) {
+	nullable := schema.Nullable != nil && *schema.Nullable
+	var typ string
+	for _, t := range schema.Type {
+		if t == "null" {
+			nullable = true
+			continue
+		}
+		if typ == "" {
+			typ = t
+		}
+	}
+	return typ, nullable
 }
 
 func makeField(state *api.APIState, packageName, messageName, name string, optional bool, field *base.Schema) (*api.Field, error) {
@@ -412,12 +765,13 @@ func makeField(state *api.APIState, packageName, messageName, name string, optio
 		// Simple object fields name an AllOf attribute, but no `Type` attribute.
 		return makeObjectField(state, packageName, messageName, name, field)
 	}
-	if len(field.Type) == 0 {
+	typ, nullable := schemaType(field)
+	if typ == "" {
 		return nil, fmt.Errorf("missing field type for field %s.%s", messageName, name)
 	}
-	switch field.Type[0] {
+	switch typ {
 	case "boolean", "integer", "number", "string":
-		return makeScalarField(messageName, name, field, optional, field)
+		return makeScalarField(messageName, name, field, optional || nullable, field)
 	case "object":
 		return makeObjectField(state, packageName, messageName, name, field)
 	case "array":
@@ -508,11 +862,15 @@ func makeArrayField(state *api.APIState, packageName, messageName, name string,
 	if err != nil {
 		return nil, fmt.Errorf("cannot build items schema for %s.%s error=%q", messageName, name, err)
 	}
-	if len(schema.Type) != 1 {
+	typ, _ := schemaType(schema)
+	if typ == "" && reference != "" && isObjectSchema(schema) {
+		typ = "object"
+	}
+	if typ == "" {
 		return nil, fmt.Errorf("the items for field  %s.%s should have a single type", messageName, name)
 	}
 	var result *api.Field
-	switch schema.Type[0] {
+	switch typ {
 	case "boolean", "integer", "number", "string":
 		result, err = makeScalarField(messageName, name, schema, false, field)
 	case "object":
@@ -531,7 +889,7 @@ func makeArrayField(state *api.APIState, packageName, messageName, name string,
 			result, err = makeObjectField(state, packageName, messageName, name, schema)
 		}
 	default:
-		return nil, fmt.Errorf("unknown array field type for %s.%s %q", messageName, name, schema.Type[0])
+		return nil, fmt.Errorf("unknown array field type for %s.%s %q", messageName, name, typ)
 	}
 	if err != nil {
 		return nil, err
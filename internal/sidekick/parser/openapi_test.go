@@ -0,0 +1,343 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"os"
+	"path"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+	"github.com/julieqiu/librarianx/internal/sidekick/sample"
+)
+
+func newTestOpenAPIModel(t *testing.T, filename string) *api.API {
+	t.Helper()
+	return newTestOpenAPIModelWithOptions(t, filename, nil)
+}
+
+func newTestOpenAPIModelWithOptions(t *testing.T, filename string, options map[string]string) *api.API {
+	t.Helper()
+	contents, err := os.ReadFile(path.Join("testdata", filename))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := createDocModel(contents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	model, err := makeAPIForOpenAPI(sample.ServiceConfig(), doc, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := api.CrossReference(model); err != nil {
+		t.Fatal(err)
+	}
+	return model
+}
+
+type openAPIField struct {
+	Name     string
+	TypezID  string
+	Optional bool
+	IsOneOf  bool
+}
+
+func openAPIFields(fields []*api.Field) []openAPIField {
+	var got []openAPIField
+	for _, f := range fields {
+		got = append(got, openAPIField{Name: f.Name, TypezID: f.TypezID, Optional: f.Optional, IsOneOf: f.IsOneOf})
+	}
+	return got
+}
+
+func TestOpenAPI_OneOf(t *testing.T) {
+	model := newTestOpenAPIModel(t, "oneof_openapi.json")
+	pet, ok := model.State.MessageByID[".google.cloud.secretmanager.v1.Pet"]
+	if !ok {
+		t.Fatalf("missing message .google.cloud.secretmanager.v1.Pet")
+	}
+	want := []openAPIField{
+		{Name: "name", TypezID: "string", Optional: true},
+		{Name: "owner", TypezID: ".google.cloud.secretmanager.v1.Owner", Optional: true},
+		{Name: "home", TypezID: ".google.cloud.secretmanager.v1.Owner", Optional: true},
+		{Name: "housecat", TypezID: ".google.cloud.secretmanager.v1.Cat", IsOneOf: true},
+		{Name: "dog", TypezID: ".google.cloud.secretmanager.v1.Dog", IsOneOf: true},
+		{Name: "stringValue", TypezID: "string", IsOneOf: true},
+		{Name: "integerValue", TypezID: "int32", IsOneOf: true},
+	}
+	if diff := cmp.Diff(want, openAPIFields(pet.Fields)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	var groups []string
+	for _, group := range pet.OneOfs {
+		groups = append(groups, group.Name)
+		for _, field := range group.Fields {
+			if field.Group != group {
+				t.Errorf("field %s is not linked to its group %s", field.Name, group.Name)
+			}
+		}
+	}
+	if diff := cmp.Diff([]string{"animal", "age"}, groups); diff != "" {
+		t.Errorf("mismatch in one-of groups (-want +got):\n%s", diff)
+	}
+	if got, want := pet.OneOfs[0].Documentation, "The kind of animal."; got != want {
+		t.Errorf("one-of documentation = %q, want %q", got, want)
+	}
+}
+
+func TestOpenAPI_OneOfMessage(t *testing.T) {
+	model := newTestOpenAPIModel(t, "oneof_openapi.json")
+	animal, ok := model.State.MessageByID[".google.cloud.secretmanager.v1.Animal"]
+	if !ok {
+		t.Fatalf("missing message .google.cloud.secretmanager.v1.Animal")
+	}
+	want := []openAPIField{
+		{Name: "cat", TypezID: ".google.cloud.secretmanager.v1.Cat", IsOneOf: true},
+		{Name: "dog", TypezID: ".google.cloud.secretmanager.v1.Dog", IsOneOf: true},
+	}
+	if diff := cmp.Diff(want, openAPIFields(animal.Fields)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if len(animal.OneOfs) != 1 || animal.OneOfs[0].Name != "kind" {
+		t.Errorf("expected a single one-of named after the discriminator, got %v", animal.OneOfs)
+	}
+}
+
+func TestOpenAPI_OneOfScalarTitles(t *testing.T) {
+	model := newTestOpenAPIModel(t, "oneof_openapi.json")
+	schedule, ok := model.State.MessageByID[".google.cloud.secretmanager.v1.Schedule"]
+	if !ok {
+		t.Fatalf("missing message .google.cloud.secretmanager.v1.Schedule")
+	}
+	want := []openAPIField{
+		{Name: "requestId", TypezID: "string", IsOneOf: true},
+		{Name: "label", TypezID: "string", IsOneOf: true},
+		{Name: "integerValue", TypezID: "int64", IsOneOf: true},
+	}
+	if diff := cmp.Diff(want, openAPIFields(schedule.Fields)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOpenAPI_OneOfDuplicateScalars(t *testing.T) {
+	contents := []byte(`{
+    "openapi": "3.1.0",
+    "info": {"title": "Test", "version": "v1"},
+    "paths": {},
+    "components": {
+        "schemas": {
+            "Schedule": {
+                "type": "object",
+                "properties": {
+                    "when": {
+                        "oneOf": [
+                            {"type": "string", "format": "uuid"},
+                            {"type": "string"}
+                        ]
+                    }
+                }
+            }
+        }
+    }
+}`)
+	doc, err := createDocModel(contents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := makeAPIForOpenAPI(sample.ServiceConfig(), doc, nil); err == nil {
+		t.Errorf("expected an error with duplicate one-of alternatives, got=%v", got)
+	}
+}
+
+func TestOpenAPI_ReturnsEmpty(t *testing.T) {
+	model := newTestOpenAPIModel(t, "oneof_openapi.json")
+	for _, test := range []struct {
+		id           string
+		outputTypeID string
+		returnsEmpty bool
+	}{
+		{".google.cloud.secretmanager.v1.SecretManagerService.GetPet", ".google.cloud.secretmanager.v1.Pet", false},
+		{".google.cloud.secretmanager.v1.SecretManagerService.DeletePet", ".google.protobuf.Empty", true},
+	} {
+		method, ok := model.State.MethodByID[test.id]
+		if !ok {
+			t.Fatalf("missing method %s", test.id)
+		}
+		if method.OutputTypeID != test.outputTypeID {
+			t.Errorf("%s: OutputTypeID = %q, want %q", test.id, method.OutputTypeID, test.outputTypeID)
+		}
+		if method.ReturnsEmpty != test.returnsEmpty {
+			t.Errorf("%s: ReturnsEmpty = %t, want %t", test.id, method.ReturnsEmpty, test.returnsEmpty)
+		}
+	}
+}
+
+func TestOpenAPI_Services(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		options map[string]string
+		want    map[string][]string
+	}{
+		{
+			name: "single service",
+			want: map[string][]string{
+				"SecretManagerService": {"pets.list", "pets.create", "getPet", "ListOwners", "GetInventory", "ListOrders", "CheckHealth"},
+			},
+		},
+		{
+			name:    "by tag",
+			options: map[string]string{"service-grouping": "tag"},
+			want: map[string][]string{
+				"Pets":                 {"List", "Create", "GetPet"},
+				"Owners":               {"ListOwners"},
+				"Store":                {"GetInventory", "ListOrders"},
+				"SecretManagerService": {"CheckHealth"},
+			},
+		},
+		{
+			name: "by path prefix",
+			options: map[string]string{
+				"service-grouping":  "tag",
+				"service:Inventory": "/v1/stores/{store}/inventory",
+				"service:Stores":    "/v1/stores",
+			},
+			want: map[string][]string{
+				"Pets":                 {"List", "Create", "GetPet"},
+				"Owners":               {"ListOwners"},
+				"Inventory":            {"GetInventory"},
+				"Stores":               {"ListOrders"},
+				"SecretManagerService": {"CheckHealth"},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			model := newTestOpenAPIModelWithOptions(t, "services_openapi.json", test.options)
+			got := map[string][]string{}
+			for _, service := range model.Services {
+				for _, method := range service.Methods {
+					got[service.Name] = append(got[service.Name], method.Name)
+					if method.InputType.Parent == nil || method.InputType.Parent.ID != service.ID {
+						t.Errorf("request for %s is not nested in its service %s", method.ID, service.ID)
+					}
+				}
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	model := newTestOpenAPIModelWithOptions(t, "services_openapi.json", map[string]string{"service-grouping": "tag"})
+	pets := model.State.ServiceByID[".google.cloud.secretmanager.v1.Pets"]
+	if pets == nil {
+		t.Fatal("missing service .google.cloud.secretmanager.v1.Pets")
+	}
+	if got, want := pets.Documentation, "Operations on pets."; got != want {
+		t.Errorf("documentation = %q, want %q", got, want)
+	}
+	if _, ok := model.State.MethodByID[".google.cloud.secretmanager.v1.Pets.List"]; !ok {
+		t.Errorf("missing method .google.cloud.secretmanager.v1.Pets.List")
+	}
+}
+
+func TestOpenAPI_ServiceRulesErrors(t *testing.T) {
+	for _, options := range []map[string]string{
+		{"service-grouping": "path"},
+		{"service:": "/v1/pets"},
+		{"service:Pets": "/v1/pets", "service:Animals": "/v1/pets"},
+	} {
+		if _, err := newServiceRules(options); err == nil {
+			t.Errorf("newServiceRules(%v): expected an error", options)
+		}
+	}
+}
+
+func TestOpenAPI_ServiceSchemaCollision(t *testing.T) {
+	model := newTestOpenAPIModelWithOptions(t, "tags_schemas_openapi.json", map[string]string{"service-grouping": "tag"})
+	got := map[string][]string{}
+	for _, service := range model.Services {
+		for _, method := range service.Methods {
+			got[service.Name] = append(got[service.Name], method.Name)
+		}
+	}
+	want := map[string][]string{
+		"PetService": {"GetPet"},
+		"Owner":      {"ListOwners"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	pet, ok := model.State.MessageByID[".google.cloud.secretmanager.v1.Pet"]
+	if !ok {
+		t.Fatal("missing message .google.cloud.secretmanager.v1.Pet")
+	}
+	if pet.ServicePlaceholder {
+		t.Errorf("message %s was replaced by a service placeholder", pet.ID)
+	}
+	method := model.State.MethodByID[".google.cloud.secretmanager.v1.PetService.GetPet"]
+	if method == nil {
+		t.Fatal("missing method .google.cloud.secretmanager.v1.PetService.GetPet")
+	}
+	if got, want := method.OutputTypeID, pet.ID; got != want {
+		t.Errorf("OutputTypeID = %q, want %q", got, want)
+	}
+}
+
+func TestOpenAPI_ServiceSchemaCollisionError(t *testing.T) {
+	contents, err := os.ReadFile(path.Join("testdata", "tags_schemas_openapi.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := createDocModel(contents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	options := map[string]string{"service-grouping": "tag", "service:Pet": "/v1/pets"}
+	if _, err := makeAPIForOpenAPI(sample.ServiceConfig(), doc, options); err == nil {
+		t.Errorf("makeAPIForOpenAPI(%v): expected an error", options)
+	}
+}
+
+func TestOpenAPI_Diff(t *testing.T) {
+	before := newTestOpenAPIModel(t, "pagination_openapi.json")
+	if got := api.Diff(before, newTestOpenAPIModel(t, "pagination_openapi.json")); len(got) != 0 {
+		t.Errorf("expected no changes, got=%v", got)
+	}
+
+	after := newTestOpenAPIModel(t, "pagination_openapi.json")
+	response := after.State.MessageByID[".google.cloud.secretmanager.v1.ListFoosResponse"]
+	if response == nil {
+		t.Fatal("missing message .google.cloud.secretmanager.v1.ListFoosResponse")
+	}
+	response.Fields = slices.DeleteFunc(response.Fields, func(f *api.Field) bool { return f.Name == "secrets" })
+	response.Fields = append(response.Fields, &api.Field{
+		Name:     "total",
+		ID:       ".google.cloud.secretmanager.v1.ListFoosResponse.total",
+		JSONName: "total",
+		Typez:    api.INT32_TYPE,
+		TypezID:  "int32",
+	})
+	want := []*api.Change{
+		{Kind: api.Removed, Element: "field", ID: ".google.cloud.secretmanager.v1.ListFoosResponse.secrets", Breaking: true},
+		{Kind: api.Added, Element: "field", ID: ".google.cloud.secretmanager.v1.ListFoosResponse.total"},
+	}
+	if diff := cmp.Diff(want, api.Diff(before, after)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
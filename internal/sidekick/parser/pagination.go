@@ -15,6 +15,7 @@
 package parser
 
 import (
+	"fmt"
 	"slices"
 
 	"github.com/julieqiu/librarianx/internal/sidekick/api"
@@ -48,6 +49,30 @@ func updateMethodPagination(overrides []config.PaginationOverride, a *api.API) {
 	}
 }
 
+// CheckPaginationOverrides returns an error for each override that does not
+// apply to the model, because the method does not exist, is not paginated, or
+// its response has no field named `ItemField`.
+func CheckPaginationOverrides(model *api.API, overrides []config.PaginationOverride) []error {
+	var errs []error
+	for _, override := range overrides {
+		method, ok := model.State.MethodByID[override.ID]
+		if !ok {
+			errs = append(errs, fmt.Errorf("cannot find method %s to apply pagination override", override.ID))
+			continue
+		}
+		request := model.State.MessageByID[method.InputTypeID]
+		response := model.State.MessageByID[method.OutputTypeID]
+		if paginationRequestInfo(request) == nil || response == nil || paginationResponseNextPageToken(response) == nil {
+			errs = append(errs, fmt.Errorf("method %s is not paginated, cannot apply pagination override", override.ID))
+			continue
+		}
+		if !slices.ContainsFunc(response.Fields, func(f *api.Field) bool { return f.Name == override.ItemField }) {
+			errs = append(errs, fmt.Errorf("cannot find field %s in %s to apply pagination override for %s", override.ItemField, response.ID, override.ID))
+		}
+	}
+	return errs
+}
+
 func paginationRequestInfo(request *api.Message) *api.Field {
 	if request == nil {
 		return nil
@@ -685,3 +685,50 @@ func TestPaginationResponseNextPageToken(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckPaginationOverrides(t *testing.T) {
+	request := &api.Message{
+		Name: "Request",
+		ID:   ".package.Request",
+		Fields: []*api.Field{
+			{Name: "page_token", JSONName: "pageToken", ID: ".package.Request.pageToken", Typez: api.STRING_TYPE},
+			{Name: "page_size", JSONName: "pageSize", ID: ".package.Request.pageSize", Typez: api.INT32_TYPE},
+		},
+	}
+	response := &api.Message{
+		Name: "Response",
+		ID:   ".package.Response",
+		Fields: []*api.Field{
+			{Name: "next_page_token", JSONName: "nextPageToken", ID: ".package.Response.nextPageToken", Typez: api.STRING_TYPE},
+			{Name: "items", JSONName: "items", ID: ".package.Response.items", Typez: api.MESSAGE_TYPE, TypezID: ".package.Resource", Repeated: true},
+		},
+	}
+	resource := &api.Message{Name: "Resource", ID: ".package.Resource"}
+	service := &api.Service{
+		Name: "Service",
+		ID:   ".package.Service",
+		Methods: []*api.Method{
+			{Name: "List", ID: ".package.Service.List", InputTypeID: request.ID, OutputTypeID: response.ID},
+			{Name: "Get", ID: ".package.Service.Get", InputTypeID: resource.ID, OutputTypeID: resource.ID},
+		},
+	}
+	model := api.NewTestAPI([]*api.Message{request, response, resource}, []*api.Enum{}, []*api.Service{service})
+	overrides := []config.PaginationOverride{
+		{ID: ".package.Service.List", ItemField: "items"},
+		{ID: ".package.Service.List", ItemField: "renamed"},
+		{ID: ".package.Service.Get", ItemField: "items"},
+		{ID: ".package.Service.Missing", ItemField: "items"},
+	}
+	var got []string
+	for _, err := range CheckPaginationOverrides(model, overrides) {
+		got = append(got, err.Error())
+	}
+	want := []string{
+		"cannot find field renamed in .package.Response to apply pagination override for .package.Service.List",
+		"method .package.Service.Get is not paginated, cannot apply pagination override",
+		"cannot find method .package.Service.Missing to apply pagination override",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch, (-want, +got):\n%s", diff)
+	}
+}
@@ -15,7 +15,10 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
+	"strings"
 
 	"github.com/julieqiu/librarianx/internal/sidekick/api"
 	"github.com/julieqiu/librarianx/internal/sidekick/config"
@@ -34,6 +37,8 @@ func CreateModel(config *config.Config) (*api.API, error) {
 		model, err = ParseOpenAPI(config)
 	case "protobuf":
 		model, err = ParseProtobuf(config)
+	case "descriptor-set":
+		model, err = ParseDescriptorSet(config)
 	case "none":
 		return nil, nil
 	default:
@@ -42,6 +47,9 @@ func CreateModel(config *config.Config) (*api.API, error) {
 	if err != nil {
 		return nil, err
 	}
+	if errs := CheckPaginationOverrides(model, config.PaginationOverrides); len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
 	updateMethodPagination(config.PaginationOverrides, model)
 	api.LabelRecursiveFields(model)
 	if err := api.CrossReference(model); err != nil {
@@ -57,6 +65,11 @@ func CreateModel(config *config.Config) (*api.API, error) {
 	if err := api.Validate(model); err != nil {
 		return nil, err
 	}
+	if config.Lint != nil {
+		if err := lintModel(model, config.Lint); err != nil {
+			return nil, err
+		}
+	}
 	if name, ok := config.Source["name-override"]; ok {
 		model.Name = name
 	}
@@ -68,3 +81,18 @@ func CreateModel(config *config.Config) (*api.API, error) {
 	}
 	return model, nil
 }
+
+// lintModel logs the AIP lint findings, and returns an error if the
+// configuration requires no findings.
+func lintModel(model *api.API, cfg *config.Lint) error {
+	findings := api.Lint(model, cfg)
+	var lines []string
+	for _, f := range findings {
+		slog.Warn("AIP lint finding", "rule", f.Rule, "id", f.ID, "message", f.Message)
+		lines = append(lines, f.String())
+	}
+	if cfg.Fail && len(findings) != 0 {
+		return fmt.Errorf("found %d AIP lint findings:\n%s", len(findings), strings.Join(lines, "\n"))
+	}
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
 	"github.com/julieqiu/librarianx/internal/sidekick/config"
 )
 
@@ -91,7 +92,6 @@ func TestCreateModelOpenAPI(t *testing.T) {
 }
 
 func TestCreateModelProtobuf(t *testing.T) {
-	requireProtoc(t)
 	cfg := &config.Config{
 		General: config.GeneralConfig{
 			SpecificationFormat: "protobuf",
@@ -114,7 +114,6 @@ func TestCreateModelProtobuf(t *testing.T) {
 }
 
 func TestCreateModelOverrides(t *testing.T) {
-	requireProtoc(t)
 	cfg := &config.Config{
 		General: config.GeneralConfig{
 			SpecificationFormat: "protobuf",
@@ -149,7 +148,6 @@ func TestCreateModelOverrides(t *testing.T) {
 }
 
 func TestCreateModelNone(t *testing.T) {
-	requireProtoc(t)
 	cfg := &config.Config{
 		General: config.GeneralConfig{
 			SpecificationFormat: "none",
@@ -210,3 +208,38 @@ func TestCreateModelBadParse(t *testing.T) {
 		t.Errorf("expected error with bad specification, got=%v", got)
 	}
 }
+
+func TestCreateModelLint(t *testing.T) {
+	cfg := &config.Config{
+		General: config.GeneralConfig{
+			SpecificationFormat: "protobuf",
+			ServiceConfig:       secretManagerYamlRelative,
+			SpecificationSource: "google/cloud/secretmanager/v1",
+		},
+		Source: map[string]string{
+			"googleapis-root": path.Join(testdataDir, "googleapis"),
+		},
+		Lint: &config.Lint{Fail: true},
+	}
+	if _, err := CreateModel(cfg); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLintModel(t *testing.T) {
+	service := &api.Service{Name: "Service", ID: ".test.Service", Package: "test"}
+	model := api.NewTestAPI([]*api.Message{}, []*api.Enum{}, []*api.Service{service})
+	if err := lintModel(model, &config.Lint{}); err != nil {
+		t.Errorf("findings should only be logged, got error %v", err)
+	}
+	if err := lintModel(model, &config.Lint{Fail: true}); err == nil {
+		t.Errorf("expected an error with findings and `fail = true`")
+	}
+	suppressed := &config.Lint{
+		Fail:         true,
+		Suppressions: []config.LintSuppression{{Rule: api.LintServiceDocumentation, ID: service.ID}},
+	}
+	if err := lintModel(model, suppressed); err != nil {
+		t.Errorf("expected no errors with suppressed findings, got %v", err)
+	}
+}
@@ -16,21 +16,26 @@ package parser
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
 	"maps"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"slices"
 	"strings"
 
+	"github.com/bufbuild/protocompile"
+	"github.com/bufbuild/protocompile/linker"
 	"github.com/julieqiu/librarianx/internal/sidekick/api"
 	"github.com/julieqiu/librarianx/internal/sidekick/config"
 	"github.com/julieqiu/librarianx/internal/sidekick/parser/svcconfig"
 	"github.com/julieqiu/librarianx/internal/sidekick/protobuf"
 	"google.golang.org/genproto/googleapis/api/serviceconfig"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
 	"google.golang.org/protobuf/types/descriptorpb"
 	"google.golang.org/protobuf/types/pluginpb"
 )
@@ -69,8 +74,12 @@ func newCodeGeneratorRequest(source string, options map[string]string) (_ *plugi
 		return nil, err
 	}
 
-	// Call protoc with the given arguments.
-	contents, err := protoc(tempFile.Name(), files, options)
+	var contents []byte
+	if options["compiler"] == "protoc" {
+		contents, err = protoc(tempFile.Name(), files, options)
+	} else {
+		contents, err = compile(files, options)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -127,6 +136,88 @@ func protoc(tempFile string, files []string, options map[string]string) ([]byte,
 	return os.ReadFile(tempFile)
 }
 
+// compile parses and links files in-process, returning the same serialized
+// `FileDescriptorSet` as `protoc --include_imports --include_source_info
+// --retain_options`.
+//
+// Like protoc, the files are named relative to the source root containing
+// them. Imports not found in any source root fall back to the well-known
+// types compiled into the binary.
+func compile(files []string, options map[string]string) ([]byte, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no proto files to compile")
+	}
+	var roots []string
+	for _, name := range config.SourceRoots(options) {
+		if path, ok := options[name]; ok {
+			roots = append(roots, path)
+		}
+	}
+	var names []string
+	for _, filename := range files {
+		name, err := importName(filename, roots)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	compiler := protocompile.Compiler{
+		Resolver:       protocompile.WithStandardImports(&protocompile.SourceResolver{ImportPaths: roots}),
+		SourceInfoMode: protocompile.SourceInfoStandard,
+	}
+	compiled, err := compiler.Compile(context.Background(), names...)
+	if err != nil {
+		return nil, fmt.Errorf("error compiling protos\nroots:\n%v\nfiles:\n%v\n: %w", roots, names, err)
+	}
+
+	// Imports come before the files importing them, as they do in the
+	// output of protoc.
+	descriptors := &descriptorpb.FileDescriptorSet{}
+	seen := map[string]bool{}
+	var add func(fd linker.File)
+	add = func(fd linker.File) {
+		if seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+		imports := fd.Imports()
+		for i := range imports.Len() {
+			add(fd.FindImportByPath(imports.Get(i).Path()))
+		}
+		descriptors.File = append(descriptors.File, fileDescriptorProto(fd))
+	}
+	for _, fd := range compiled {
+		add(fd)
+	}
+	return proto.Marshal(descriptors)
+}
+
+// importName returns the name of filename relative to the first source root
+// containing it, i.e. the name protoc uses for the file.
+func importName(filename string, roots []string) (string, error) {
+	if len(roots) == 0 {
+		return filepath.ToSlash(filename), nil
+	}
+	for _, root := range roots {
+		rel, err := filepath.Rel(root, filename)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		return filepath.ToSlash(rel), nil
+	}
+	return "", fmt.Errorf("%s is not in any of the source roots %v", filename, roots)
+}
+
+// fileDescriptorProto returns the descriptor of fd, keeping the source info
+// and options of files compiled from source.
+func fileDescriptorProto(fd linker.File) *descriptorpb.FileDescriptorProto {
+	if result, ok := fd.(linker.Result); ok {
+		return result.FileDescriptorProto()
+	}
+	return protodesc.ToFileDescriptorProto(fd)
+}
+
 func newCompilerVersion() *pluginpb.Version {
 	var (
 		i int32
@@ -25,7 +25,6 @@ import (
 )
 
 func TestProtobuf_LocationMixin(t *testing.T) {
-	requireProtoc(t)
 	var serviceConfig = &serviceconfig.Service{
 		Name:  "test.googleapis.com",
 		Title: "Test API",
@@ -92,7 +91,6 @@ func TestProtobuf_LocationMixin(t *testing.T) {
 }
 
 func TestProtobuf_IAMMixin(t *testing.T) {
-	requireProtoc(t)
 	var serviceConfig = &serviceconfig.Service{
 		Name:  "test.googleapis.com",
 		Title: "Test API",
@@ -160,7 +158,6 @@ func TestProtobuf_IAMMixin(t *testing.T) {
 }
 
 func TestProtobuf_OperationMixin(t *testing.T) {
-	requireProtoc(t)
 	var serviceConfig = &serviceconfig.Service{
 		Name:  "test.googleapis.com",
 		Title: "Test API",
@@ -233,7 +230,6 @@ func TestProtobuf_OperationMixin(t *testing.T) {
 }
 
 func TestProtobuf_OperationMixinNoEmpty(t *testing.T) {
-	requireProtoc(t)
 	var serviceConfig = &serviceconfig.Service{
 		Name:  "test.googleapis.com",
 		Title: "Test API",
@@ -327,7 +323,6 @@ func TestProtobuf_OperationMixinNoEmpty(t *testing.T) {
 }
 
 func TestProtobuf_DuplicateMixin(t *testing.T) {
-	requireProtoc(t)
 	var serviceConfig = &serviceconfig.Service{
 		Name:  "test.googleapis.com",
 		Title: "Test API",
@@ -26,12 +26,12 @@ import (
 	"github.com/julieqiu/librarianx/internal/sidekick/sample"
 	"google.golang.org/genproto/googleapis/api/annotations"
 	"google.golang.org/genproto/googleapis/api/serviceconfig"
+	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/apipb"
 	"google.golang.org/protobuf/types/pluginpb"
 )
 
 func TestProtobuf_Info(t *testing.T) {
-	requireProtoc(t)
 	sc := sample.ServiceConfig()
 	got := makeAPIForProtobuf(sc, newTestCodeGeneratorRequest(t, "scalar.proto"))
 	if got.Name != "secretmanager" {
@@ -46,7 +46,6 @@ func TestProtobuf_Info(t *testing.T) {
 }
 
 func TestProtobuf_PartialInfo(t *testing.T) {
-	requireProtoc(t)
 	var serviceConfig = &serviceconfig.Service{
 		Name:  "secretmanager.googleapis.com",
 		Title: "Secret Manager API",
@@ -65,7 +64,6 @@ func TestProtobuf_PartialInfo(t *testing.T) {
 }
 
 func TestProtobuf_Scalar(t *testing.T) {
-	requireProtoc(t)
 	test := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "scalar.proto"))
 	message, ok := test.State.MessageByID[".test.Fake"]
 	if !ok {
@@ -187,7 +185,6 @@ func TestProtobuf_Scalar(t *testing.T) {
 }
 
 func TestProtobuf_ScalarArray(t *testing.T) {
-	requireProtoc(t)
 	test := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "scalar_array.proto"))
 	message, ok := test.State.MessageByID[".test.Fake"]
 	if !ok {
@@ -236,7 +233,6 @@ func TestProtobuf_ScalarArray(t *testing.T) {
 }
 
 func TestProtobuf_ScalarOptional(t *testing.T) {
-	requireProtoc(t)
 	test := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "scalar_optional.proto"))
 	message, ok := test.State.MessageByID[".test.Fake"]
 	if !ok {
@@ -285,7 +281,6 @@ func TestProtobuf_ScalarOptional(t *testing.T) {
 }
 
 func TestProtobuf_SkipExternalMessages(t *testing.T) {
-	requireProtoc(t)
 	test := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "with_import.proto"))
 	// Both `ImportedMessage` and `LocalMessage` should be in the index:
 	_, ok := test.State.MessageByID[".away.ImportedMessage"]
@@ -331,7 +326,6 @@ func TestProtobuf_SkipExternalMessages(t *testing.T) {
 }
 
 func TestProtobuf_SkipExternaEnums(t *testing.T) {
-	requireProtoc(t)
 	test := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "with_import.proto"))
 	// Both `ImportedEnum` and `LocalEnum` should be in the index:
 	_, ok := test.State.EnumByID[".away.ImportedEnum"]
@@ -371,7 +365,6 @@ func TestProtobuf_SkipExternaEnums(t *testing.T) {
 }
 
 func TestProtobuf_Comments(t *testing.T) {
-	requireProtoc(t)
 	test := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "comments.proto"))
 	message, ok := test.State.MessageByID[".test.Request"]
 	if !ok {
@@ -474,7 +467,6 @@ func TestProtobuf_Comments(t *testing.T) {
 }
 
 func TestProtobuf_UniqueEnumValues(t *testing.T) {
-	requireProtoc(t)
 	test := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "enum_values.proto"))
 	withAlias, ok := test.State.EnumByID[".test.WithAlias"]
 	if !ok {
@@ -527,7 +519,6 @@ func TestProtobuf_UniqueEnumValues(t *testing.T) {
 }
 
 func TestProtobuf_OneOfs(t *testing.T) {
-	requireProtoc(t)
 	test := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "oneofs.proto"))
 	message, ok := test.State.MessageByID[".test.Fake"]
 	if !ok {
@@ -599,7 +590,6 @@ func TestProtobuf_OneOfs(t *testing.T) {
 }
 
 func TestProtobuf_ObjectFields(t *testing.T) {
-	requireProtoc(t)
 	test := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "object_fields.proto"))
 	message, ok := test.State.MessageByID[".test.Fake"]
 	if !ok {
@@ -633,7 +623,6 @@ func TestProtobuf_ObjectFields(t *testing.T) {
 }
 
 func TestProtobuf_WellKnownTypeFields(t *testing.T) {
-	requireProtoc(t)
 	test := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "wkt_fields.proto"))
 	message, ok := test.State.MessageByID[".test.Fake"]
 	if !ok {
@@ -697,7 +686,6 @@ func TestProtobuf_WellKnownTypeFields(t *testing.T) {
 }
 
 func TestProtobuf_JsonName(t *testing.T) {
-	requireProtoc(t)
 	test := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "json_name.proto"))
 	message, ok := test.State.MessageByID[".test.Request"]
 	if !ok {
@@ -732,7 +720,6 @@ func TestProtobuf_JsonName(t *testing.T) {
 }
 
 func TestProtobuf_MapFields(t *testing.T) {
-	requireProtoc(t)
 	test := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "map_fields.proto"))
 	message, ok := test.State.MessageByID[".test.Fake"]
 	if !ok {
@@ -827,7 +814,6 @@ func TestProtobuf_MapFields(t *testing.T) {
 }
 
 func TestProtobuf_Service(t *testing.T) {
-	requireProtoc(t)
 	test := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "test_service.proto"))
 	service, ok := test.State.ServiceByID[".test.TestService"]
 	if !ok {
@@ -963,7 +949,6 @@ func TestProtobuf_Service(t *testing.T) {
 }
 
 func TestProtobuf_QueryParameters(t *testing.T) {
-	requireProtoc(t)
 	test := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "query_parameters.proto"))
 	service, ok := test.State.ServiceByID[".test.TestService"]
 	if !ok {
@@ -1029,7 +1014,6 @@ func TestProtobuf_QueryParameters(t *testing.T) {
 }
 
 func TestProtobuf_Enum(t *testing.T) {
-	requireProtoc(t)
 	test := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "enum.proto"))
 	e, ok := test.State.EnumByID[".test.Code"]
 	if !ok {
@@ -1079,7 +1063,6 @@ func TestProtobuf_TrimLeadingSpacesInDocumentation(t *testing.T) {
 }
 
 func TestProtobuf_Pagination(t *testing.T) {
-	requireProtoc(t)
 	test := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "pagination.proto"))
 	updateMethodPagination(nil, test)
 	service, ok := test.State.ServiceByID[".test.TestService"]
@@ -1391,7 +1374,6 @@ func TestProtobuf_Pagination(t *testing.T) {
 }
 
 func TestProtobuf_OperationInfo(t *testing.T) {
-	requireProtoc(t)
 	var serviceConfig = &serviceconfig.Service{
 		Name:  "test.googleapis.com",
 		Title: "Test API",
@@ -1518,7 +1500,6 @@ func TestProtobuf_OperationInfo(t *testing.T) {
 }
 
 func TestProtobuf_AutoPopulated(t *testing.T) {
-	requireProtoc(t)
 	var serviceConfig = &serviceconfig.Service{
 		Name:  "test.googleapis.com",
 		Title: "Test API",
@@ -1684,7 +1665,6 @@ func TestProtobuf_AutoPopulated(t *testing.T) {
 }
 
 func TestProtobuf_Deprecated(t *testing.T) {
-	requireProtoc(t)
 	test := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "deprecated.proto"))
 	s, ok := test.State.ServiceByID[".test.ServiceA"]
 	if !ok {
@@ -1804,7 +1784,6 @@ func TestProtobuf_Deprecated(t *testing.T) {
 }
 
 func TestProtobuf_ParseBadFiles(t *testing.T) {
-	requireProtoc(t)
 	for _, general := range []config.GeneralConfig{
 		{SpecificationSource: "-invalid-file-name-", ServiceConfig: secretManagerYamlFullPath},
 		{SpecificationSource: protobufFile, ServiceConfig: "-invalid-file-name-"},
@@ -1819,6 +1798,52 @@ func TestProtobuf_ParseBadFiles(t *testing.T) {
 	}
 }
 
+func TestProtobuf_CompilerMatchesProtoc(t *testing.T) {
+	requireProtoc(t)
+	options := map[string]string{
+		"googleapis-root":   "../testdata/googleapis",
+		"extra-protos-root": "testdata",
+		"include-list":      "scalar.proto,deprecated.proto",
+	}
+	got, err := newCodeGeneratorRequest("testdata", options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	options["compiler"] = "protoc"
+	want, err := newCodeGeneratorRequest("testdata", options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want.FileToGenerate, got.FileToGenerate); diff != "" {
+		t.Errorf("mismatch in FileToGenerate (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(want.SourceFileDescriptors, got.SourceFileDescriptors, protocmp.Transform()); diff != "" {
+		t.Errorf("mismatch in SourceFileDescriptors (-want +got):\n%s", diff)
+	}
+}
+
+func TestProtobuf_ImportName(t *testing.T) {
+	roots := []string{"../testdata/googleapis", "testdata"}
+	for _, test := range []struct {
+		filename string
+		want     string
+	}{
+		{"../testdata/googleapis/google/cloud/secretmanager/v1/service.proto", "google/cloud/secretmanager/v1/service.proto"},
+		{"testdata/scalar.proto", "scalar.proto"},
+	} {
+		got, err := importName(test.filename, roots)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("importName(%q) = %q, want %q", test.filename, got, test.want)
+		}
+	}
+	if _, err := importName("other/scalar.proto", roots); err == nil {
+		t.Errorf("expected an error for a file outside the source roots")
+	}
+}
+
 func newTestCodeGeneratorRequest(t *testing.T, filename string) *pluginpb.CodeGeneratorRequest {
 	t.Helper()
 	options := map[string]string{
@@ -23,7 +23,6 @@ import (
 )
 
 func TestExamples(t *testing.T) {
-	requireProtoc(t)
 	for _, test := range []struct {
 		methodID string
 		want     []*api.RoutingInfo
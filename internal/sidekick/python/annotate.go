@@ -0,0 +1,1012 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+	"github.com/julieqiu/librarianx/internal/sidekick/language"
+	"github.com/julieqiu/librarianx/internal/sidekick/license"
+)
+
+// moduleNames are the names imported by `types.py`. Generated types must not
+// use them.
+var moduleNames = []string{
+	"Any", "Dict", "List", "MutableMapping", "MutableSequence", "Optional",
+	"annotations", "dataclasses", "enum", "proto",
+}
+
+type modelAnnotations struct {
+	PackageName      string
+	DistributionName string
+	Version          string
+	CopyrightYear    string
+	BoilerPlate      []string
+	// The package description in `pyproject.toml`, quoted.
+	Description string
+	// The package docstring.
+	DocLines []string
+	// Exactly one of these is true.
+	Dataclass bool
+	ProtoPlus bool
+	// The protobuf package for proto-plus messages.
+	ProtoPackage string
+	// The top-level messages and enums, including external types. Nested
+	// types are generated within their parent.
+	Messages []*api.Message
+	Enums    []*api.Enum
+	// The names of the top-level types, exported by the package.
+	TypeNames []string
+	// The `google.protobuf` modules used by proto-plus messages.
+	ProtobufModules []string
+	// Only services with at least one generated method.
+	Services []*api.Service
+	// Enable the runtime support for pagination, long-running operations,
+	// and auto-populated request IDs.
+	HasPagination    bool
+	HasLROs          bool
+	HasAutoPopulated bool
+}
+
+// HasServices returns true if the package has any clients.
+func (m *modelAnnotations) HasServices() bool {
+	return len(m.Services) != 0
+}
+
+// ClientNames returns the names of the clients, exported by the package.
+func (m *modelAnnotations) ClientNames() []string {
+	var names []string
+	for _, s := range m.Services {
+		names = append(names, s.Codec.(*serviceAnnotations).Name)
+	}
+	return names
+}
+
+type serviceAnnotations struct {
+	// The name of the client, e.g. `SecretManagerServiceClient`.
+	Name        string
+	DocLines    []string
+	DefaultHost string
+	// Only the generated methods.
+	Methods []*api.Method
+	// Set if any method returns a long-running operation. The client polls
+	// the operations using the `GetOperation` mixin.
+	Poll *pollAnnotation
+}
+
+type pollAnnotation struct {
+	SendName    string
+	RequestType string
+	NameField   string
+}
+
+type methodAnnotation struct {
+	// The name of the client method, e.g. `create_secret`.
+	Name string
+	// The name of the helper that sends the request, e.g. `_create_secret`.
+	SendName     string
+	DocLines     []string
+	RequestType  string
+	ResponseType string
+	// The class used to decode the response, or `None`.
+	ResponseClass string
+	ReturnsEmpty  bool
+	// The HTTP method, e.g. `POST`.
+	Verb string
+	// A Python expression computing the request path.
+	Path string
+	// A Python list with the query parameters, and whether they are sent
+	// with default values.
+	Query string
+	// A Python expression for the request body.
+	Body string
+	// A Python list with the routing parameters.
+	Routing string
+	// The attributes for request IDs, set to a new UUID4 if empty.
+	AutoPopulated []string
+	// Set if the method returns a page of items, iterated by a `Pager`.
+	Pagination *paginationAnnotation
+	// Set if the method starts a long-running operation.
+	LRO *lroAnnotation
+	// The name of the method that resumes a long-running operation, e.g.
+	// `create_instance_operation`.
+	ResumeName string
+}
+
+// IsPlain returns true if the method returns the response directly.
+func (m *methodAnnotation) IsPlain() bool {
+	return m.Pagination == nil && m.LRO == nil
+}
+
+type paginationAnnotation struct {
+	ItemType       string
+	PageTokenField string
+	NextTokenField string
+	ItemsField     string
+}
+
+type lroAnnotation struct {
+	// The classes used to decode the result and metadata, or `None`.
+	ResponseClass string
+	MetadataClass string
+	// The type hints for the result and metadata.
+	ResponseType string
+	MetadataType string
+}
+
+type messageAnnotation struct {
+	Name string
+	// The name relative to the module, e.g. `Replication.Automatic`.
+	QualifiedName string
+	DocLines      []string
+	HasFields     bool
+	// The nested types, excluding map entries.
+	NestedMessages []*api.Message
+	NestedEnums    []*api.Enum
+}
+
+type fieldAnnotation struct {
+	Name     string
+	JSONName string
+	Hint     string
+	// The default value with dataclasses.
+	Default string
+	// The encoding with dataclasses, see `_encoding.py`.
+	Encoding string
+	Optional bool
+	// The field declaration with proto-plus, e.g.
+	// `proto.Field(proto.STRING, number=1)`.
+	ProtoPlus string
+}
+
+type enumAnnotation struct {
+	Name          string
+	QualifiedName string
+	DocLines      []string
+}
+
+type enumValueAnnotation struct {
+	Name string
+	// The value with dataclasses, e.g. `"ACTIVE"`.
+	Value    string
+	Number   int32
+	DocLines []string
+}
+
+type annotator struct {
+	model     *api.API
+	state     *api.APIState
+	protoPlus bool
+	// The qualified Python names of the generated messages and enums, by ID.
+	names map[string]string
+	// The names used at the top-level of `types.py`.
+	used map[string]bool
+	// The `google.protobuf` modules used by proto-plus messages.
+	modules map[string]bool
+}
+
+// annotateModel creates the structs used as input for the mustache templates.
+func annotateModel(model *api.API, codec *codec) *modelAnnotations {
+	a := &annotator{
+		model:     model,
+		state:     model.State,
+		protoPlus: codec.messageTypes == protoPlusMessages,
+		names:     map[string]string{},
+		used:      map[string]bool{},
+		modules:   map[string]bool{},
+	}
+	for _, name := range moduleNames {
+		a.used[name] = true
+	}
+	var services []*api.Service
+	for _, s := range model.Services {
+		if slices.ContainsFunc(s.Methods, shouldGenerateMethod) {
+			services = append(services, s)
+		}
+	}
+	messages, enums := a.collectTypes(services)
+	var typeNames []string
+	for _, m := range messages {
+		name := a.uniqueName(toPascal(m.Name), m.Package)
+		a.nameMessage(m, name)
+		typeNames = append(typeNames, name)
+	}
+	for _, e := range enums {
+		name := a.uniqueName(toPascal(e.Name), e.Package)
+		a.names[e.ID] = name
+		typeNames = append(typeNames, name)
+	}
+	for _, e := range enums {
+		a.annotateEnum(e)
+	}
+	for _, m := range messages {
+		a.annotateMessage(m)
+	}
+
+	name := packageName(model, codec.packageNameOverride)
+	distribution := codec.distributionName
+	if distribution == "" {
+		distribution = strings.ReplaceAll(name, "_", "-")
+	}
+	protoPackage := model.PackageName
+	if protoPackage == "" {
+		protoPackage = name
+	}
+	title := model.Title
+	if title == "" {
+		title = fmt.Sprintf("The %s package.", name)
+	}
+	var boilerPlate []string
+	for _, line := range append(license.LicenseHeader(codec.generationYear),
+		"",
+		" Code generated by sidekick. DO NOT EDIT.") {
+		boilerPlate = append(boilerPlate, strings.TrimRight("#"+line, " "))
+	}
+	ann := &modelAnnotations{
+		PackageName:      name,
+		DistributionName: distribution,
+		Version:          codec.version,
+		CopyrightYear:    codec.generationYear,
+		BoilerPlate:      boilerPlate,
+		Description:      strconv.Quote(title),
+		DocLines:         docstring(appendParagraph(docLines(title), docLines(model.Description)...)),
+		Dataclass:        !a.protoPlus,
+		ProtoPlus:        a.protoPlus,
+		ProtoPackage:     protoPackage,
+		Messages:         messages,
+		Enums:            enums,
+		TypeNames:        typeNames,
+		Services:         services,
+	}
+	for module := range a.modules {
+		ann.ProtobufModules = append(ann.ProtobufModules, module)
+	}
+	sort.Strings(ann.ProtobufModules)
+	for _, s := range services {
+		a.annotateService(s)
+		for _, m := range s.Codec.(*serviceAnnotations).Methods {
+			mAnn := m.Codec.(*methodAnnotation)
+			ann.HasPagination = ann.HasPagination || mAnn.Pagination != nil
+			ann.HasLROs = ann.HasLROs || mAnn.LRO != nil
+			ann.HasAutoPopulated = ann.HasAutoPopulated || len(mAnn.AutoPopulated) != 0
+		}
+	}
+	model.Codec = ann
+	return ann
+}
+
+// collectTypes returns the top-level messages and enums: all the types in the
+// package, and any types they, or the services, depend on. Nested types are
+// generated within their parent, which may bring in more types.
+func (a *annotator) collectTypes(services []*api.Service) ([]*api.Message, []*api.Enum) {
+	var messages []*api.Message
+	var enums []*api.Enum
+	seen := map[string]bool{}
+	root := func(m *api.Message) *api.Message {
+		for m.Parent != nil {
+			m = m.Parent
+		}
+		return m
+	}
+	addMessage := func(m *api.Message) {
+		m = root(m)
+		if seen[m.ID] || m.IsMap || isWellKnownType(m.ID) {
+			return
+		}
+		seen[m.ID] = true
+		messages = append(messages, m)
+	}
+	addEnum := func(e *api.Enum) {
+		if isWellKnownType(e.ID) {
+			return
+		}
+		if e.Parent != nil {
+			addMessage(e.Parent)
+			return
+		}
+		if !seen[e.ID] {
+			seen[e.ID] = true
+			enums = append(enums, e)
+		}
+	}
+	for _, e := range a.model.Enums {
+		addEnum(e)
+	}
+	for _, m := range a.model.Messages {
+		addMessage(m)
+	}
+	var ids []string
+	for _, s := range services {
+		ids = append(ids, s.ID)
+	}
+	// The generated messages include their nested types, which may depend on
+	// more types. Repeat until no new messages are added.
+	for done := 0; ; {
+		for _, m := range messages[done:] {
+			ids = appendSubtree(ids, m)
+		}
+		done = len(messages)
+		deps, err := api.FindDependencies(a.model, ids)
+		if err != nil {
+			slog.Error("cannot compute the dependencies", "error", err)
+		}
+		var external []string
+		for id := range deps {
+			external = append(external, id)
+		}
+		sort.Strings(external)
+		for _, id := range external {
+			if m, ok := a.state.MessageByID[id]; ok {
+				addMessage(m)
+				continue
+			}
+			if e, ok := a.state.EnumByID[id]; ok {
+				addEnum(e)
+			}
+		}
+		if done == len(messages) {
+			break
+		}
+	}
+	return messages, enums
+}
+
+// appendSubtree appends the IDs of a message and its nested types.
+func appendSubtree(ids []string, m *api.Message) []string {
+	ids = append(ids, m.ID)
+	for _, e := range m.Enums {
+		ids = append(ids, e.ID)
+	}
+	for _, child := range m.Messages {
+		ids = appendSubtree(ids, child)
+	}
+	return ids
+}
+
+// uniqueName returns `name`, or a variation if it is already used.
+func (a *annotator) uniqueName(name, pkg string) string {
+	candidate := name
+	if a.used[candidate] {
+		candidate = toPascal(packageName(&api.API{PackageName: pkg}, "")) + name
+	}
+	for i := 2; a.used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	a.used[candidate] = true
+	return candidate
+}
+
+// nameMessage records the qualified names of a message and its nested types.
+func (a *annotator) nameMessage(m *api.Message, qualified string) {
+	a.names[m.ID] = qualified
+	for _, child := range m.Messages {
+		if !child.IsMap {
+			a.nameMessage(child, qualified+"."+toPascal(child.Name))
+		}
+	}
+	for _, e := range m.Enums {
+		a.names[e.ID] = qualified + "." + toPascal(e.Name)
+	}
+}
+
+// simpleName returns the last component of a qualified name.
+func simpleName(qualified string) string {
+	return qualified[strings.LastIndex(qualified, ".")+1:]
+}
+
+func (a *annotator) annotateEnum(e *api.Enum) {
+	docs := docLines(e.Documentation)
+	if e.Deprecated {
+		docs = appendParagraph(docs, "Deprecated: Do not use.")
+	}
+	e.Codec = &enumAnnotation{
+		Name:          simpleName(a.names[e.ID]),
+		QualifiedName: a.names[e.ID],
+		DocLines:      docstring(docs),
+	}
+	used := map[string]bool{}
+	for _, ev := range e.Values {
+		name := valueIdentifier(ev.Name)
+		for used[name] {
+			name += "_"
+		}
+		used[name] = true
+		docs := docLines(ev.Documentation)
+		if ev.Deprecated {
+			docs = appendParagraph(docs, "Deprecated: Do not use.")
+		}
+		ev.Codec = &enumValueAnnotation{
+			Name:     name,
+			Value:    strconv.Quote(ev.Name),
+			Number:   ev.Number,
+			DocLines: docstring(docs),
+		}
+	}
+}
+
+func (a *annotator) annotateMessage(m *api.Message) {
+	var attributes []string
+	// Attributes that would clash with Python keywords, or with the methods
+	// of the generated classes.
+	used := map[string]bool{"to_dict": true, "from_dict": true, "to_json": true, "from_json": true}
+	for i, f := range m.Fields {
+		name := toSnake(f.Name)
+		for used[name] {
+			name += "_"
+		}
+		used[name] = true
+		ann := &fieldAnnotation{
+			Name:     name,
+			JSONName: f.JSONName,
+			Optional: f.Optional,
+		}
+		if ann.JSONName == "" {
+			ann.JSONName = f.Name
+		}
+		if a.protoPlus {
+			ann.Hint = a.protoPlusHint(f)
+			ann.ProtoPlus = a.protoPlusField(f, ann, i+1)
+		} else {
+			ann.Hint, ann.Default = a.dataclassHint(f)
+			ann.Encoding = a.encoding(f)
+		}
+		f.Codec = ann
+
+		attributes = append(attributes, fmt.Sprintf("%s (%s):", name, ann.Hint))
+		docs := docLines(f.Documentation)
+		if f.IsOneOf && f.Group != nil {
+			docs = appendParagraph(docs, fmt.Sprintf("This field is a member of the `%s` oneof.", f.Group.Name))
+		}
+		if f.Deprecated {
+			docs = appendParagraph(docs, "Deprecated: Do not use.")
+		}
+		attributes = append(attributes, indent(docs, "    ")...)
+	}
+	docs := docLines(m.Documentation)
+	if m.Deprecated {
+		docs = appendParagraph(docs, "Deprecated: Do not use.")
+	}
+	if len(attributes) != 0 {
+		docs = appendParagraph(docs, "Attributes:")
+		docs = append(docs, indent(attributes, "    ")...)
+	}
+	ann := &messageAnnotation{
+		Name:          simpleName(a.names[m.ID]),
+		QualifiedName: a.names[m.ID],
+		DocLines:      docstring(docs),
+		HasFields:     len(m.Fields) != 0,
+		NestedEnums:   language.FilterSlice(m.Enums, func(e *api.Enum) bool { return !isWellKnownType(e.ID) }),
+	}
+	for _, e := range ann.NestedEnums {
+		a.annotateEnum(e)
+	}
+	for _, child := range m.Messages {
+		if !child.IsMap {
+			ann.NestedMessages = append(ann.NestedMessages, child)
+			a.annotateMessage(child)
+		}
+	}
+	// Mustache falls back to the parent context on missing values, the
+	// slices must not be nil to stop the recursion.
+	if ann.NestedMessages == nil {
+		ann.NestedMessages = []*api.Message{}
+	}
+	if ann.NestedEnums == nil {
+		ann.NestedEnums = []*api.Enum{}
+	}
+	m.Codec = ann
+}
+
+// dataclassHint returns the type hint and default value for a field.
+func (a *annotator) dataclassHint(f *api.Field) (string, string) {
+	if f.Map {
+		key, value := a.mapEntry(f)
+		if key == nil || value == nil {
+			return "Dict[str, Any]", "dataclasses.field(default_factory=dict)"
+		}
+		return fmt.Sprintf("Dict[%s, %s]", scalarHint(key.Typez), a.elementHint(value)), "dataclasses.field(default_factory=dict)"
+	}
+	if f.Repeated {
+		return fmt.Sprintf("List[%s]", a.elementHint(f)), "dataclasses.field(default_factory=list)"
+	}
+	switch f.Typez {
+	case api.MESSAGE_TYPE, api.ENUM_TYPE:
+		hint := a.elementHint(f)
+		if hint == "Any" {
+			return hint, "None"
+		}
+		return fmt.Sprintf("Optional[%s]", hint), "None"
+	default:
+		if f.Optional {
+			return fmt.Sprintf("Optional[%s]", scalarHint(f.Typez)), "None"
+		}
+		return scalarHint(f.Typez), scalarDefault(f.Typez)
+	}
+}
+
+// elementHint returns the type hint for the elements of repeated fields, and
+// the values of map fields, with dataclasses.
+func (a *annotator) elementHint(f *api.Field) string {
+	switch f.Typez {
+	case api.MESSAGE_TYPE, api.ENUM_TYPE:
+		if wkt, ok := wellKnownTypes[f.TypezID]; ok {
+			return wkt.Hint
+		}
+		return a.typeName(f.TypezID)
+	default:
+		return scalarHint(f.Typez)
+	}
+}
+
+// encoding returns the expression for the field encoding with dataclasses.
+func (a *annotator) encoding(f *api.Field) string {
+	if f.Map {
+		key, value := a.mapEntry(f)
+		if key == nil || value == nil {
+			return "_encoding.VALUE"
+		}
+		return fmt.Sprintf("_encoding.map_of(%s, %s)", a.elementEncoding(key), a.elementEncoding(value))
+	}
+	if f.Repeated {
+		return fmt.Sprintf("_encoding.repeated(%s)", a.elementEncoding(f))
+	}
+	return a.elementEncoding(f)
+}
+
+func (a *annotator) elementEncoding(f *api.Field) string {
+	if wkt, ok := wellKnownTypes[f.TypezID]; ok && (f.Typez == api.MESSAGE_TYPE || f.Typez == api.ENUM_TYPE) {
+		return "_encoding." + wkt.Encoding
+	}
+	switch f.Typez {
+	case api.MESSAGE_TYPE:
+		return fmt.Sprintf("_encoding.message(lambda: %s)", a.typeName(f.TypezID))
+	case api.ENUM_TYPE:
+		return fmt.Sprintf("_encoding.enum_of(lambda: %s)", a.typeName(f.TypezID))
+	default:
+		return "_encoding." + scalarEncoding(f.Typez)
+	}
+}
+
+// protoPlusHint returns the type hint for a field with proto-plus.
+func (a *annotator) protoPlusHint(f *api.Field) string {
+	element := func(f *api.Field) string {
+		switch f.Typez {
+		case api.MESSAGE_TYPE, api.ENUM_TYPE:
+			if wkt, ok := wellKnownTypes[f.TypezID]; ok {
+				return wkt.ProtoPlus
+			}
+			return a.typeName(f.TypezID)
+		default:
+			return scalarHint(f.Typez)
+		}
+	}
+	if f.Map {
+		key, value := a.mapEntry(f)
+		if key == nil || value == nil {
+			return "MutableMapping[str, Any]"
+		}
+		return fmt.Sprintf("MutableMapping[%s, %s]", scalarHint(key.Typez), element(value))
+	}
+	if f.Repeated {
+		return fmt.Sprintf("MutableSequence[%s]", element(f))
+	}
+	return element(f)
+}
+
+// protoPlusField returns the proto-plus field declaration. The field numbers
+// are positional, the REST transport only uses the JSON encoding.
+func (a *annotator) protoPlusField(f *api.Field, ann *fieldAnnotation, number int) string {
+	reference := func(f *api.Field) string {
+		switch f.Typez {
+		case api.MESSAGE_TYPE:
+			return ", message=" + a.protoPlusReference(f.TypezID)
+		case api.ENUM_TYPE:
+			return ", enum=" + a.protoPlusReference(f.TypezID)
+		default:
+			return ""
+		}
+	}
+	// proto-plus derives the JSON name from the attribute, unless it is set.
+	jsonName := ""
+	if protoJSONName(ann.Name) != ann.JSONName {
+		jsonName = fmt.Sprintf(", json_name=%q", ann.JSONName)
+	}
+	if f.Map {
+		key, value := a.mapEntry(f)
+		if key == nil || value == nil {
+			a.modules["struct_pb2"] = true
+			return fmt.Sprintf("proto.MapField(proto.STRING, proto.MESSAGE, number=%d%s, message=struct_pb2.Value)", number, jsonName)
+		}
+		return fmt.Sprintf("proto.MapField(%s, %s, number=%d%s%s)", protoPlusType(key.Typez), protoPlusType(value.Typez), number, jsonName, reference(value))
+	}
+	if f.Repeated {
+		return fmt.Sprintf("proto.RepeatedField(%s, number=%d%s%s)", protoPlusType(f.Typez), number, jsonName, reference(f))
+	}
+	options := jsonName
+	if f.IsOneOf && f.Group != nil {
+		options += fmt.Sprintf(", oneof=%q", f.Group.Name)
+	}
+	if f.Optional && f.Typez != api.MESSAGE_TYPE {
+		options += ", optional=True"
+	}
+	return fmt.Sprintf("proto.Field(%s, number=%d%s%s)", protoPlusType(f.Typez), number, options, reference(f))
+}
+
+// protoJSONName returns the default JSON name protobuf uses for a field name.
+func protoJSONName(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range name {
+		switch {
+		case r == '_':
+			upper = true
+		case upper:
+			b.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// protoPlusReference returns the reference to a message or enum in proto-plus
+// field declarations.
+func (a *annotator) protoPlusReference(id string) string {
+	if wkt, ok := wellKnownTypes[id]; ok {
+		a.modules[wkt.ProtoPlusModule] = true
+		return wkt.ProtoPlus
+	}
+	// proto-plus resolves names relative to the package in the manifest.
+	return strconv.Quote(a.typeName(id))
+}
+
+func (a *annotator) mapEntry(f *api.Field) (*api.Field, *api.Field) {
+	entry, ok := a.state.MessageByID[f.TypezID]
+	if !ok || len(entry.Fields) != 2 {
+		slog.Error("unable to lookup map entry", "id", f.TypezID)
+		return nil, nil
+	}
+	return entry.Fields[0], entry.Fields[1]
+}
+
+// typeName returns the qualified Python name of a message or enum in
+// `types.py`.
+func (a *annotator) typeName(id string) string {
+	if wkt, ok := wellKnownTypes[id]; ok {
+		return wkt.Hint
+	}
+	if name, ok := a.names[id]; ok {
+		return name
+	}
+	slog.Error("unable to lookup type", "id", id)
+	return "Any"
+}
+
+// typeClass returns the class used to decode messages in `client.py`, or
+// `None` for well-known types, which are not decoded.
+func (a *annotator) typeClass(id string) string {
+	if isWellKnownType(id) {
+		return "None"
+	}
+	return "types." + a.typeName(id)
+}
+
+// typeHint returns the type hint for messages in `client.py`.
+func (a *annotator) typeHint(id string) string {
+	if wkt, ok := wellKnownTypes[id]; ok {
+		if a.protoPlus {
+			return "Dict[str, Any]"
+		}
+		return wkt.Hint
+	}
+	return "types." + a.typeName(id)
+}
+
+func (a *annotator) annotateService(s *api.Service) {
+	methods := language.FilterSlice(s.Methods, shouldGenerateMethod)
+	poll := a.poll(methods)
+	ann := &serviceAnnotations{
+		Name:        toPascal(s.Name) + "Client",
+		DocLines:    docstring(docLines(s.Documentation)),
+		DefaultHost: s.DefaultHost,
+		Methods:     methods,
+	}
+	names := map[string]bool{}
+	for _, m := range methods {
+		names[toSnake(m.Name)] = true
+	}
+	for _, m := range methods {
+		a.annotateMethod(m, poll, names)
+		if m.Codec.(*methodAnnotation).LRO != nil {
+			ann.Poll = poll
+		}
+	}
+	s.Codec = ann
+}
+
+func (a *annotator) annotateMethod(m *api.Method, poll *pollAnnotation, names map[string]bool) {
+	request := a.state.MessageByID[m.InputTypeID]
+	binding := m.PathInfo.Bindings[0]
+	ann := &methodAnnotation{
+		Name:          toSnake(m.Name),
+		SendName:      "_" + strings.TrimSuffix(toSnake(m.Name), "_"),
+		RequestType:   a.typeHint(m.InputTypeID),
+		ResponseType:  a.typeHint(m.OutputTypeID),
+		ResponseClass: a.typeClass(m.OutputTypeID),
+		ReturnsEmpty:  m.ReturnsEmpty,
+		Verb:          strings.ToUpper(binding.Verb),
+		Body:          a.body(m, request),
+	}
+	var variables [][]string
+	ann.Path, variables = a.path(binding.PathTemplate, request)
+	ann.Query = a.query(m, binding)
+	ann.Routing = a.routing(m, request, variables)
+	for _, f := range m.AutoPopulated {
+		ann.AutoPopulated = append(ann.AutoPopulated, a.attributeName(f))
+	}
+	ann.Pagination = a.pagination(m)
+	if poll != nil {
+		ann.LRO = a.lro(m)
+	}
+
+	docs := docLines(m.Documentation)
+	if m.Deprecated {
+		docs = appendParagraph(docs, "Deprecated: Do not use.")
+	}
+	docs = appendParagraph(docs,
+		"Args:",
+		"    request: The request message.",
+		"    timeout: The timeout for the request, in seconds.")
+	switch {
+	case ann.Pagination != nil:
+		docs = appendParagraph(docs,
+			"Returns:",
+			"    A pager iterating over the items, it fetches the pages as needed,",
+			"    starting with the page token in the request, if any.")
+	case ann.LRO != nil:
+		ann.ResumeName = ann.Name + "_operation"
+		if names[ann.ResumeName] {
+			ann.ResumeName = ann.Name + "_long_running_operation"
+		}
+		docs = appendParagraph(docs,
+			"Returns:",
+			"    The long-running operation, use `result()` to wait for its result.")
+	case !ann.ReturnsEmpty:
+		docs = appendParagraph(docs,
+			"Returns:",
+			"    The response message.")
+	}
+	docs = appendParagraph(docs,
+		"Raises:",
+		"    google.api_core.exceptions.GoogleAPICallError: If the service rejects",
+		"        the request.",
+		"    ValueError: If a field used in the request path is not set.")
+	ann.DocLines = docstring(docs)
+	m.Codec = ann
+}
+
+// attributeName returns the Python attribute for a field.
+func (a *annotator) attributeName(f *api.Field) string {
+	if ann, ok := f.Codec.(*fieldAnnotation); ok {
+		return ann.Name
+	}
+	return toSnake(f.Name)
+}
+
+// body returns the Python expression for the request body.
+func (a *annotator) body(m *api.Method, request *api.Message) string {
+	switch m.PathInfo.BodyFieldPath {
+	case "":
+		return "None"
+	case "*":
+		return "data"
+	}
+	if request != nil {
+		for _, f := range request.Fields {
+			if f.Name == m.PathInfo.BodyFieldPath {
+				return fmt.Sprintf("data.get(%q)", f.JSONName)
+			}
+		}
+	}
+	slog.Error("unable to find the body field", "method", m.ID, "body", m.PathInfo.BodyFieldPath)
+	return "data"
+}
+
+// path returns the Python expression for the request path, and the JSON paths
+// of the variables used in it.
+func (a *annotator) path(template *api.PathTemplate, request *api.Message) (string, [][]string) {
+	var parts []string
+	var variables [][]string
+	literal := ""
+	for _, segment := range template.Segments {
+		switch {
+		case segment.Literal != nil:
+			literal += "/" + *segment.Literal
+		case segment.Variable != nil:
+			parts = append(parts, strconv.Quote(literal+"/"))
+			literal = ""
+			jsonPath := a.jsonPath(segment.Variable.FieldPath, request)
+			variables = append(variables, segment.Variable.FieldPath, jsonPath)
+			parts = append(parts, fmt.Sprintf("_transport.path_value(data, %s)", pythonList(jsonPath)))
+		}
+	}
+	if template.Verb != nil {
+		literal += ":" + *template.Verb
+	}
+	if literal != "" {
+		parts = append(parts, strconv.Quote(literal))
+	}
+	return strings.Join(parts, " + "), variables
+}
+
+// jsonPath converts a field path to the path of JSON names in the encoded
+// request.
+func (a *annotator) jsonPath(fieldPath []string, request *api.Message) []string {
+	var result []string
+	message := request
+	for _, name := range fieldPath {
+		var field *api.Field
+		if message != nil {
+			idx := slices.IndexFunc(message.Fields, func(f *api.Field) bool { return f.Name == name })
+			if idx != -1 {
+				field = message.Fields[idx]
+			}
+		}
+		if field == nil || field.JSONName == "" {
+			result = append(result, name)
+			message = nil
+			continue
+		}
+		result = append(result, field.JSONName)
+		message = a.state.MessageByID[field.TypezID]
+	}
+	return result
+}
+
+// query returns the Python list of query parameters. Optional fields are sent
+// even if they have the default value.
+func (a *annotator) query(m *api.Method, binding *api.PathBinding) string {
+	if m.InputType == nil {
+		return "[]"
+	}
+	var params []string
+	for _, f := range language.QueryParams(m, binding) {
+		name := f.JSONName
+		if name == "" {
+			name = f.Name
+		}
+		force := "False"
+		if f.Optional {
+			force = "True"
+		}
+		params = append(params, fmt.Sprintf("(%q, %s)", name, force))
+	}
+	return "[" + strings.Join(params, ", ") + "]"
+}
+
+// routing returns the Python list of routing parameters for the
+// `x-goog-request-params` header.
+//
+// With explicit routing annotations (AIP-4222) the first matching variant of
+// each parameter is used. Otherwise, the header contains the path variables.
+func (a *annotator) routing(m *api.Method, request *api.Message, variables [][]string) string {
+	var params []string
+	if len(m.Routing) == 0 {
+		for i := 0; i+1 < len(variables); i += 2 {
+			params = append(params, fmt.Sprintf("(%q, [(%s, [], [\"**\"], [])])",
+				strings.Join(variables[i], "."), pythonList(variables[i+1])))
+		}
+		return "[" + strings.Join(params, ", ") + "]"
+	}
+	for _, info := range m.Routing {
+		if info.Name == "" {
+			// An empty annotation disables the routing headers.
+			return "[]"
+		}
+	}
+	for _, info := range m.Routing {
+		var variants []string
+		for _, variant := range info.Variants {
+			variants = append(variants, fmt.Sprintf("(%s, %s, %s, %s)",
+				pythonList(a.jsonPath(variant.FieldPath, request)),
+				pythonList(variant.Prefix.Segments),
+				pythonList(variant.Matching.Segments),
+				pythonList(variant.Suffix.Segments)))
+		}
+		params = append(params, fmt.Sprintf("(%q, [%s])", info.Name, strings.Join(variants, ", ")))
+	}
+	return "[" + strings.Join(params, ", ") + "]"
+}
+
+// pagination returns the pagination annotations, if the method returns pages
+// of items that a `Pager` can traverse.
+func (a *annotator) pagination(m *api.Method) *paginationAnnotation {
+	if m.Pagination == nil || m.Pagination.Typez != api.STRING_TYPE {
+		return nil
+	}
+	response := a.state.MessageByID[m.OutputTypeID]
+	if response == nil || response.Pagination == nil {
+		return nil
+	}
+	next, items := response.Pagination.NextPageToken, response.Pagination.PageableItem
+	if next == nil || items == nil || items.Map || next.Typez != api.STRING_TYPE {
+		return nil
+	}
+	itemType := scalarHint(items.Typez)
+	if items.Typez == api.MESSAGE_TYPE || items.Typez == api.ENUM_TYPE {
+		itemType = a.typeHint(items.TypezID)
+	}
+	return &paginationAnnotation{
+		ItemType:       itemType,
+		PageTokenField: a.attributeName(m.Pagination),
+		NextTokenField: a.attributeName(next),
+		ItemsField:     a.attributeName(items),
+	}
+}
+
+// lro returns the annotations for long-running operations (AIP-151).
+func (a *annotator) lro(m *api.Method) *lroAnnotation {
+	if m.OperationInfo == nil {
+		return nil
+	}
+	info := m.OperationInfo
+	ann := &lroAnnotation{
+		ResponseClass: a.typeClass(info.ResponseTypeID),
+		MetadataClass: a.typeClass(info.MetadataTypeID),
+		ResponseType:  a.typeHint(info.ResponseTypeID),
+		MetadataType:  a.typeHint(info.MetadataTypeID),
+	}
+	// The well-known types are not decoded, `Operation` returns None for them.
+	if ann.ResponseClass == "None" {
+		ann.ResponseType = "None"
+	}
+	if ann.MetadataClass == "None" {
+		ann.MetadataType = "None"
+	}
+	return ann
+}
+
+// poll returns the method used to poll long-running operations. The service
+// must include the `GetOperation` mixin, otherwise the methods returning
+// operations are generated as plain methods.
+func (a *annotator) poll(methods []*api.Method) *pollAnnotation {
+	idx := slices.IndexFunc(methods, func(m *api.Method) bool {
+		return m.InputTypeID == ".google.longrunning.GetOperationRequest"
+	})
+	if idx == -1 {
+		return nil
+	}
+	request := a.state.MessageByID[methods[idx].InputTypeID]
+	if request == nil {
+		return nil
+	}
+	field := slices.IndexFunc(request.Fields, func(f *api.Field) bool { return f.Name == "name" })
+	if field == -1 {
+		return nil
+	}
+	return &pollAnnotation{
+		SendName:    "_" + strings.TrimSuffix(toSnake(methods[idx].Name), "_"),
+		RequestType: a.typeClass(methods[idx].InputTypeID),
+		NameField:   a.attributeName(request.Fields[field]),
+	}
+}
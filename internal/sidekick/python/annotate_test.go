@@ -0,0 +1,277 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+	"github.com/julieqiu/librarianx/internal/sidekick/config"
+	"github.com/julieqiu/librarianx/internal/sidekick/sample"
+)
+
+// newTestModel returns the sample API used in the codec tests.
+func newTestModel(t *testing.T) *api.API {
+	t.Helper()
+	model := sample.ResourceAPI()
+	if err := api.CrossReference(model); err != nil {
+		t.Fatal(err)
+	}
+	return model
+}
+
+func TestAnnotateFields(t *testing.T) {
+	model := newTestModel(t)
+	codec, err := newCodec(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotateModel(model, codec)
+
+	resource := model.State.MessageByID[".test.v1.Resource"]
+	var got []*fieldAnnotation
+	for _, f := range resource.Fields {
+		got = append(got, f.Codec.(*fieldAnnotation))
+	}
+	want := []*fieldAnnotation{
+		{Name: "name", JSONName: "name", Hint: "str", Default: `""`, Encoding: "_encoding.STRING"},
+		{Name: "state", JSONName: "state", Hint: "Optional[State]", Default: "None", Encoding: "_encoding.enum_of(lambda: State)"},
+		{Name: "size", JSONName: "size", Hint: "Optional[int]", Default: "None", Encoding: "_encoding.INT64", Optional: true},
+		{Name: "counts", JSONName: "counts", Hint: "List[int]", Default: "dataclasses.field(default_factory=list)", Encoding: "_encoding.repeated(_encoding.INT64)"},
+		{Name: "labels", JSONName: "labels", Hint: "Dict[str, str]", Default: "dataclasses.field(default_factory=dict)", Encoding: "_encoding.map_of(_encoding.STRING, _encoding.STRING)"},
+		{Name: "update_time", JSONName: "updateTime", Hint: "Optional[str]", Default: "None", Encoding: "_encoding.VALUE", Optional: true},
+		{Name: "spec", JSONName: "spec", Hint: "Optional[Resource.Spec]", Default: "None", Encoding: "_encoding.message(lambda: Resource.Spec)", Optional: true},
+		{Name: "from_", JSONName: "from", Hint: "str", Default: `""`, Encoding: "_encoding.STRING"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+
+	ann := model.Codec.(*modelAnnotations)
+	wantNames := []string{
+		"Resource", "CreateResourceRequest", "ListResourcesRequest",
+		"ListResourcesResponse", "OperationMetadata", "GetOperationRequest",
+		"Operation", "State",
+	}
+	if diff := cmp.Diff(wantNames, ann.TypeNames); diff != "" {
+		t.Errorf("mismatch in type names (-want, +got):\n%s", diff)
+	}
+	nested := resource.Codec.(*messageAnnotation)
+	if len(nested.NestedMessages) != 1 || nested.NestedMessages[0].Codec.(*messageAnnotation).QualifiedName != "Resource.Spec" {
+		t.Errorf("mismatch in nested messages, the map entries should be skipped, got=%v", nested.NestedMessages)
+	}
+	if len(nested.NestedEnums) != 1 || nested.NestedEnums[0].Codec.(*enumAnnotation).QualifiedName != "Resource.Kind" {
+		t.Errorf("mismatch in nested enums, got=%v", nested.NestedEnums)
+	}
+}
+
+func TestAnnotateProtoPlusFields(t *testing.T) {
+	model := newTestModel(t)
+	codec, err := newCodec(map[string]string{"message-types": "proto-plus"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotateModel(model, codec)
+
+	resource := model.State.MessageByID[".test.v1.Resource"]
+	var got [][]string
+	for _, f := range resource.Fields {
+		ann := f.Codec.(*fieldAnnotation)
+		got = append(got, []string{ann.Name, ann.Hint, ann.ProtoPlus})
+	}
+	want := [][]string{
+		{"name", "str", "proto.Field(proto.STRING, number=1)"},
+		{"state", "State", `proto.Field(proto.ENUM, number=2, enum="State")`},
+		{"size", "int", "proto.Field(proto.INT64, number=3, optional=True)"},
+		{"counts", "MutableSequence[int]", "proto.RepeatedField(proto.UINT64, number=4)"},
+		{"labels", "MutableMapping[str, str]", "proto.MapField(proto.STRING, proto.STRING, number=5)"},
+		{"update_time", "timestamp_pb2.Timestamp", "proto.Field(proto.MESSAGE, number=6, message=timestamp_pb2.Timestamp)"},
+		{"spec", "Resource.Spec", `proto.Field(proto.MESSAGE, number=7, message="Resource.Spec")`},
+		{"from_", "str", "proto.Field(proto.STRING, number=8)"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+	ann := model.Codec.(*modelAnnotations)
+	if diff := cmp.Diff([]string{"timestamp_pb2"}, ann.ProtobufModules); diff != "" {
+		t.Errorf("mismatch in protobuf modules (-want, +got):\n%s", diff)
+	}
+}
+
+func TestAnnotateEnumValues(t *testing.T) {
+	model := newTestModel(t)
+	// `Optional` is imported by `types.py`, the enum must be renamed using the
+	// package name.
+	optional := &api.Enum{
+		Name:    "Optional",
+		ID:      ".test.v1.Optional",
+		Package: "test.v1",
+		Values: []*api.EnumValue{
+			{Name: "OPTIONAL_UNSPECIFIED", Number: 0},
+			{Name: "with-dashes", Number: 1},
+			{Name: "2ND", Number: 2},
+		},
+	}
+	model.Enums = append(model.Enums, optional)
+	model.State.EnumByID[optional.ID] = optional
+	codec, err := newCodec(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotateModel(model, codec)
+
+	if got := optional.Codec.(*enumAnnotation).Name; got != "TestV1Optional" {
+		t.Errorf("mismatch in enum name, want=TestV1Optional, got=%s", got)
+	}
+	var got []*enumValueAnnotation
+	for _, ev := range optional.Values {
+		got = append(got, ev.Codec.(*enumValueAnnotation))
+	}
+	want := []*enumValueAnnotation{
+		{Name: "OPTIONAL_UNSPECIFIED", Value: `"OPTIONAL_UNSPECIFIED"`, Number: 0},
+		{Name: "with_dashes", Value: `"with-dashes"`, Number: 1},
+		{Name: "V2ND", Value: `"2ND"`, Number: 2},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestAnnotateMethods(t *testing.T) {
+	model := newTestModel(t)
+	codec, err := newCodec(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotateModel(model, codec)
+
+	service := model.Services[0].Codec.(*serviceAnnotations)
+	if service.Name != "ResourceServiceClient" {
+		t.Errorf("mismatch in service name, want=ResourceServiceClient, got=%s", service.Name)
+	}
+	if len(service.Methods) != 3 {
+		t.Errorf("the streaming method should be skipped, got=%v", service.Methods)
+	}
+	wantPoll := &pollAnnotation{SendName: "_get_operation", RequestType: "types.GetOperationRequest", NameField: "name"}
+	if diff := cmp.Diff(wantPoll, service.Poll); diff != "" {
+		t.Errorf("mismatch in poll annotations (-want, +got):\n%s", diff)
+	}
+
+	create := model.State.MethodByID[".test.v1.ResourceService.CreateResource"].Codec.(*methodAnnotation)
+	wantCreate := &methodAnnotation{
+		Name:     "create_resource",
+		SendName: "_create_resource",
+		DocLines: []string{
+			`"""Creates a resource.`,
+			"",
+			"Args:",
+			"    request: The request message.",
+			"    timeout: The timeout for the request, in seconds.",
+			"",
+			"Returns:",
+			"    The long-running operation, use `result()` to wait for its result.",
+			"",
+			"Raises:",
+			"    google.api_core.exceptions.GoogleAPICallError: If the service rejects",
+			"        the request.",
+			"    ValueError: If a field used in the request path is not set.",
+			`"""`,
+		},
+		RequestType:   "types.CreateResourceRequest",
+		ResponseType:  "types.Operation",
+		ResponseClass: "types.Operation",
+		Verb:          "POST",
+		Path:          `"/v1/" + _transport.path_value(data, ["parent"]) + "/resources"`,
+		Query:         `[("requestId", False), ("validateOnly", True)]`,
+		Body:          `data.get("resource")`,
+		Routing:       `[("project", [(["parent"], [], ["projects", "*"], [])])]`,
+		AutoPopulated: []string{"request_id"},
+		LRO: &lroAnnotation{
+			ResponseClass: "types.Resource",
+			MetadataClass: "types.OperationMetadata",
+			ResponseType:  "types.Resource",
+			MetadataType:  "types.OperationMetadata",
+		},
+		ResumeName: "create_resource_operation",
+	}
+	if diff := cmp.Diff(wantCreate, create); diff != "" {
+		t.Errorf("mismatch in CreateResource (-want, +got):\n%s", diff)
+	}
+
+	list := model.State.MethodByID[".test.v1.ResourceService.ListResources"].Codec.(*methodAnnotation)
+	wantPagination := &paginationAnnotation{
+		ItemType:       "types.Resource",
+		PageTokenField: "page_token",
+		NextTokenField: "next_page_token",
+		ItemsField:     "resources",
+	}
+	if diff := cmp.Diff(wantPagination, list.Pagination); diff != "" {
+		t.Errorf("mismatch in pagination (-want, +got):\n%s", diff)
+	}
+	if want := `[("parent", [(["parent"], [], ["**"], [])])]`; list.Routing != want {
+		t.Errorf("mismatch in implicit routing, want=%s, got=%s", want, list.Routing)
+	}
+
+	ann := model.Codec.(*modelAnnotations)
+	if !ann.HasPagination || !ann.HasLROs || !ann.HasAutoPopulated {
+		t.Errorf("expected the runtime support for all features, got=%+v", ann)
+	}
+}
+
+func TestGenerateTestModel(t *testing.T) {
+	unknown := &config.Config{Codec: map[string]string{"not-an-option": "true"}}
+	if err := Generate(newTestModel(t), t.TempDir(), unknown); err == nil {
+		t.Errorf("expected an error with an unknown codec option")
+	}
+
+	outDir := t.TempDir()
+	model := newTestModel(t)
+	if err := Generate(model, outDir, &config.Config{}); err != nil {
+		t.Fatal(err)
+	}
+	contents, err := os.ReadFile(path.Join(outDir, "test_v1", "client.py"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"    ) -> operation.Operation[types.Resource, types.OperationMetadata]:",
+		"    def create_resource_operation(self, name: str) -> operation.Operation[types.Resource, types.OperationMetadata]:",
+		"    ) -> pagers.Pager[types.Resource]:",
+		"            request.request_id = str(uuid.uuid4())",
+	} {
+		if !strings.Contains(string(contents), want) {
+			t.Errorf("missing %q in generated client", want)
+		}
+	}
+	compilePackage(t, outDir)
+
+	// Run the client tests from `testdata`. The generated clients depend on
+	// google-api-core and google-auth.
+	python := pythonCommand(t)
+	if err := exec.Command(python, "-c", "import google.api_core, google.auth, requests").Run(); err != nil {
+		t.Skip("skipping the tests for the generated client, google-api-core or google-auth are not installed")
+	}
+	cmd := exec.Command(python, "-m", "unittest", "-q", "test_client")
+	cmd.Dir = "testdata"
+	cmd.Env = append(os.Environ(), "PYTHONPATH="+outDir, "PYTHONDONTWRITEBYTECODE=1")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("the tests for the generated client failed: %v\n%s", err, output)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"fmt"
+
+	"github.com/julieqiu/librarianx/internal/sidekick/language"
+)
+
+type codec struct {
+	// Overrides the name of the Python package, by default the last component
+	// of the protobuf package that is not a version, and the version, e.g.
+	// `secretmanager_v1`.
+	packageNameOverride string
+	// The name of the distribution in `pyproject.toml`, by default the
+	// package name with dashes, e.g. `secretmanager-v1`.
+	distributionName string
+	// The version in `pyproject.toml`.
+	version string
+	// Either `dataclass` or `proto-plus`.
+	messageTypes string
+	// The year in the copyright headers.
+	generationYear string
+}
+
+func newCodec(options map[string]string) (*codec, error) {
+	codec := &codec{
+		version:        "0.1.0",
+		messageTypes:   dataclassMessages,
+		generationYear: language.GenerationYear(),
+	}
+	for key, definition := range options {
+		switch key {
+		case "package-name-override":
+			codec.packageNameOverride = definition
+		case "distribution-name":
+			codec.distributionName = definition
+		case "version":
+			codec.version = definition
+		case "message-types":
+			if definition != dataclassMessages && definition != protoPlusMessages {
+				return nil, fmt.Errorf("unknown value for `message-types` %q, must be %q or %q", definition, dataclassMessages, protoPlusMessages)
+			}
+			codec.messageTypes = definition
+		case "copyright-year":
+			codec.generationYear = definition
+		default:
+			return nil, fmt.Errorf("unknown Python codec option %q", key)
+		}
+	}
+	return codec, nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"embed"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+	"github.com/julieqiu/librarianx/internal/sidekick/config"
+	"github.com/julieqiu/librarianx/internal/sidekick/language"
+)
+
+//go:embed all:templates
+var pythonTemplates embed.FS
+
+// Generate generates a Python package from the model.
+func Generate(model *api.API, outdir string, cfg *config.Config) error {
+	if err := Annotate(model, cfg); err != nil {
+		return err
+	}
+	files := generatedFiles(model)
+	if err := language.GenerateFromModel(outdir, model, templatesProvider(), files); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := formatFile(filepath.Join(outdir, file.OutputPath)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Annotate runs the Python annotations on the model, without generating any
+// code. The annotations are stored in the `Codec` field of each element.
+func Annotate(model *api.API, cfg *config.Config) error {
+	codec, err := newCodec(cfg.Codec)
+	if err != nil {
+		return err
+	}
+	annotateModel(model, codec)
+	return nil
+}
+
+func templatesProvider() language.TemplateProvider {
+	return func(name string) (string, error) {
+		contents, err := pythonTemplates.ReadFile(filepath.ToSlash(name))
+		if err != nil {
+			return "", err
+		}
+		return string(contents), nil
+	}
+}
+
+// generatedFiles returns the files to generate. The files in
+// `templates/package` go into the directory named after the Python package.
+// The runtime support for clients, pagination, and long-running operations is
+// only generated if needed.
+func generatedFiles(model *api.API) []language.GeneratedFile {
+	ann := model.Codec.(*modelAnnotations)
+	skip := map[string]bool{
+		"client.py":     !ann.HasServices(),
+		"_transport.py": !ann.HasServices(),
+		"pagers.py":     !ann.HasPagination,
+		"operation.py":  !ann.HasLROs,
+	}
+	var files []language.GeneratedFile
+	for _, f := range language.WalkTemplatesDir(pythonTemplates, "templates") {
+		if skip[filepath.Base(f.OutputPath)] {
+			continue
+		}
+		if dir, name, ok := strings.Cut(strings.TrimPrefix(filepath.ToSlash(f.OutputPath), "/"), "/"); ok && dir == "package" {
+			f.OutputPath = filepath.Join(ann.PackageName, name)
+		}
+		files = append(files, f)
+	}
+	return files
+}
+
+// formatFile removes the trailing whitespace left by the templates, e.g. on
+// empty docstring lines.
+func formatFile(path string) error {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(string(contents), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0666)
+}
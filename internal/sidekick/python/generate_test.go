@@ -0,0 +1,121 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/julieqiu/librarianx/internal/sidekick/config"
+	"github.com/julieqiu/librarianx/internal/sidekick/parser"
+)
+
+var testdataDir, _ = filepath.Abs("../testdata")
+
+func TestGenerate(t *testing.T) {
+	protobuf := config.GeneralConfig{
+		SpecificationFormat: "protobuf",
+		ServiceConfig:       "google/cloud/secretmanager/v1/secretmanager_v1.yaml",
+		SpecificationSource: "google/cloud/secretmanager/v1",
+	}
+	openapi := config.GeneralConfig{
+		SpecificationFormat: "openapi",
+		ServiceConfig:       path.Join(testdataDir, "googleapis/google/cloud/secretmanager/v1/secretmanager_v1.yaml"),
+		SpecificationSource: path.Join(testdataDir, "openapi/secretmanager_openapi_v1.json"),
+	}
+	for _, test := range []struct {
+		name    string
+		general config.GeneralConfig
+		codec   map[string]string
+		pkg     string
+	}{
+		{
+			name:    "protobuf",
+			general: protobuf,
+			codec:   map[string]string{"copyright-year": "2025"},
+			pkg:     "secretmanager_v1",
+		},
+		{
+			name:    "protobuf-proto-plus",
+			general: protobuf,
+			codec:   map[string]string{"message-types": "proto-plus"},
+			pkg:     "secretmanager_v1",
+		},
+		{
+			name:    "openapi",
+			general: openapi,
+			codec:   map[string]string{"package-name-override": "secretmanager"},
+			pkg:     "secretmanager",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := &config.Config{
+				General: test.general,
+				Source: map[string]string{
+					"googleapis-root": path.Join(testdataDir, "googleapis"),
+				},
+				Codec: test.codec,
+			}
+			outDir := t.TempDir()
+			model, err := parser.CreateModel(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := Generate(model, outDir, cfg); err != nil {
+				t.Fatal(err)
+			}
+			for _, expected := range []string{
+				"pyproject.toml",
+				path.Join(test.pkg, "__init__.py"),
+				path.Join(test.pkg, "_encoding.py"),
+				path.Join(test.pkg, "_transport.py"),
+				path.Join(test.pkg, "client.py"),
+				path.Join(test.pkg, "pagers.py"),
+				path.Join(test.pkg, "types.py"),
+			} {
+				if _, err := os.Stat(path.Join(outDir, expected)); err != nil {
+					t.Errorf("missing %s: %v", expected, err)
+				}
+			}
+			compilePackage(t, outDir)
+		})
+	}
+}
+
+// pythonCommand returns the Python interpreter, skipping the test if it is not
+// installed.
+func pythonCommand(t *testing.T) string {
+	t.Helper()
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("skipping the checks of the generated code, python3 is not installed")
+	}
+	return python
+}
+
+// compilePackage byte-compiles the generated code, which detects syntax
+// errors. It does not import the code, the proto-plus packages may not be
+// installed.
+func compilePackage(t *testing.T, dir string) {
+	t.Helper()
+	cmd := exec.Command(pythonCommand(t), "-m", "compileall", "-q", dir)
+	cmd.Env = append(os.Environ(), "PYTHONDONTWRITEBYTECODE=")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("python3 -m compileall failed: %v\n%s", err, output)
+	}
+}
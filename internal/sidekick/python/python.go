@@ -0,0 +1,328 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package python implements a native Python code generator.
+//
+// The generated packages contain REST clients, and the messages as either
+// dataclasses or proto-plus messages. The dataclasses depend only on the Python
+// standard library, which allows generating clients from discovery docs and
+// OpenAPI specs without protoc or the Python GAPIC generator.
+package python
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/iancoleman/strcase"
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+)
+
+// The message types supported by the codec.
+const (
+	dataclassMessages = "dataclass"
+	protoPlusMessages = "proto-plus"
+)
+
+// wellKnownType describes how a well-known type maps to Python.
+type wellKnownType struct {
+	// The type hint with dataclasses.
+	Hint string
+	// The encoding with dataclasses, see `_encoding.py`.
+	Encoding string
+	// The proto-plus message, e.g. `timestamp_pb2.Timestamp`, and the module
+	// that defines it.
+	ProtoPlus       string
+	ProtoPlusModule string
+}
+
+// wellKnownTypes maps the well-known types to Python types with the same JSON
+// encoding. The generator does not generate classes for these messages.
+var wellKnownTypes = map[string]wellKnownType{
+	".google.protobuf.Any":         {Hint: "Dict[str, Any]", Encoding: "VALUE", ProtoPlus: "any_pb2.Any", ProtoPlusModule: "any_pb2"},
+	".google.protobuf.BoolValue":   {Hint: "bool", Encoding: "BOOL", ProtoPlus: "wrappers_pb2.BoolValue", ProtoPlusModule: "wrappers_pb2"},
+	".google.protobuf.BytesValue":  {Hint: "bytes", Encoding: "BYTES", ProtoPlus: "wrappers_pb2.BytesValue", ProtoPlusModule: "wrappers_pb2"},
+	".google.protobuf.DoubleValue": {Hint: "float", Encoding: "FLOAT", ProtoPlus: "wrappers_pb2.DoubleValue", ProtoPlusModule: "wrappers_pb2"},
+	".google.protobuf.Duration":    {Hint: "str", Encoding: "VALUE", ProtoPlus: "duration_pb2.Duration", ProtoPlusModule: "duration_pb2"},
+	".google.protobuf.Empty":       {Hint: "Dict[str, Any]", Encoding: "VALUE", ProtoPlus: "empty_pb2.Empty", ProtoPlusModule: "empty_pb2"},
+	".google.protobuf.FieldMask":   {Hint: "str", Encoding: "VALUE", ProtoPlus: "field_mask_pb2.FieldMask", ProtoPlusModule: "field_mask_pb2"},
+	".google.protobuf.FloatValue":  {Hint: "float", Encoding: "FLOAT", ProtoPlus: "wrappers_pb2.FloatValue", ProtoPlusModule: "wrappers_pb2"},
+	".google.protobuf.Int32Value":  {Hint: "int", Encoding: "INT", ProtoPlus: "wrappers_pb2.Int32Value", ProtoPlusModule: "wrappers_pb2"},
+	".google.protobuf.Int64Value":  {Hint: "int", Encoding: "INT64", ProtoPlus: "wrappers_pb2.Int64Value", ProtoPlusModule: "wrappers_pb2"},
+	".google.protobuf.ListValue":   {Hint: "List[Any]", Encoding: "VALUE", ProtoPlus: "struct_pb2.ListValue", ProtoPlusModule: "struct_pb2"},
+	".google.protobuf.StringValue": {Hint: "str", Encoding: "STRING", ProtoPlus: "wrappers_pb2.StringValue", ProtoPlusModule: "wrappers_pb2"},
+	".google.protobuf.Struct":      {Hint: "Dict[str, Any]", Encoding: "VALUE", ProtoPlus: "struct_pb2.Struct", ProtoPlusModule: "struct_pb2"},
+	".google.protobuf.Timestamp":   {Hint: "str", Encoding: "VALUE", ProtoPlus: "timestamp_pb2.Timestamp", ProtoPlusModule: "timestamp_pb2"},
+	".google.protobuf.UInt32Value": {Hint: "int", Encoding: "INT", ProtoPlus: "wrappers_pb2.UInt32Value", ProtoPlusModule: "wrappers_pb2"},
+	".google.protobuf.UInt64Value": {Hint: "int", Encoding: "INT64", ProtoPlus: "wrappers_pb2.UInt64Value", ProtoPlusModule: "wrappers_pb2"},
+	".google.protobuf.Value":       {Hint: "Any", Encoding: "VALUE", ProtoPlus: "struct_pb2.Value", ProtoPlusModule: "struct_pb2"},
+	".google.protobuf.NullValue":   {Hint: "Any", Encoding: "VALUE", ProtoPlus: "struct_pb2.NullValue", ProtoPlusModule: "struct_pb2"},
+}
+
+// isWellKnownType returns true if the message or enum ID maps to a Python type.
+func isWellKnownType(id string) bool {
+	_, ok := wellKnownTypes[id]
+	return ok
+}
+
+// scalarHint returns the type hint for scalar fields.
+func scalarHint(typez api.Typez) string {
+	switch typez {
+	case api.BOOL_TYPE:
+		return "bool"
+	case api.STRING_TYPE:
+		return "str"
+	case api.BYTES_TYPE:
+		return "bytes"
+	case api.FLOAT_TYPE, api.DOUBLE_TYPE:
+		return "float"
+	default:
+		return "int"
+	}
+}
+
+// scalarEncoding returns the encoding of scalar fields with dataclasses.
+func scalarEncoding(typez api.Typez) string {
+	switch typez {
+	case api.BOOL_TYPE:
+		return "BOOL"
+	case api.STRING_TYPE:
+		return "STRING"
+	case api.BYTES_TYPE:
+		return "BYTES"
+	case api.FLOAT_TYPE, api.DOUBLE_TYPE:
+		return "FLOAT"
+	case api.INT64_TYPE, api.SINT64_TYPE, api.SFIXED64_TYPE,
+		api.UINT64_TYPE, api.FIXED64_TYPE:
+		return "INT64"
+	default:
+		return "INT"
+	}
+}
+
+// scalarDefault returns the default value of scalar fields.
+func scalarDefault(typez api.Typez) string {
+	switch typez {
+	case api.BOOL_TYPE:
+		return "False"
+	case api.STRING_TYPE:
+		return `""`
+	case api.BYTES_TYPE:
+		return `b""`
+	case api.FLOAT_TYPE, api.DOUBLE_TYPE:
+		return "0.0"
+	default:
+		return "0"
+	}
+}
+
+// protoPlusType returns the proto-plus type constant for scalar fields, e.g.
+// `proto.STRING`.
+func protoPlusType(typez api.Typez) string {
+	switch typez {
+	case api.DOUBLE_TYPE:
+		return "proto.DOUBLE"
+	case api.FLOAT_TYPE:
+		return "proto.FLOAT"
+	case api.INT64_TYPE:
+		return "proto.INT64"
+	case api.UINT64_TYPE:
+		return "proto.UINT64"
+	case api.INT32_TYPE:
+		return "proto.INT32"
+	case api.FIXED64_TYPE:
+		return "proto.FIXED64"
+	case api.FIXED32_TYPE:
+		return "proto.FIXED32"
+	case api.BOOL_TYPE:
+		return "proto.BOOL"
+	case api.STRING_TYPE:
+		return "proto.STRING"
+	case api.BYTES_TYPE:
+		return "proto.BYTES"
+	case api.UINT32_TYPE:
+		return "proto.UINT32"
+	case api.SFIXED32_TYPE:
+		return "proto.SFIXED32"
+	case api.SFIXED64_TYPE:
+		return "proto.SFIXED64"
+	case api.SINT32_TYPE:
+		return "proto.SINT32"
+	case api.SINT64_TYPE:
+		return "proto.SINT64"
+	case api.ENUM_TYPE:
+		return "proto.ENUM"
+	default:
+		return "proto.MESSAGE"
+	}
+}
+
+// pythonKeywords are the Python keywords, and the names the generated classes
+// use, that cannot be used as attributes.
+var pythonKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true,
+	"assert": true, "async": true, "await": true, "break": true, "class": true,
+	"continue": true, "def": true, "del": true, "elif": true, "else": true,
+	"except": true, "finally": true, "for": true, "from": true, "global": true,
+	"if": true, "import": true, "in": true, "is": true, "lambda": true,
+	"nonlocal": true, "not": true, "or": true, "pass": true, "raise": true,
+	"return": true, "try": true, "while": true, "with": true, "yield": true,
+	"self": true,
+}
+
+var nonIdentifier = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// escapeKeyword appends an underscore to names that are Python keywords.
+func escapeKeyword(name string) string {
+	if pythonKeywords[name] {
+		return name + "_"
+	}
+	return name
+}
+
+// toSnake converts a name in any of the conventions used by the
+// specification formats to a Python attribute or method name.
+func toSnake(name string) string {
+	name = strcase.ToSnake(nonIdentifier.ReplaceAllString(name, "_"))
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "x" + name
+	}
+	return escapeKeyword(name)
+}
+
+// toPascal converts a name to a Python class name.
+func toPascal(name string) string {
+	name = strcase.ToCamel(nonIdentifier.ReplaceAllString(name, "_"))
+	if name == "" || !unicode.IsLetter(rune(name[0])) {
+		name = "X" + name
+	}
+	return escapeKeyword(name)
+}
+
+// valueIdentifier converts an enum value, which may be any string in
+// discovery docs and OpenAPI specs, to a valid enum member name.
+func valueIdentifier(name string) string {
+	name = strings.Trim(nonIdentifier.ReplaceAllString(name, "_"), "_")
+	if name == "" {
+		return "EMPTY"
+	}
+	if unicode.IsDigit(rune(name[0])) {
+		name = "V" + name
+	}
+	return escapeKeyword(name)
+}
+
+// commentRefsRegex finds cross-references in the documentation, such as
+// `[Secret][google.cloud.secretmanager.v1.Secret]`, and `[Secret][]`.
+var commentRefsRegex = regexp.MustCompile(`\[([^\]]+)\]\[([\w\.]*)\]`)
+
+// docLines converts the documentation of an element into plain text lines.
+// Python docstrings have no syntax for cross-references outside the package,
+// the references are replaced by their text.
+func docLines(documentation string) []string {
+	if documentation == "" {
+		return nil
+	}
+	lines := strings.Split(documentation, "\n")
+	for i, line := range lines {
+		line = strings.TrimRightFunc(line, unicode.IsSpace)
+		line = commentRefsRegex.ReplaceAllString(line, "$1")
+		lines[i] = strings.ReplaceAll(strings.ReplaceAll(line, `\`, `\\`), `"""`, `\"\"\"`)
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// docstring returns the lines of a docstring, including the quotes. It
+// returns nil if there is no documentation.
+func docstring(lines []string) []string {
+	if len(lines) == 0 {
+		return nil
+	}
+	if len(lines) == 1 && !strings.HasSuffix(lines[0], `"`) {
+		return []string{`"""` + lines[0] + `"""`}
+	}
+	result := []string{`"""` + lines[0]}
+	result = append(result, lines[1:]...)
+	return append(result, `"""`)
+}
+
+// appendParagraph adds a paragraph to documentation lines.
+func appendParagraph(lines []string, paragraph ...string) []string {
+	if len(lines) != 0 {
+		lines = append(lines, "")
+	}
+	return append(lines, paragraph...)
+}
+
+// indent indents each non-empty line.
+func indent(lines []string, prefix string) []string {
+	var result []string
+	for _, line := range lines {
+		if line == "" {
+			result = append(result, "")
+			continue
+		}
+		result = append(result, prefix+line)
+	}
+	return result
+}
+
+// packageName returns the name of the generated Python package, e.g.
+// `secretmanager_v1` for `google.cloud.secretmanager.v1`.
+func packageName(model *api.API, override string) string {
+	if override != "" {
+		return override
+	}
+	parts := strings.Split(model.PackageName, ".")
+	for i := len(parts) - 1; i >= 0; i-- {
+		if parts[i] == "" || isVersion(parts[i]) {
+			continue
+		}
+		name := toSnake(parts[i])
+		if i+1 < len(parts) && isVersion(parts[i+1]) {
+			name += "_" + parts[i+1]
+		}
+		return name
+	}
+	return toSnake(model.Name)
+}
+
+func isVersion(s string) bool {
+	return len(s) > 1 && s[0] == 'v' && unicode.IsDigit(rune(s[1]))
+}
+
+// pythonList formats a list of strings as a Python list literal.
+func pythonList(s []string) string {
+	quoted := make([]string, 0, len(s))
+	for _, v := range s {
+		quoted = append(quoted, strconv.Quote(v))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// shouldGenerateMethod returns true if the method can be called with the REST
+// transport.
+func shouldGenerateMethod(m *api.Method) bool {
+	if m.ClientSideStreaming || m.ServerSideStreaming {
+		return false
+	}
+	if m.PathInfo == nil || len(m.PathInfo.Bindings) == 0 {
+		return false
+	}
+	return m.PathInfo.Bindings[0].PathTemplate != nil
+}
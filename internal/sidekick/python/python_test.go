@@ -0,0 +1,163 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+)
+
+func TestToSnake(t *testing.T) {
+	for _, test := range []struct {
+		input string
+		want  string
+	}{
+		{"secret_id", "secret_id"},
+		{"displayName", "display_name"},
+		{"kebab-case", "kebab_case"},
+		{"CreateSecret", "create_secret"},
+		{"from", "from_"},
+		{"self", "self_"},
+		{"1st", "x1_st"},
+	} {
+		if got := toSnake(test.input); got != test.want {
+			t.Errorf("toSnake(%q) = %q, want %q", test.input, got, test.want)
+		}
+	}
+}
+
+func TestToPascal(t *testing.T) {
+	for _, test := range []struct {
+		input string
+		want  string
+	}{
+		{"Secret", "Secret"},
+		{"secret_version", "SecretVersion"},
+		{"kebab-case", "KebabCase"},
+		{"1st", "X1St"},
+		{"", "X"},
+	} {
+		if got := toPascal(test.input); got != test.want {
+			t.Errorf("toPascal(%q) = %q, want %q", test.input, got, test.want)
+		}
+	}
+}
+
+func TestValueIdentifier(t *testing.T) {
+	for _, test := range []struct {
+		input string
+		want  string
+	}{
+		{"ACTIVE", "ACTIVE"},
+		{"with-dashes", "with_dashes"},
+		{"a.b/c", "a_b_c"},
+		{"2XX", "V2XX"},
+		{"None", "None_"},
+		{"-", "EMPTY"},
+	} {
+		if got := valueIdentifier(test.input); got != test.want {
+			t.Errorf("valueIdentifier(%q) = %q, want %q", test.input, got, test.want)
+		}
+	}
+}
+
+func TestProtoJSONName(t *testing.T) {
+	for _, test := range []struct {
+		input string
+		want  string
+	}{
+		{"name", "name"},
+		{"display_name", "displayName"},
+		{"from_", "from"},
+	} {
+		if got := protoJSONName(test.input); got != test.want {
+			t.Errorf("protoJSONName(%q) = %q, want %q", test.input, got, test.want)
+		}
+	}
+}
+
+func TestDocstring(t *testing.T) {
+	input := `Creates a [Secret][google.cloud.secretmanager.v1.Secret].
+
+Uses the [parent][] field, see """quotes""" and \d.
+`
+	want := []string{
+		`"""Creates a Secret.`,
+		"",
+		`Uses the parent field, see \"\"\"quotes\"\"\" and \\d.`,
+		`"""`,
+	}
+	if diff := cmp.Diff(want, docstring(docLines(input))); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{`"""A single line."""`}, docstring(docLines("A single line."))); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+	if got := docstring(docLines("")); got != nil {
+		t.Errorf("expected no docstring for empty documentation, got=%v", got)
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	for _, test := range []struct {
+		packageName string
+		override    string
+		want        string
+	}{
+		{"google.cloud.secretmanager.v1", "", "secretmanager_v1"},
+		{"google.cloud.foo.v1beta2", "", "foo_v1beta2"},
+		{"google.cloud.secretManager", "", "secret_manager"},
+		{"google.cloud.secretmanager.v1", "sm", "sm"},
+		{"", "", "test"},
+	} {
+		model := &api.API{Name: "Test", PackageName: test.packageName}
+		if got := packageName(model, test.override); got != test.want {
+			t.Errorf("packageName(%q, %q) = %q, want %q", test.packageName, test.override, got, test.want)
+		}
+	}
+}
+
+func TestNewCodec(t *testing.T) {
+	got, err := newCodec(map[string]string{
+		"package-name-override": "sm",
+		"distribution-name":     "google-cloud-sm",
+		"version":               "1.2.3",
+		"message-types":         "proto-plus",
+		"copyright-year":        "2024",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &codec{
+		packageNameOverride: "sm",
+		distributionName:    "google-cloud-sm",
+		version:             "1.2.3",
+		messageTypes:        protoPlusMessages,
+		generationYear:      "2024",
+	}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(codec{})); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+	for _, options := range []map[string]string{
+		{"unknown": "true"},
+		{"message-types": "pydantic"},
+	} {
+		if _, err := newCodec(options); err == nil {
+			t.Errorf("expected an error with options %v", options)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+# Copyright 2025 Google LLC
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     https://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+"""Tests the package generated from `sample.ResourceAPI()`.
+
+The tests run the generated client against a fake session.
+"""
+
+import json
+import os
+import re
+import tempfile
+import unittest
+import urllib.parse
+from unittest import mock
+
+import test_v1
+from google.api_core import exceptions as core_exceptions
+from google.auth import credentials as ga_credentials
+from google.auth import exceptions as auth_exceptions
+from google.auth.transport.requests import AuthorizedSession
+from test_v1 import types
+
+
+class FakeResponse:
+    def __init__(self, status_code, payload):
+        self.status_code = status_code
+        self.content = json.dumps(payload).encode("utf-8")
+
+
+class FakeSession:
+    """Records the requests and returns the responses for each path."""
+
+    def __init__(self, responses):
+        self.responses = responses
+        self.requests = []
+
+    def request(self, method, url, *, params=None, data=None, headers=None, timeout=None):
+        self.requests.append(
+            {
+                "method": method,
+                "url": url,
+                "params": params,
+                "body": json.loads(data) if data else None,
+                "headers": headers,
+            }
+        )
+        path = urllib.parse.urlparse(url).path
+        if path not in self.responses or not self.responses[path]:
+            return FakeResponse(404, {"error": {"code": 404, "message": "not found", "status": "NOT_FOUND"}})
+        return FakeResponse(200, self.responses[path].pop(0))
+
+
+class ClientTest(unittest.TestCase):
+    def setUp(self):
+        test_v1.operation.Operation.initial_delay = 0
+
+    def test_create_resource(self):
+        session = FakeSession(
+            {
+                "/v1/projects/my-project/resources": [{"name": "projects/my-project/operations/op1"}],
+                "/v1/projects/my-project/operations/op1": [
+                    {"name": "projects/my-project/operations/op1", "metadata": {"createTime": "2025-01-02T03:04:05Z"}},
+                    {
+                        "name": "projects/my-project/operations/op1",
+                        "done": True,
+                        "response": {"@type": "type.googleapis.com/test.v1.Resource", "name": "r1", "size": "42", "counts": ["1", 2]},
+                    },
+                ],
+            }
+        )
+        client = test_v1.ResourceServiceClient(endpoint="https://test.example.com", session=session)
+        op = client.create_resource(
+            types.CreateResourceRequest(parent="projects/my-project", resource=types.Resource(name="r1", labels={"a": "b"}))
+        )
+        self.assertEqual(op.name, "projects/my-project/operations/op1")
+        self.assertFalse(op.done())
+
+        result = op.result()
+        self.assertEqual(result, types.Resource(name="r1", size=42, counts=[1, 2]))
+        self.assertEqual(op.metadata, None)
+
+        create = session.requests[0]
+        self.assertEqual(create["method"], "POST")
+        self.assertEqual(create["url"], "https://test.example.com/v1/projects/my-project/resources")
+        self.assertEqual(create["headers"]["x-goog-request-params"], "project=projects%2Fmy-project")
+        self.assertEqual(create["body"], {"name": "r1", "labels": {"a": "b"}})
+        params = dict(create["params"])
+        self.assertRegex(params["requestId"], r"^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$")
+        self.assertNotIn("validateOnly", params)
+        self.assertEqual(len(session.requests), 3)
+
+    def test_create_resource_validate_only(self):
+        session = FakeSession({"/v1/projects/my-project/resources": [{"name": "op1", "done": True, "response": {}}]})
+        client = test_v1.ResourceServiceClient(session=session)
+        request = types.CreateResourceRequest(parent="projects/my-project", request_id="my-id", validate_only=False)
+        client.create_resource(request).result()
+        self.assertEqual(session.requests[0]["params"], [("requestId", "my-id"), ("validateOnly", "false")])
+        self.assertEqual(session.requests[0]["url"], "https://test.googleapis.com/v1/projects/my-project/resources")
+
+    def test_create_resource_failed(self):
+        session = FakeSession(
+            {
+                "/v1/projects/my-project/resources": [
+                    {"name": "op1", "done": True, "error": {"code": 3, "message": "invalid resource"}},
+                ],
+            }
+        )
+        client = test_v1.ResourceServiceClient(session=session)
+        op = client.create_resource(types.CreateResourceRequest(parent="projects/my-project"))
+        with self.assertRaises(core_exceptions.GoogleAPICallError) as e:
+            op.result()
+        self.assertEqual(e.exception.message, "invalid resource")
+
+    def test_resume_operation(self):
+        session = FakeSession(
+            {
+                "/v1/projects/my-project/operations/op1": [
+                    {
+                        "name": "projects/my-project/operations/op1",
+                        "done": True,
+                        "metadata": {"createTime": "2025-01-02T03:04:05Z"},
+                        "response": {"name": "r1", "state": "ACTIVE"},
+                    },
+                ],
+            }
+        )
+        client = test_v1.ResourceServiceClient(session=session)
+        op = client.create_resource_operation("projects/my-project/operations/op1")
+        self.assertEqual(op.result().state, types.State.ACTIVE)
+        self.assertEqual(op.metadata, types.OperationMetadata(create_time="2025-01-02T03:04:05Z"))
+
+    def test_missing_path_field(self):
+        client = test_v1.ResourceServiceClient(session=FakeSession({}))
+        with self.assertRaisesRegex(ValueError, "parent"):
+            client.create_resource(types.CreateResourceRequest())
+
+    def test_list_resources(self):
+        session = FakeSession(
+            {
+                "/v1/projects/my-project/resources": [
+                    {"resources": [{"name": "r1"}, {"name": "r2"}], "nextPageToken": "t1"},
+                    {"resources": [{"name": "r3"}]},
+                ],
+            }
+        )
+        client = test_v1.ResourceServiceClient(session=session)
+        request = types.ListResourcesRequest(parent="projects/my-project", page_size=2)
+        names = [r.name for r in client.list_resources(request)]
+        self.assertEqual(names, ["r1", "r2", "r3"])
+        self.assertEqual(request.page_token, "")
+        self.assertEqual(session.requests[0]["params"], [("pageSize", "2")])
+        self.assertEqual(session.requests[1]["params"], [("pageSize", "2"), ("pageToken", "t1")])
+        self.assertEqual(session.requests[0]["headers"]["x-goog-request-params"], "parent=projects%2Fmy-project")
+        self.assertIsNone(session.requests[0]["body"])
+
+    def test_api_error(self):
+        client = test_v1.ResourceServiceClient(session=FakeSession({}))
+        with self.assertRaises(core_exceptions.NotFound) as e:
+            list(client.list_resources(types.ListResourcesRequest(parent="projects/my-project")))
+        self.assertEqual(e.exception.code, 404)
+        self.assertEqual(e.exception.message, "not found")
+
+    def test_api_key(self):
+        session = FakeSession({"/v1/projects/p/resources": [{}]})
+        client = test_v1.ResourceServiceClient(session=session, api_key="my-key")
+        self.assertEqual(list(client.list_resources(types.ListResourcesRequest(parent="projects/p"))), [])
+        self.assertEqual(session.requests[0]["headers"]["x-goog-api-key"], "my-key")
+
+    def test_credentials(self):
+        client = test_v1.ResourceServiceClient(credentials=ga_credentials.AnonymousCredentials())
+        self.assertIsInstance(client._transport._session, AuthorizedSession)
+
+    def test_default_credentials(self):
+        with tempfile.TemporaryDirectory() as tmp:
+            missing = os.path.join(tmp, "missing.json")
+            with mock.patch.dict(os.environ, {"GOOGLE_APPLICATION_CREDENTIALS": missing}):
+                with self.assertRaises(auth_exceptions.DefaultCredentialsError):
+                    test_v1.ResourceServiceClient()
+
+
+class EncodingTest(unittest.TestCase):
+    def test_round_trip(self):
+        resource = types.Resource(
+            name="r1",
+            state=types.State.ACTIVE,
+            size=0,
+            counts=[1, 2**63],
+            labels={"k": "v"},
+            update_time="2025-01-02T03:04:05Z",
+            spec=types.Resource.Spec(replicas=3, data=b"\xff\x00", kind=types.Resource.Kind.LARGE),
+            from_="here",
+        )
+        data = resource.to_dict()
+        self.assertEqual(
+            data,
+            {
+                "name": "r1",
+                "state": "ACTIVE",
+                "size": "0",
+                "counts": ["1", str(2**63)],
+                "labels": {"k": "v"},
+                "updateTime": "2025-01-02T03:04:05Z",
+                "spec": {"replicas": 3, "data": "/wA=", "kind": "LARGE"},
+                "from": "here",
+            },
+        )
+        self.assertEqual(types.Resource.from_dict(json.loads(json.dumps(data))), resource)
+
+    def test_defaults(self):
+        self.assertEqual(types.Resource().to_dict(), {})
+        self.assertEqual(types.Resource.from_dict({}), types.Resource())
+
+    def test_unknown_values(self):
+        resource = types.Resource.from_dict({"state": "NEW_STATE", "unknownField": 1, "spec": {"data": "_wA"}})
+        self.assertEqual(resource.state, "NEW_STATE")
+        self.assertEqual(resource.spec.data, b"\xff\x00")
+
+    def test_proto_names(self):
+        resource = types.Resource.from_dict({"update_time": "2025-01-02T03:04:05Z"})
+        self.assertEqual(resource.update_time, "2025-01-02T03:04:05Z")
+
+
+if __name__ == "__main__":
+    unittest.main()
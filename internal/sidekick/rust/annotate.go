@@ -142,6 +142,14 @@ func (s *serviceAnnotations) HasLROs() bool {
 	return slices.IndexFunc(s.Methods, func(m *api.Method) bool { return m.DiscoveryLro != nil }) != -1
 }
 
+// HasMediaMethods returns true if this service includes methods that upload or
+// download media.
+func (s *serviceAnnotations) HasMediaMethods() bool {
+	return slices.IndexFunc(s.Methods, func(m *api.Method) bool {
+		return m.MediaUpload != nil || m.SupportsMediaDownload
+	}) != -1
+}
+
 // FeatureName returns the feature name for the service.
 func (a *serviceAnnotations) FeatureName() string {
 	return strcase.ToKebab(a.ModuleName)
@@ -223,6 +231,52 @@ type methodAnnotation struct {
 	Attributes                []string
 	RoutingRequired           bool
 	DetailedTracingAttributes bool
+	// Helpers to build the requests that upload or download media.
+	MediaBuilders []*mediaBuilder
+}
+
+// HasMediaBuilders returns true if the method uploads or downloads media.
+func (m *methodAnnotation) HasMediaBuilders() bool {
+	return len(m.MediaBuilders) != 0
+}
+
+// mediaBuilder describes a client method that uploads or downloads media,
+// and the helper to create its request builder.
+//
+// gaxi::http::ReqwestClient only sends and receives JSON payloads. The
+// generated helpers prepare the request (path, query parameters, and the
+// media protocol selector), and the transport sends the media with its own
+// credentials.
+type mediaBuilder struct {
+	// The name of the helper function, e.g. `insert_upload_builder`.
+	Name string
+	// The name of the client method, e.g. `insert_upload`.
+	MethodName string
+	// The type returned by the client method: the method response for
+	// uploads, and `bytes::Bytes` for downloads.
+	ReturnType string
+	// Download is true if the method downloads the media.
+	Download bool
+	// Multipart is true if the request body is sent along with the media in
+	// a single `multipart/related` request.
+	Multipart bool
+	// Resumable is true if the media is sent using a resumable upload
+	// session.
+	Resumable bool
+	// The expression to access the request body, sent as the metadata of
+	// multipart and resumable uploads. Empty if the method has no body.
+	Metadata string
+	// A short description of the protocol, used in the documentation.
+	Description string
+	// The query parameter selecting the protocol, e.g. `uploadType`.
+	QueryName string
+	// The value for the query parameter, e.g. `multipart`.
+	QueryValue string
+	// The HTTP binding for the request.
+	Binding *api.PathBinding
+	// The system parameters for the request. Downloads do not use them, as
+	// they would override the `alt=media` query parameter.
+	SystemParameters []systemParameter
 }
 
 type pathInfoAnnotation struct {
@@ -821,6 +875,7 @@ func (c *codec) annotateMethod(m *api.Method) {
 		RoutingRequired:           c.routingRequired,
 		DetailedTracingAttributes: c.detailedTracingAttributes,
 	}
+	annotation.MediaBuilders = c.mediaBuilders(m, annotation.Name, returnType)
 	if annotation.Name == "clone" {
 		// Some methods look too similar to standard Rust traits. Clippy makes
 		// a recommendation that is not applicable to generated code.
@@ -852,6 +907,76 @@ func (c *codec) annotateMethod(m *api.Method) {
 	m.Codec = annotation
 }
 
+func (c *codec) mediaBuilders(m *api.Method, name, returnType string) []*mediaBuilder {
+	var builders []*mediaBuilder
+	if upload := m.MediaUpload; upload != nil {
+		if p := upload.Simple; p != nil {
+			// Without a request body there is no metadata to send along with
+			// the media.
+			metadata := mediaMetadata(m)
+			multipart := p.Multipart && metadata != ""
+			uploadType := "media"
+			if multipart {
+				uploadType = "multipart"
+			}
+			p.Binding.Codec = c.annotatePathBinding(p.Binding, m)
+			builders = append(builders, &mediaBuilder{
+				Name:             name + "_upload_builder",
+				MethodName:       name + "_upload",
+				ReturnType:       returnType,
+				Multipart:        multipart,
+				Metadata:         metadata,
+				Description:      "a simple media upload",
+				QueryName:        "uploadType",
+				QueryValue:       uploadType,
+				Binding:          p.Binding,
+				SystemParameters: c.systemParameters,
+			})
+		}
+		if p := upload.Resumable; p != nil {
+			p.Binding.Codec = c.annotatePathBinding(p.Binding, m)
+			builders = append(builders, &mediaBuilder{
+				Name:             name + "_resumable_upload_builder",
+				MethodName:       name + "_resumable_upload",
+				ReturnType:       returnType,
+				Resumable:        true,
+				Metadata:         mediaMetadata(m),
+				Description:      "a resumable media upload session",
+				QueryName:        "uploadType",
+				QueryValue:       "resumable",
+				Binding:          p.Binding,
+				SystemParameters: c.systemParameters,
+			})
+		}
+	}
+	if m.SupportsMediaDownload {
+		builders = append(builders, &mediaBuilder{
+			Name:        name + "_download_builder",
+			MethodName:  name + "_download",
+			ReturnType:  "bytes::Bytes",
+			Download:    true,
+			Description: "a media download",
+			QueryName:   "alt",
+			QueryValue:  "media",
+			Binding:     m.PathInfo.Bindings[0],
+		})
+	}
+	return builders
+}
+
+// mediaMetadata returns the expression to access the request body of m, which
+// is sent as the metadata of media uploads.
+func mediaMetadata(m *api.Method) string {
+	switch m.PathInfo.BodyFieldPath {
+	case "":
+		return ""
+	case "*":
+		return "&req"
+	default:
+		return "&req." + toSnake(m.PathInfo.BodyFieldPath)
+	}
+}
+
 func (c *codec) annotateRoutingAccessors(variant *api.RoutingInfoVariant, m *api.Method) []string {
 	return makeAccessors(variant.FieldPath, m)
 }
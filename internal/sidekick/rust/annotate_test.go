@@ -265,6 +265,120 @@ func TestMethodAnnotationsDetailedTracing(t *testing.T) {
 	}
 }
 
+func TestMethodAnnotationsMediaBuilders(t *testing.T) {
+	model := serviceAnnotationsModel()
+	method, ok := model.State.MethodByID[".test.v1.ResourceService.GetResource"]
+	if !ok {
+		t.Fatal("cannot find .test.v1.ResourceService.GetResource")
+	}
+	upload := &api.PathBinding{
+		Verb: "POST",
+		PathTemplate: api.NewPathTemplate().
+			WithLiteral("upload").
+			WithLiteral("v1").
+			WithLiteral("resource"),
+	}
+	resumable := &api.PathBinding{
+		Verb: "POST",
+		PathTemplate: api.NewPathTemplate().
+			WithLiteral("resumable").
+			WithLiteral("upload").
+			WithLiteral("v1").
+			WithLiteral("resource"),
+	}
+	method.MediaUpload = &api.MediaUpload{
+		Simple:    &api.UploadProtocol{Multipart: true, Binding: upload},
+		Resumable: &api.UploadProtocol{Binding: resumable},
+	}
+	method.SupportsMediaDownload = true
+	method.PathInfo.BodyFieldPath = "*"
+	codec, err := newCodec("protobuf", map[string]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotateModel(model, codec)
+	got := method.Codec.(*methodAnnotation)
+	want := []*mediaBuilder{
+		{
+			Name:             "get_resource_upload_builder",
+			MethodName:       "get_resource_upload",
+			ReturnType:       "crate::model::Response",
+			Multipart:        true,
+			Metadata:         "&req",
+			Description:      "a simple media upload",
+			QueryName:        "uploadType",
+			QueryValue:       "multipart",
+			Binding:          upload,
+			SystemParameters: codec.systemParameters,
+		},
+		{
+			Name:             "get_resource_resumable_upload_builder",
+			MethodName:       "get_resource_resumable_upload",
+			ReturnType:       "crate::model::Response",
+			Resumable:        true,
+			Metadata:         "&req",
+			Description:      "a resumable media upload session",
+			QueryName:        "uploadType",
+			QueryValue:       "resumable",
+			Binding:          resumable,
+			SystemParameters: codec.systemParameters,
+		},
+		{
+			Name:        "get_resource_download_builder",
+			MethodName:  "get_resource_download",
+			ReturnType:  "bytes::Bytes",
+			Download:    true,
+			Description: "a media download",
+			QueryName:   "alt",
+			QueryValue:  "media",
+			Binding:     method.PathInfo.Bindings[0],
+		},
+	}
+	if diff := cmp.Diff(want, got.MediaBuilders, cmpopts.IgnoreFields(api.PathBinding{}, "Codec")); diff != "" {
+		t.Errorf("mismatch in media builders (-want, +got)\n:%s", diff)
+	}
+	if !got.HasMediaBuilders() {
+		t.Errorf("expected HasMediaBuilders() for %v", got)
+	}
+	if upload.Codec == nil {
+		t.Errorf("expected the upload binding to be annotated")
+	}
+	service := model.State.ServiceByID[".test.v1.ResourceService"]
+	if !service.Codec.(*serviceAnnotations).HasMediaMethods() {
+		t.Errorf("expected HasMediaMethods() for %v", service.Codec)
+	}
+}
+
+func TestMediaBuildersWithoutBody(t *testing.T) {
+	model := serviceAnnotationsModel()
+	method, ok := model.State.MethodByID[".test.v1.ResourceService.GetResource"]
+	if !ok {
+		t.Fatal("cannot find .test.v1.ResourceService.GetResource")
+	}
+	method.MediaUpload = &api.MediaUpload{
+		Simple: &api.UploadProtocol{
+			Multipart: true,
+			Binding: &api.PathBinding{
+				Verb:         "POST",
+				PathTemplate: api.NewPathTemplate().WithLiteral("upload").WithLiteral("resource"),
+			},
+		},
+	}
+	codec, err := newCodec("protobuf", map[string]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotateModel(model, codec)
+	got := method.Codec.(*methodAnnotation).MediaBuilders
+	if len(got) != 1 {
+		t.Fatalf("expected a single media builder, got=%v", got)
+	}
+	// Without a request body there is no metadata to send with the media.
+	if got[0].Multipart || got[0].QueryValue != "media" || got[0].Metadata != "" {
+		t.Errorf("expected a plain media upload, got=%+v", got[0])
+	}
+}
+
 func TestServiceAnnotationsPerServiceFeatures(t *testing.T) {
 	model := serviceAnnotationsModel()
 	service, ok := model.State.ServiceByID[".test.v1.ResourceService"]
@@ -52,8 +52,10 @@ var commentUrlRegex = regexp.MustCompile(
 		`(/[-a-zA-Z0-9@:%_\+.~#?&/={}\$]*)?`) // Accept just about anything on the query and URL fragments
 
 func newCodec(specificationFormat string, options map[string]string) (*codec, error) {
+	// Descriptor sets are compiled protos, with the same wire conventions.
+	protobuf := specificationFormat == "protobuf" || specificationFormat == "descriptor-set"
 	var sysParams []systemParameter
-	if specificationFormat == "protobuf" {
+	if protobuf {
 		sysParams = append(sysParams, systemParameter{
 			Name: "$alt", Value: "json;enum-encoding=int",
 		})
@@ -72,7 +74,7 @@ func newCodec(specificationFormat string, options map[string]string) (*codec, er
 		version:                 "0.0.0",
 		releaseLevel:            "preview",
 		systemParameters:        sysParams,
-		serializeEnumsAsStrings: specificationFormat != "protobuf",
+		serializeEnumsAsStrings: !protobuf,
 		bytesUseUrlSafeAlphabet: specificationFormat == "disco",
 	}
 
@@ -160,6 +162,12 @@ func newCodec(specificationFormat string, options map[string]string) (*codec, er
 				return nil, fmt.Errorf("cannot convert `generate-setter-samples` value %q to boolean: %w", definition, err)
 			}
 			codec.generateSetterSamples = value
+		case key == "generate-samples":
+			value, err := strconv.ParseBool(definition)
+			if err != nil {
+				return nil, fmt.Errorf("cannot convert `generate-samples` value %q to boolean: %w", definition, err)
+			}
+			codec.generateSamples = value
 		default:
 			return nil, fmt.Errorf("unknown Rust codec option %q", key)
 		}
@@ -298,6 +306,9 @@ type codec struct {
 	routingRequired bool
 	// If true, the generator will produce reference documentation samples for message fields setters.
 	generateSetterSamples bool
+	// If true, the generator will produce a runnable code sample for each
+	// method, and an index of these samples.
+	generateSamples bool
 }
 
 type systemParameter struct {
@@ -329,6 +340,7 @@ func resolveUsedPackages(model *api.API, extraPackages []*packagez) {
 	hasServices := len(model.State.ServiceByID) > 0
 	hasLROs := false
 	hasAutoPopulation := false
+	hasMedia := false
 	for _, s := range model.Services {
 		// In practice, barely any services have auto-population. We are
 		// almost always performing the full loop. `break`ing early does
@@ -341,6 +353,9 @@ func resolveUsedPackages(model *api.API, extraPackages []*packagez) {
 			if len(m.AutoPopulated) != 0 {
 				hasAutoPopulation = true
 			}
+			if m.MediaUpload != nil || m.SupportsMediaDownload {
+				hasMedia = true
+			}
 		}
 	}
 	for _, pkg := range extraPackages {
@@ -360,6 +375,10 @@ func resolveUsedPackages(model *api.API, extraPackages []*packagez) {
 				pkg.used = true
 				break
 			}
+			if namedFeature == "media" && hasMedia {
+				pkg.used = true
+				break
+			}
 		}
 	}
 }
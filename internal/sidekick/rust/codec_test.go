@@ -296,6 +296,15 @@ func TestParseOptions(t *testing.T) {
 				c.generateSetterSamples = true
 			},
 		},
+		{
+			Format: "protobuf",
+			Options: map[string]string{
+				"generate-samples": "true",
+			},
+			Update: func(c *codec) {
+				c.generateSamples = true
+			},
+		},
 	} {
 		want, err := newCodec(test.Format, map[string]string{})
 		if err != nil {
@@ -331,6 +340,7 @@ func TestParseOptionsErrors(t *testing.T) {
 		{Options: map[string]string{"has-veneer": ""}},
 		{Options: map[string]string{"routing-required": ""}},
 		{Options: map[string]string{"generate-setter-samples": ""}},
+		{Options: map[string]string{"generate-samples": ""}},
 		{Options: map[string]string{"--invalid--": ""}},
 	} {
 		if got, err := newCodec("disco", test.Options); err == nil {
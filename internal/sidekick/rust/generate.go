@@ -35,7 +35,24 @@ func Generate(model *api.API, outdir string, cfg *config.Config) error {
 	annotations := annotateModel(model, codec)
 	provider := templatesProvider()
 	generatedFiles := codec.generatedFiles(annotations.HasServices())
-	return language.GenerateFromModel(outdir, model, provider, generatedFiles)
+	if err := language.GenerateFromModel(outdir, model, provider, generatedFiles); err != nil {
+		return err
+	}
+	if !codec.generateSamples || codec.templateOverride != "" || !annotations.HasServices() {
+		return nil
+	}
+	return language.GenerateSnippets(outdir, "examples/snippet_index.json", "rust", provider, samples(model))
+}
+
+// Annotate runs the Rust annotations on the model, without generating any
+// code. The annotations are stored in the `Codec` field of each element.
+func Annotate(model *api.API, cfg *config.Config) error {
+	codec, err := newCodec(cfg.General.SpecificationFormat, cfg.Codec)
+	if err != nil {
+		return err
+	}
+	annotateModel(model, codec)
+	return nil
 }
 
 // GenerateStorage generates Rust code for the storage service.
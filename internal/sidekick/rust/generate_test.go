@@ -16,13 +16,13 @@ package rust
 
 import (
 	"os"
-	"os/exec"
 	"path"
 	"path/filepath"
 	"slices"
 	"strings"
 	"testing"
 
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
 	"github.com/julieqiu/librarianx/internal/sidekick/config"
 	"github.com/julieqiu/librarianx/internal/sidekick/parser"
 )
@@ -108,7 +108,6 @@ func TestCodecError(t *testing.T) {
 }
 
 func TestRustFromOpenAPI(t *testing.T) {
-	requireProtoc(t)
 	outDir := t.TempDir()
 
 	cfg := &config.Config{
@@ -139,7 +138,6 @@ func TestRustFromOpenAPI(t *testing.T) {
 }
 
 func TestRustFromProtobuf(t *testing.T) {
-	requireProtoc(t)
 	outDir := t.TempDir()
 
 	cfg := &config.Config{
@@ -172,8 +170,134 @@ func TestRustFromProtobuf(t *testing.T) {
 	importsModelModules(t, path.Join(outDir, "src", "model.rs"))
 }
 
+func TestRustMedia(t *testing.T) {
+	outDir := t.TempDir()
+
+	cfg := &config.Config{
+		General: config.GeneralConfig{
+			SpecificationFormat: "openapi",
+			ServiceConfig:       path.Join(testdataDir, "googleapis/google/cloud/secretmanager/v1/secretmanager_v1.yaml"),
+			SpecificationSource: path.Join(testdataDir, "openapi/secretmanager_openapi_v1.json"),
+		},
+	}
+	model, err := parser.CreateModel(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	method, ok := model.State.MethodByID[".google.cloud.secretmanager.v1.SecretManagerService.AddSecretVersion"]
+	if !ok {
+		t.Fatal("cannot find AddSecretVersion")
+	}
+	method.MediaUpload = &api.MediaUpload{
+		Simple: &api.UploadProtocol{
+			Multipart: true,
+			Binding: &api.PathBinding{
+				Verb:         "POST",
+				PathTemplate: method.PathInfo.Bindings[0].PathTemplate,
+			},
+		},
+		Resumable: &api.UploadProtocol{
+			Binding: &api.PathBinding{
+				Verb:         "POST",
+				PathTemplate: method.PathInfo.Bindings[0].PathTemplate,
+			},
+		},
+	}
+	method.SupportsMediaDownload = true
+	if err := Generate(model, outDir, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		filename string
+		want     []string
+	}{
+		{
+			filename: path.Join("src", "client.rs"),
+			want: []string{
+				"pub async fn add_secret_version_upload(",
+				"pub async fn add_secret_version_resumable_upload(",
+				"pub async fn add_secret_version_download(",
+			},
+		},
+		{
+			filename: path.Join("src", "stub.rs"),
+			want: []string{
+				"fn add_secret_version_upload(",
+				"fn add_secret_version_resumable_upload(",
+				"fn add_secret_version_download(",
+			},
+		},
+		{
+			filename: path.Join("src", "transport.rs"),
+			want: []string{
+				"cred: auth::credentials::Credentials,",
+				"async fn add_secret_version_upload(",
+				"let builder = self.add_secret_version_upload_builder(&req)?;",
+				"multipart/related; boundary={boundary}",
+				"let session = self.send_media(builder).await?;",
+				"async fn add_secret_version_download(",
+			},
+		},
+	} {
+		contents, err := os.ReadFile(path.Join(outDir, test.filename))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range test.want {
+			if !strings.Contains(string(contents), want) {
+				t.Errorf("expected %q in %s", want, test.filename)
+			}
+		}
+	}
+}
+
+func TestRustSamples(t *testing.T) {
+	outDir := t.TempDir()
+
+	cfg := &config.Config{
+		General: config.GeneralConfig{
+			SpecificationFormat: "protobuf",
+			ServiceConfig:       "google/cloud/secretmanager/v1/secretmanager_v1.yaml",
+			SpecificationSource: "google/cloud/secretmanager/v1",
+		},
+		Source: map[string]string{
+			"googleapis-root": path.Join(testdataDir, "googleapis"),
+		},
+		Codec: map[string]string{
+			"copyright-year":   "2025",
+			"generate-samples": "true",
+		},
+	}
+	model, err := parser.CreateModel(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Generate(model, outDir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	contents, err := os.ReadFile(path.Join(outDir, "examples", "secret_manager_service_create_secret.rs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(contents)
+	for _, want := range []string{
+		"// [START secretmanager_v1_generated_SecretManagerService_CreateSecret_async]",
+		"use google_cloud_secretmanager_v1::client::SecretManagerService;",
+		`.set_parent("projects/my-project")`,
+		`.set_secret_id("my-secret")`,
+		"tokio_test::block_on(sample())",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in sample:\n%s", want, got)
+		}
+	}
+	if _, err := os.Stat(path.Join(outDir, "examples", "snippet_index.json")); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestRustClient(t *testing.T) {
-	requireProtoc(t)
 	for _, override := range []string{"http-client", "grpc-client"} {
 		outDir := t.TempDir()
 
@@ -219,7 +343,6 @@ func TestRustClient(t *testing.T) {
 }
 
 func TestRustNosvc(t *testing.T) {
-	requireProtoc(t)
 	outDir := t.TempDir()
 
 	cfg := &config.Config{
@@ -257,7 +380,6 @@ func TestRustNosvc(t *testing.T) {
 }
 
 func TestRustModuleRpc(t *testing.T) {
-	requireProtoc(t)
 	outDir := t.TempDir()
 
 	cfg := &config.Config{
@@ -296,7 +418,6 @@ func TestRustModuleRpc(t *testing.T) {
 }
 
 func TestRustBootstrapWkt(t *testing.T) {
-	requireProtoc(t)
 	outDir := t.TempDir()
 
 	cfg := &config.Config{
@@ -347,10 +468,3 @@ func importsModelModules(t *testing.T, filename string) {
 		}
 	}
 }
-
-func requireProtoc(t *testing.T) {
-	t.Helper()
-	if _, err := exec.LookPath("protoc"); err != nil {
-		t.Skip("skipping test because protoc is not installed")
-	}
-}
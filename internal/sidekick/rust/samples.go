@@ -0,0 +1,140 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rust
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+	"github.com/julieqiu/librarianx/internal/sidekick/language"
+)
+
+// sampleAnnotation is the input to the template for a method sample.
+type sampleAnnotation struct {
+	CopyrightYear    string
+	BoilerPlate      []string
+	RegionTag        string
+	PackageNamespace string
+	// The name of the client, e.g. `SecretManagerService`.
+	ClientName string
+	// The name of the method in the client, e.g. `create_secret`.
+	MethodName string
+	// The lines setting the request fields, e.g. `.set_parent("projects/my-project")`.
+	Setters []string
+	IsLRO   bool
+	// True if the method is paginated, the sample iterates over the items.
+	IsPaginated  bool
+	ReturnsEmpty bool
+}
+
+// samples returns the code samples for all the generated methods in the
+// model. The model must be annotated.
+func samples(model *api.API) []language.Snippet {
+	ann := model.Codec.(*modelAnnotations)
+	var snippets []language.Snippet
+	for _, service := range ann.Services {
+		svcAnn := service.Codec.(*serviceAnnotations)
+		// The generated client is not public when there is a handwritten
+		// client surface.
+		if svcAnn.HasVeneer {
+			continue
+		}
+		for _, method := range svcAnn.Methods {
+			methodAnn := method.Codec.(*methodAnnotation)
+			tag := language.RegionTag(method)
+			snippets = append(snippets, language.Snippet{
+				Method:       method,
+				TemplatePath: "templates/example/sample.mustache",
+				OutputPath:   path.Join("examples", fmt.Sprintf("%s_%s.rs", svcAnn.ModuleName, methodAnn.NameNoMangling)),
+				RegionTag:    tag,
+				Data: &sampleAnnotation{
+					CopyrightYear:    ann.CopyrightYear,
+					BoilerPlate:      ann.BoilerPlate,
+					RegionTag:        tag,
+					PackageNamespace: ann.PackageNamespace,
+					ClientName:       svcAnn.Name,
+					MethodName:       methodAnn.Name,
+					Setters:          sampleSetters(model, api.SampleRequest(model, method), "        "),
+					IsLRO:            method.OperationInfo != nil || method.DiscoveryLro != nil,
+					IsPaginated:      method.Pagination != nil,
+					ReturnsEmpty:     method.ReturnsEmpty,
+				},
+			})
+		}
+	}
+	return snippets
+}
+
+// sampleSetters returns the calls to set each field, e.g.
+// `.set_parent("projects/my-project")`, one line per element.
+func sampleSetters(model *api.API, fields []*api.SampleField, indent string) []string {
+	var lines []string
+	for _, f := range fields {
+		value := sampleValue(model, f, indent)
+		if f.Field.Repeated {
+			value = "[" + value + "]"
+		}
+		setter := fmt.Sprintf("%s.set_%s(%s)", indent, f.Field.Codec.(*fieldAnnotations).SetterName, value)
+		lines = append(lines, strings.Split(setter, "\n")...)
+	}
+	return lines
+}
+
+// sampleValue returns the Rust expression for the value of a field. Message
+// values may span multiple lines.
+func sampleValue(model *api.API, f *api.SampleField, indent string) string {
+	switch f.Field.Typez {
+	case api.STRING_TYPE:
+		return strconv.Quote(f.Value)
+	case api.BYTES_TYPE:
+		return fmt.Sprintf("b%s.to_vec()", strconv.Quote(f.Value))
+	case api.BOOL_TYPE:
+		return f.Value
+	case api.ENUM_TYPE:
+		enum := model.State.EnumByID[f.Field.TypezID]
+		return fmt.Sprintf("%s::%s", enum.Codec.(*enumAnnotation).NameInExamples, f.EnumValue.Codec.(*enumValueAnnotation).VariantName)
+	case api.MESSAGE_TYPE:
+		name := model.State.MessageByID[f.Field.TypezID].Codec.(*messageAnnotation).NameInExamples
+		if len(f.Fields) == 0 {
+			return name + "::default()"
+		}
+		nested := sampleSetters(model, f.Fields, indent+"        ")
+		return fmt.Sprintf("\n%s    %s::new()\n%s\n%s", indent, name, strings.Join(nested, "\n"), indent)
+	default:
+		// Use a suffix, the setters are generic and Rust would infer `i32`
+		// for a plain literal.
+		return f.Value + "_" + sampleNumericType(f.Field.Typez)
+	}
+}
+
+func sampleNumericType(typez api.Typez) string {
+	switch typez {
+	case api.INT64_TYPE, api.SINT64_TYPE, api.SFIXED64_TYPE:
+		return "i64"
+	case api.UINT32_TYPE, api.FIXED32_TYPE:
+		return "u32"
+	case api.UINT64_TYPE, api.FIXED64_TYPE:
+		return "u64"
+	case api.FLOAT_TYPE:
+		return "f32"
+	case api.DOUBLE_TYPE:
+		return "f64"
+	default:
+		return "i32"
+	}
+}
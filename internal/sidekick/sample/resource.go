@@ -0,0 +1,226 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sample
+
+import "github.com/julieqiu/librarianx/internal/sidekick/api"
+
+// ResourceAPI returns a sample API for the codec tests. It has a long-running
+// operation, a paginated method, a streaming method, explicit routing, an
+// auto-populated request ID, and nested types. The caller must run
+// `api.CrossReference()` on the result.
+func ResourceAPI() *api.API {
+	state := &api.Enum{
+		Name:    "State",
+		ID:      ".test.v1.State",
+		Package: "test.v1",
+		Values: []*api.EnumValue{
+			{Name: "STATE_UNSPECIFIED", Number: 0},
+			{Name: "ACTIVE", Number: 1},
+		},
+	}
+	labelsEntry := &api.Message{
+		Name:    "LabelsEntry",
+		ID:      ".test.v1.Resource.LabelsEntry",
+		Package: "test.v1",
+		IsMap:   true,
+		Fields: []*api.Field{
+			{Name: "key", JSONName: "key", Typez: api.STRING_TYPE},
+			{Name: "value", JSONName: "value", Typez: api.STRING_TYPE},
+		},
+	}
+	spec := &api.Message{
+		Name:    "Spec",
+		ID:      ".test.v1.Resource.Spec",
+		Package: "test.v1",
+		Fields: []*api.Field{
+			{Name: "replicas", JSONName: "replicas", Typez: api.INT32_TYPE},
+			{Name: "data", JSONName: "data", Typez: api.BYTES_TYPE},
+			{Name: "kind", JSONName: "kind", Typez: api.ENUM_TYPE, TypezID: ".test.v1.Resource.Kind"},
+		},
+	}
+	kind := &api.Enum{
+		Name:    "Kind",
+		ID:      ".test.v1.Resource.Kind",
+		Package: "test.v1",
+		Values: []*api.EnumValue{
+			{Name: "KIND_UNSPECIFIED", Number: 0},
+			{Name: "LARGE", Number: 1},
+		},
+	}
+	resource := &api.Message{
+		Name:          "Resource",
+		ID:            ".test.v1.Resource",
+		Package:       "test.v1",
+		Documentation: "A resource.",
+		Fields: []*api.Field{
+			{Name: "name", JSONName: "name", Typez: api.STRING_TYPE},
+			{Name: "state", JSONName: "state", Typez: api.ENUM_TYPE, TypezID: ".test.v1.State"},
+			{Name: "size", JSONName: "size", Typez: api.INT64_TYPE, Optional: true},
+			{Name: "counts", JSONName: "counts", Typez: api.UINT64_TYPE, Repeated: true},
+			{Name: "labels", JSONName: "labels", Typez: api.MESSAGE_TYPE, TypezID: ".test.v1.Resource.LabelsEntry", Map: true},
+			{Name: "update_time", JSONName: "updateTime", Typez: api.MESSAGE_TYPE, TypezID: ".google.protobuf.Timestamp", Optional: true},
+			{Name: "spec", JSONName: "spec", Typez: api.MESSAGE_TYPE, TypezID: ".test.v1.Resource.Spec", Optional: true},
+			{Name: "from", JSONName: "from", Typez: api.STRING_TYPE},
+		},
+	}
+	createRequest := &api.Message{
+		Name:    "CreateResourceRequest",
+		ID:      ".test.v1.CreateResourceRequest",
+		Package: "test.v1",
+		Fields: []*api.Field{
+			{Name: "parent", JSONName: "parent", Typez: api.STRING_TYPE},
+			{Name: "resource", JSONName: "resource", Typez: api.MESSAGE_TYPE, TypezID: ".test.v1.Resource", Optional: true},
+			{Name: "request_id", JSONName: "requestId", Typez: api.STRING_TYPE},
+			{Name: "validate_only", JSONName: "validateOnly", Typez: api.BOOL_TYPE, Optional: true},
+		},
+	}
+	pageToken := &api.Field{Name: "page_token", JSONName: "pageToken", Typez: api.STRING_TYPE}
+	listRequest := &api.Message{
+		Name:    "ListResourcesRequest",
+		ID:      ".test.v1.ListResourcesRequest",
+		Package: "test.v1",
+		Fields: []*api.Field{
+			{Name: "parent", JSONName: "parent", Typez: api.STRING_TYPE},
+			{Name: "page_size", JSONName: "pageSize", Typez: api.INT32_TYPE},
+			pageToken,
+		},
+	}
+	items := &api.Field{Name: "resources", JSONName: "resources", Typez: api.MESSAGE_TYPE, TypezID: ".test.v1.Resource", Repeated: true}
+	nextPageToken := &api.Field{Name: "next_page_token", JSONName: "nextPageToken", Typez: api.STRING_TYPE}
+	listResponse := &api.Message{
+		Name:       "ListResourcesResponse",
+		ID:         ".test.v1.ListResourcesResponse",
+		Package:    "test.v1",
+		Fields:     []*api.Field{items, nextPageToken},
+		Pagination: &api.PaginationInfo{NextPageToken: nextPageToken, PageableItem: items},
+	}
+	metadata := &api.Message{
+		Name:    "OperationMetadata",
+		ID:      ".test.v1.OperationMetadata",
+		Package: "test.v1",
+		Fields: []*api.Field{
+			{Name: "create_time", JSONName: "createTime", Typez: api.MESSAGE_TYPE, TypezID: ".google.protobuf.Timestamp", Optional: true},
+		},
+	}
+	getOperationRequest := &api.Message{
+		Name:    "GetOperationRequest",
+		ID:      ".google.longrunning.GetOperationRequest",
+		Package: "google.longrunning",
+		Fields: []*api.Field{
+			{Name: "name", JSONName: "name", Typez: api.STRING_TYPE},
+		},
+	}
+	operation := &api.Message{
+		Name:    "Operation",
+		ID:      ".google.longrunning.Operation",
+		Package: "google.longrunning",
+		Fields: []*api.Field{
+			{Name: "name", JSONName: "name", Typez: api.STRING_TYPE},
+			{Name: "done", JSONName: "done", Typez: api.BOOL_TYPE},
+		},
+	}
+
+	create := &api.Method{
+		Name:          "CreateResource",
+		ID:            ".test.v1.ResourceService.CreateResource",
+		Documentation: "Creates a resource.",
+		InputType:     createRequest,
+		InputTypeID:   createRequest.ID,
+		OutputTypeID:  operation.ID,
+		PathInfo: &api.PathInfo{
+			Bindings: []*api.PathBinding{{
+				Verb: "POST",
+				PathTemplate: api.NewPathTemplate().
+					WithLiteral("v1").
+					WithVariable(api.NewPathVariable("parent").WithLiteral("projects").WithMatch()).
+					WithLiteral("resources"),
+				QueryParameters: map[string]bool{"request_id": true, "validate_only": true},
+			}},
+			BodyFieldPath: "resource",
+		},
+		OperationInfo: &api.OperationInfo{
+			ResponseTypeID: resource.ID,
+			MetadataTypeID: metadata.ID,
+		},
+		Routing: []*api.RoutingInfo{{
+			Name: "project",
+			Variants: []*api.RoutingInfoVariant{{
+				FieldPath: []string{"parent"},
+				Matching:  api.RoutingPathSpec{Segments: []string{"projects", "*"}},
+			}},
+		}},
+		AutoPopulated: []*api.Field{createRequest.Fields[2]},
+	}
+	list := &api.Method{
+		Name:         "ListResources",
+		ID:           ".test.v1.ResourceService.ListResources",
+		InputType:    listRequest,
+		InputTypeID:  listRequest.ID,
+		OutputTypeID: listResponse.ID,
+		PathInfo: &api.PathInfo{
+			Bindings: []*api.PathBinding{{
+				Verb: "GET",
+				PathTemplate: api.NewPathTemplate().
+					WithLiteral("v1").
+					WithVariable(api.NewPathVariable("parent").WithLiteral("projects").WithMatch()).
+					WithLiteral("resources"),
+				QueryParameters: map[string]bool{"page_size": true, "page_token": true},
+			}},
+		},
+		Pagination: pageToken,
+	}
+	getOperation := &api.Method{
+		Name:         "GetOperation",
+		ID:           ".test.v1.ResourceService.GetOperation",
+		InputType:    getOperationRequest,
+		InputTypeID:  getOperationRequest.ID,
+		OutputTypeID: operation.ID,
+		PathInfo: &api.PathInfo{
+			Bindings: []*api.PathBinding{{
+				Verb: "GET",
+				PathTemplate: api.NewPathTemplate().
+					WithLiteral("v1").
+					WithVariable(api.NewPathVariable("name").
+						WithLiteral("projects").WithMatch().
+						WithLiteral("operations").WithMatch()),
+			}},
+		},
+	}
+	stream := &api.Method{
+		Name:                "StreamResources",
+		ID:                  ".test.v1.ResourceService.StreamResources",
+		InputType:           listRequest,
+		InputTypeID:         listRequest.ID,
+		OutputTypeID:        resource.ID,
+		ServerSideStreaming: true,
+		PathInfo:            list.PathInfo,
+	}
+	service := &api.Service{
+		Name:        "ResourceService",
+		ID:          ".test.v1.ResourceService",
+		Package:     "test.v1",
+		DefaultHost: "test.googleapis.com",
+		Methods:     []*api.Method{create, list, getOperation, stream},
+	}
+	model := api.NewTestAPI(
+		[]*api.Message{resource, labelsEntry, spec, createRequest, listRequest, listResponse, metadata},
+		[]*api.Enum{state, kind},
+		[]*api.Service{service})
+	model.Title = "Test API"
+	for _, m := range []*api.Message{getOperationRequest, operation} {
+		model.State.MessageByID[m.ID] = m
+	}
+	return model
+}
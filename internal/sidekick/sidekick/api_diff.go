@@ -0,0 +1,129 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sidekick
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"strings"
+
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+	"github.com/julieqiu/librarianx/internal/sidekick/config"
+)
+
+var (
+	baseSourceOpts = map[string]string{}
+	diffFormat     string
+)
+
+func init() {
+	newCommand(
+		"sidekick api-diff",
+		"Reports the changes in the API surface of a client library.",
+		`
+Parses the specification for a single client library at two revisions of its
+sources, and reports the differences between the two models. The new revision
+uses the configuration parameters saved in the .sidekick.toml file, and any
+-source-option flags. The base revision uses the same configuration, with the
+-base-source-option flags applied on top, for example:
+
+  sidekick api-diff -output src/generated/cloud/secretmanager/v1 \
+    -base-source-option googleapis-root=https://github.com/googleapis/googleapis/archive/<old-sha>.tar.gz \
+    -base-source-option googleapis-sha256=<old-sha256>
+
+Each change is classified as breaking or non-breaking. The report is printed to
+stdout, as text or JSON.
+`,
+		cmdSidekick,
+		apiDiff,
+	).
+		addFlagFunc("base-source-option", "source options for the base revision", func(opt string) error {
+			components := strings.SplitN(opt, "=", 2)
+			if len(components) != 2 {
+				return fmt.Errorf("invalid base source option, must be in key=value format (%s)", opt)
+			}
+			baseSourceOpts[components[0]] = components[1]
+			return nil
+		}).
+		addFlagString(&diffFormat, "diff-format", "the output format: text (the default) or json")
+}
+
+// apiDiff compares the API surface of a client library at two revisions of
+// its sources.
+func apiDiff(rootConfig *config.Config, cmdLine *CommandLine) error {
+	if len(baseSourceOpts) == 0 {
+		return fmt.Errorf("must provide at least one -base-source-option")
+	}
+	if diffFormat != "" && diffFormat != "text" && diffFormat != "json" {
+		return fmt.Errorf("unknown -diff-format %q, must be text or json", diffFormat)
+	}
+	baseConfig := *rootConfig
+	baseConfig.Source = maps.Clone(rootConfig.Source)
+	if baseConfig.Source == nil {
+		baseConfig.Source = map[string]string{}
+	}
+	maps.Copy(baseConfig.Source, baseSourceOpts)
+
+	before, err := loadRevision(&baseConfig, cmdLine.Output)
+	if err != nil {
+		return fmt.Errorf("cannot load base revision: %w", err)
+	}
+	after, err := loadRevision(rootConfig, cmdLine.Output)
+	if err != nil {
+		return fmt.Errorf("cannot load new revision: %w", err)
+	}
+	return writeAPIDiff(os.Stdout, diffFormat, api.Diff(before, after))
+}
+
+func loadRevision(rootConfig *config.Config, output string) (*api.API, error) {
+	override, err := overrideSources(rootConfig)
+	if err != nil {
+		return nil, err
+	}
+	model, _, err := loadDir(override, output)
+	return model, err
+}
+
+// writeAPIDiff prints the changes in the given format.
+func writeAPIDiff(w io.Writer, format string, changes []*api.Change) error {
+	if format == "json" {
+		if changes == nil {
+			changes = []*api.Change{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(changes)
+	}
+	breaking := 0
+	for _, c := range changes {
+		classification := "non-breaking"
+		if c.Breaking {
+			classification = "breaking"
+			breaking++
+		}
+		line := fmt.Sprintf("[%s] %s %s %s", classification, c.Kind, c.Element, c.ID)
+		if c.Description != "" {
+			line += ": " + c.Description
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d changes, %d breaking\n", len(changes), breaking)
+	return err
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sidekick
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+	"github.com/julieqiu/librarianx/internal/sidekick/config"
+	"github.com/julieqiu/librarianx/internal/sidekick/parser"
+)
+
+func TestWriteAPIDiff(t *testing.T) {
+	changes := []*api.Change{
+		{Kind: api.Added, Element: "field", ID: ".test.Request.filter"},
+		{Kind: api.Changed, Element: "method", ID: ".test.Service.Get", Breaking: true, Description: "streaming changed from none to server-side"},
+	}
+	for _, test := range []struct {
+		format  string
+		changes []*api.Change
+		want    string
+	}{
+		{"", changes, `[non-breaking] added field .test.Request.filter
+[breaking] changed method .test.Service.Get: streaming changed from none to server-side
+2 changes, 1 breaking
+`},
+		{"text", nil, "0 changes, 0 breaking\n"},
+		{"json", changes[1:], `[
+  {
+    "kind": "changed",
+    "element": "method",
+    "id": ".test.Service.Get",
+    "breaking": true,
+    "description": "streaming changed from none to server-side"
+  }
+]
+`},
+		{"json", nil, "[]\n"},
+	} {
+		var got strings.Builder
+		if err := writeAPIDiff(&got, test.format, test.changes); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(test.want, got.String()); diff != "" {
+			t.Errorf("mismatch for format %q (-want, +got):\n%s", test.format, diff)
+		}
+	}
+}
+
+func TestAPIDiffProtobuf(t *testing.T) {
+	before := parseTestProto(t, `syntax = "proto3";
+package test.v1;
+
+message Resource {
+  string name = 1;
+  int32 size = 2;
+  map<string, string> labels = 3;
+  map<string, int32> counts = 4;
+}
+`)
+	after := parseTestProto(t, `syntax = "proto3";
+package test.v1;
+
+message Resource {
+  string name = 1;
+  int64 size = 2;
+  map<string, int64> labels = 3;
+  map<int32, int32> counts = 4;
+}
+`)
+	want := []*api.Change{
+		{Kind: api.Changed, Element: "field", ID: ".test.v1.Resource.counts", Breaking: true, Description: "type changed from map<string, int32> to map<int32, int32>"},
+		{Kind: api.Changed, Element: "field", ID: ".test.v1.Resource.labels", Breaking: true, Description: "type changed from map<string, string> to map<string, int64>"},
+		{Kind: api.Changed, Element: "field", ID: ".test.v1.Resource.size", Breaking: true, Description: "type changed from int32 to int64"},
+	}
+	if diff := cmp.Diff(want, api.Diff(before, after)); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+// parseTestProto returns the model for a single proto file.
+func parseTestProto(t *testing.T, contents string) *api.API {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.MkdirAll(path.Join(root, "test/v1"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path.Join(root, "test/v1/test.proto"), []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	model, err := parser.CreateModel(&config.Config{
+		General: config.GeneralConfig{
+			SpecificationFormat: "protobuf",
+			SpecificationSource: "test/v1",
+		},
+		Source: map[string]string{"googleapis-root": root},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return model
+}
@@ -0,0 +1,349 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sidekick
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+	"github.com/julieqiu/librarianx/internal/sidekick/config"
+	"github.com/julieqiu/librarianx/internal/sidekick/dart"
+	golang "github.com/julieqiu/librarianx/internal/sidekick/go"
+	"github.com/julieqiu/librarianx/internal/sidekick/python"
+	"github.com/julieqiu/librarianx/internal/sidekick/rust"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	dumpFormat     string
+	dumpElementIDs []string
+)
+
+func init() {
+	newCommand(
+		"sidekick dump-model",
+		"Prints the annotated model for a single client library.",
+		`
+Parses the specification for a single client library, using the configuration
+parameters saved in the .sidekick.toml file, and runs the annotations for its
+language. Then prints the model, including the annotations in the Codec field of
+each element, as JSON or YAML.
+
+Fields with zero values, and empty slices and maps, are omitted. Elements that are printed elsewhere in the
+output, such as the Parent of a message or the InputType of a method, are
+printed as {"$ref": "<element ID>"}.
+
+Use -element-id to print only some elements, for example:
+
+  sidekick dump-model -output src/generated/cloud/secretmanager/v1 \
+    -element-id .google.cloud.secretmanager.v1.SecretManagerService.GetSecret
+`,
+		cmdSidekick,
+		dumpModel,
+	).
+		addFlagString(&dumpFormat, "dump-format", "the output format: json (the default) or yaml").
+		addFlagFunc("element-id", "only print the element with this ID, can be repeated", func(id string) error {
+			dumpElementIDs = append(dumpElementIDs, id)
+			return nil
+		})
+}
+
+// dumpModel prints the annotated model for one directory.
+func dumpModel(rootConfig *config.Config, cmdLine *CommandLine) error {
+	override, err := overrideSources(rootConfig)
+	if err != nil {
+		return err
+	}
+	model, config, err := loadDir(override, cmdLine.Output)
+	if err != nil {
+		return err
+	}
+	if err := annotateForLanguage(model, config); err != nil {
+		return err
+	}
+	return writeModelDump(os.Stdout, dumpFormat, model, dumpElementIDs)
+}
+
+func annotateForLanguage(model *api.API, config *config.Config) error {
+	switch config.General.Language {
+	case "rust":
+		return rust.Annotate(model, config)
+	case "dart":
+		return dart.Annotate(model, config)
+	case "go":
+		return golang.Annotate(model, config)
+	case "python":
+		return python.Annotate(model, config)
+	default:
+		return fmt.Errorf("dump-model does not support language %q, must be rust, dart, go, or python", config.General.Language)
+	}
+}
+
+// writeModelDump prints the model, or the elements with the given IDs, in the
+// given format.
+func writeModelDump(w io.Writer, format string, model *api.API, ids []string) error {
+	var value any
+	if len(ids) == 0 {
+		value = newModelDumper().root(model)
+	} else {
+		elements, err := findElements(model, ids)
+		if err != nil {
+			return err
+		}
+		var result []any
+		for _, e := range elements {
+			result = append(result, newModelDumper().root(e))
+		}
+		value = result
+	}
+	switch format {
+	case "", "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	case "yaml":
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(value); err != nil {
+			return err
+		}
+		return encoder.Close()
+	default:
+		return fmt.Errorf("unknown -dump-format %q, must be json or yaml", format)
+	}
+}
+
+// findElements returns the elements with the given IDs, in the same order.
+func findElements(model *api.API, ids []string) ([]any, error) {
+	byID := map[string]any{}
+	var addEnum func(e *api.Enum)
+	addEnum = func(e *api.Enum) {
+		byID[e.ID] = e
+		for _, v := range e.Values {
+			byID[v.ID] = v
+		}
+	}
+	var addMessage func(m *api.Message)
+	addMessage = func(m *api.Message) {
+		byID[m.ID] = m
+		for _, f := range m.Fields {
+			byID[f.ID] = f
+		}
+		for _, o := range m.OneOfs {
+			byID[o.ID] = o
+		}
+		for _, e := range m.Enums {
+			addEnum(e)
+		}
+		for _, c := range m.Messages {
+			addMessage(c)
+		}
+	}
+	for _, s := range model.Services {
+		byID[s.ID] = s
+		for _, m := range s.Methods {
+			byID[m.ID] = m
+		}
+	}
+	for _, m := range model.Messages {
+		addMessage(m)
+	}
+	for _, e := range model.Enums {
+		addEnum(e)
+	}
+	var result []any
+	for _, id := range ids {
+		e, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("cannot find element %q in the model", id)
+		}
+		result = append(result, e)
+	}
+	return result, nil
+}
+
+// modelDumper converts the model into maps, slices, and scalars that can be
+// encoded as JSON or YAML.
+//
+// The model has many back-pointers, e.g. `Method.Model`, `Method.Service`, and
+// `Message.Parent`. It also has cross-references, e.g. `Field.MessageType`.
+// Model elements (anything with an ID) are printed only where the model owns
+// them, that is, in the slices of the `api` types, e.g. `API.Messages` or
+// `Message.Fields`, and only once. Other references to elements, including
+// those in the codec annotations, are printed as `{"$ref": "<element ID>"}`.
+type modelDumper struct {
+	// The elements already printed.
+	printed map[pointerKey]bool
+	// The pointers being printed, used to break cycles in pointers that are
+	// not model elements, e.g. in the codec annotations.
+	active map[pointerKey]bool
+}
+
+type pointerKey struct {
+	t reflect.Type
+	p uintptr
+}
+
+func newModelDumper() *modelDumper {
+	return &modelDumper{printed: map[pointerKey]bool{}, active: map[pointerKey]bool{}}
+}
+
+// root converts an element, which is printed even if the model does not own
+// it.
+func (d *modelDumper) root(element any) any {
+	return d.value(reflect.ValueOf(element), true)
+}
+
+func (d *modelDumper) value(v reflect.Value, owned bool) any {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return d.value(v.Elem(), owned)
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		key := pointerKey{t: v.Type(), p: v.Pointer()}
+		if id, ok := elementID(v); ok {
+			if !owned || d.printed[key] {
+				return dumpObject{{name: "$ref", value: id}}
+			}
+			d.printed[key] = true
+			return d.value(v.Elem(), false)
+		}
+		if d.active[key] {
+			return dumpObject{{name: "$ref", value: "(cycle)"}}
+		}
+		d.active[key] = true
+		defer delete(d.active, key)
+		return d.value(v.Elem(), false)
+	case reflect.Struct:
+		return d.structValue(v)
+	case reflect.Slice, reflect.Array:
+		result := []any{}
+		for i := range v.Len() {
+			result = append(result, d.value(v.Index(i), owned))
+		}
+		return result
+	case reflect.Map:
+		result := map[string]any{}
+		iter := v.MapRange()
+		for iter.Next() {
+			result[fmt.Sprint(iter.Key().Interface())] = d.value(iter.Value(), false)
+		}
+		return result
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return nil
+	default:
+		return v.Interface()
+	}
+}
+
+func (d *modelDumper) structValue(v reflect.Value) any {
+	result := dumpObject{}
+	owner := v.Type().PkgPath() == reflect.TypeFor[api.API]().PkgPath()
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		// `State` only indexes the elements printed elsewhere.
+		if v.Type() == reflect.TypeFor[api.API]() && field.Name == "State" {
+			continue
+		}
+		value := v.Field(i)
+		if value.IsZero() {
+			continue
+		}
+		switch value.Kind() {
+		case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+			continue
+		case reflect.Slice, reflect.Map:
+			if value.Len() == 0 {
+				continue
+			}
+		}
+		owned := owner && value.Kind() == reflect.Slice
+		result = append(result, dumpField{name: field.Name, value: d.value(value, owned)})
+	}
+	return result
+}
+
+// elementID returns the ID of model elements. The model itself is also an
+// element, with a fixed ID.
+func elementID(v reflect.Value) (string, bool) {
+	if v.Type() == reflect.TypeFor[*api.API]() {
+		return "(model)", true
+	}
+	s := v.Elem()
+	if s.Kind() != reflect.Struct {
+		return "", false
+	}
+	id := s.FieldByName("ID")
+	if !id.IsValid() || id.Kind() != reflect.String {
+		return "", false
+	}
+	return id.String(), true
+}
+
+// dumpObject preserves the order of the struct fields in the output.
+type dumpObject []dumpField
+
+type dumpField struct {
+	name  string
+	value any
+}
+
+func (o dumpObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range o {
+		if i != 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(f.name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (o dumpObject) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, f := range o {
+		value := &yaml.Node{}
+		if err := value.Encode(f.value); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: f.name}, value)
+	}
+	return node, nil
+}
@@ -0,0 +1,247 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sidekick
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/julieqiu/librarianx/internal/sidekick/api"
+	"github.com/julieqiu/librarianx/internal/sidekick/config"
+	"github.com/julieqiu/librarianx/internal/sidekick/parser"
+	"gopkg.in/yaml.v3"
+)
+
+type testAnnotation struct {
+	Name     string
+	Self     *testAnnotation
+	Messages []*api.Message
+}
+
+func newDumpTestModel(t *testing.T) *api.API {
+	child := &api.Message{Name: "Child", ID: ".test.Parent.Child", Package: "test"}
+	parent := &api.Message{
+		Name:     "Parent",
+		ID:       ".test.Parent",
+		Package:  "test",
+		Messages: []*api.Message{child},
+		Fields: []*api.Field{
+			{Name: "child", ID: ".test.Parent.child", Typez: api.MESSAGE_TYPE, TypezID: child.ID},
+		},
+	}
+	method := &api.Method{Name: "Get", ID: ".test.Service.Get", InputTypeID: parent.ID, OutputTypeID: child.ID}
+	service := &api.Service{Name: "Service", ID: ".test.Service", Package: "test", Methods: []*api.Method{method}}
+	model := api.NewTestAPI([]*api.Message{parent}, []*api.Enum{}, []*api.Service{service})
+	child.Parent = parent
+	model.State.MessageByID[child.ID] = child
+	annotation := &testAnnotation{Name: "annotation", Messages: []*api.Message{parent, child}}
+	annotation.Self = annotation
+	method.Codec = annotation
+	if err := api.CrossReference(model); err != nil {
+		t.Fatal(err)
+	}
+	return model
+}
+
+func TestWriteModelDump(t *testing.T) {
+	model := newDumpTestModel(t)
+	var got strings.Builder
+	if err := writeModelDump(&got, "json", model, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "Name": "Test",
+  "PackageName": "test",
+  "Services": [
+    {
+      "Name": "Service",
+      "ID": ".test.Service",
+      "Methods": [
+        {
+          "Name": "Get",
+          "ID": ".test.Service.Get",
+          "InputTypeID": ".test.Parent",
+          "InputType": {
+            "$ref": ".test.Parent"
+          },
+          "OutputTypeID": ".test.Parent.Child",
+          "OutputType": {
+            "$ref": ".test.Parent.Child"
+          },
+          "Model": {
+            "$ref": "(model)"
+          },
+          "Service": {
+            "$ref": ".test.Service"
+          },
+          "SourceService": {
+            "$ref": ".test.Service"
+          },
+          "Codec": {
+            "Name": "annotation",
+            "Self": {
+              "$ref": "(cycle)"
+            },
+            "Messages": [
+              {
+                "$ref": ".test.Parent"
+              },
+              {
+                "$ref": ".test.Parent.Child"
+              }
+            ]
+          }
+        }
+      ],
+      "Package": "test",
+      "Model": {
+        "$ref": "(model)"
+      }
+    }
+  ],
+  "Messages": [
+    {
+      "Name": "Parent",
+      "ID": ".test.Parent",
+      "Fields": [
+        {
+          "Name": "child",
+          "ID": ".test.Parent.child",
+          "Typez": 11,
+          "TypezID": ".test.Parent.Child",
+          "Parent": {
+            "$ref": ".test.Parent"
+          },
+          "MessageType": {
+            "$ref": ".test.Parent.Child"
+          }
+        }
+      ],
+      "Messages": [
+        {
+          "Name": "Child",
+          "ID": ".test.Parent.Child",
+          "Parent": {
+            "$ref": ".test.Parent"
+          },
+          "Package": "test"
+        }
+      ],
+      "Package": "test"
+    }
+  ]
+}
+`
+	if diff := cmp.Diff(want, got.String()); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestWriteModelDumpElements(t *testing.T) {
+	model := newDumpTestModel(t)
+	var got strings.Builder
+	if err := writeModelDump(&got, "yaml", model, []string{".test.Parent.child", ".test.Parent.Child"}); err != nil {
+		t.Fatal(err)
+	}
+	want := `- Name: child
+  ID: .test.Parent.child
+  Typez: 11
+  TypezID: .test.Parent.Child
+  Parent:
+    $ref: .test.Parent
+  MessageType:
+    $ref: .test.Parent.Child
+- Name: Child
+  ID: .test.Parent.Child
+  Parent:
+    $ref: .test.Parent
+  Package: test
+`
+	if diff := cmp.Diff(want, got.String()); diff != "" {
+		t.Errorf("mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestWriteModelDumpErrors(t *testing.T) {
+	model := newDumpTestModel(t)
+	if err := writeModelDump(&strings.Builder{}, "json", model, []string{".test.Missing"}); err == nil {
+		t.Errorf("expected an error for a missing element")
+	}
+	if err := writeModelDump(&strings.Builder{}, "xml", model, nil); err == nil {
+		t.Errorf("expected an error for an unknown format")
+	}
+}
+
+func TestDumpAnnotatedModel(t *testing.T) {
+	for _, test := range []struct {
+		language string
+		codec    map[string]string
+	}{
+		{"rust", map[string]string{}},
+		{"dart", map[string]string{
+			"api-keys-environment-variables": "GOOGLE_API_KEY",
+			"issue-tracker-url":              "http://www.example.com/issues",
+			"package:http":                   "^4.5.6",
+			"package:google_cloud_rpc":       "^1.2.3",
+			"package:google_cloud_location":  "^7.8.9",
+			"package:google_cloud_protobuf":  "^0.1.2",
+			"proto:google.protobuf":          "package:google_cloud_protobuf/protobuf.dart",
+			"proto:google.cloud.location":    "package:google_cloud_location/location.dart",
+		}},
+		{"go", map[string]string{}},
+		{"python", map[string]string{}},
+	} {
+		t.Run(test.language, func(t *testing.T) {
+			cfg := &config.Config{
+				General: config.GeneralConfig{
+					Language:            test.language,
+					SpecificationFormat: "openapi",
+					SpecificationSource: specificationSource,
+					ServiceConfig:       path.Join(testdataDir, secretManagerServiceConfig),
+				},
+				Source: map[string]string{"googleapis-root": googleapisRoot},
+				Codec:  test.codec,
+			}
+			model, err := parser.CreateModel(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := annotateForLanguage(model, cfg); err != nil {
+				t.Fatal(err)
+			}
+			for _, format := range []string{"json", "yaml"} {
+				var got strings.Builder
+				if err := writeModelDump(&got, format, model, nil); err != nil {
+					t.Fatal(err)
+				}
+				var parsed map[string]any
+				if format == "json" {
+					err = json.Unmarshal([]byte(got.String()), &parsed)
+				} else {
+					err = yaml.Unmarshal([]byte(got.String()), &parsed)
+				}
+				if err != nil {
+					t.Fatalf("cannot parse %s output: %v", format, err)
+				}
+				if _, ok := parsed["Codec"]; !ok {
+					t.Errorf("missing model annotations in %s output", format)
+				}
+			}
+		})
+	}
+}
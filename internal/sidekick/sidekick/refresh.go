@@ -22,7 +22,9 @@ import (
 	"github.com/julieqiu/librarianx/internal/sidekick/codec_sample"
 	"github.com/julieqiu/librarianx/internal/sidekick/config"
 	"github.com/julieqiu/librarianx/internal/sidekick/dart"
+	golang "github.com/julieqiu/librarianx/internal/sidekick/go"
 	"github.com/julieqiu/librarianx/internal/sidekick/parser"
+	"github.com/julieqiu/librarianx/internal/sidekick/python"
 	"github.com/julieqiu/librarianx/internal/sidekick/rust"
 	"github.com/julieqiu/librarianx/internal/sidekick/rust_prost"
 )
@@ -96,6 +98,10 @@ func refreshDir(rootConfig *config.Config, cmdLine *CommandLine, output string)
 		return rust_prost.Generate(model, output, config)
 	case "dart":
 		return dart.Generate(model, output, config)
+	case "go":
+		return golang.Generate(model, output, config)
+	case "python":
+		return python.Generate(model, output, config)
 	case "sample":
 		return codec_sample.Generate(model, output, config)
 	default:
@@ -30,7 +30,7 @@ var cmdSidekick = newCommand(
 	nil, // nil parent is only allowed for the root command
 	nil).
 	addFlagString(&flagProjectRoot, "project-root", "the root of the output project").
-	addFlagString(&format, "specification-format", "the specification format. Protobuf or OpenAPI v3.").
+	addFlagString(&format, "specification-format", "the specification format: protobuf, descriptor-set, openapi or disco.").
 	addFlagString(&source, "specification-source", "the path to the input data").
 	addFlagString(&serviceConfig, "service-config", "path to service config").
 	addFlagString(&output, "output", "the path within project-root to put generated files").
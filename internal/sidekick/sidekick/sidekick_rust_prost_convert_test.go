@@ -20,7 +20,6 @@ import (
 )
 
 func TestRustProstConvert(t *testing.T) {
-	requireProtoc(t)
 	outDir := t.TempDir()
 
 	type TestConfig struct {
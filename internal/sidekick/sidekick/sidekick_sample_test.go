@@ -21,7 +21,6 @@ import (
 )
 
 func TestSampleFromProtobuf(t *testing.T) {
-	requireProtoc(t)
 	outDir := t.TempDir()
 	svcConfig := path.Join(testdataDir, "googleapis/google/type/type.yaml")
 	specificationSource := path.Join(testdataDir, "googleapis/google/type")